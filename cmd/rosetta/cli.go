@@ -144,6 +144,177 @@ VERSION:
 		Value: 200000,
 	}
 
+	cliFlagGasLimitNFTTransfer = cli.Uint64Flag{
+		Name:  "gas-limit-nft-transfer",
+		Usage: "Specifies the necessary gas limit for a transfer of SFTs, NFTs or MetaESDTs - tokens with a nonce (for transaction construction).",
+		Value: 1000000,
+	}
+
+	cliFlagEstimateGasWithObserver = cli.BoolFlag{
+		Name:  "estimate-gas-with-observer",
+		Usage: "Whether to estimate the gas limit of transfers by simulating them on the observer (for transaction construction). Contract interactions are always estimated this way.",
+	}
+
+	cliFlagGasEstimationSafetyMargin = cli.Float64Flag{
+		Name:  "gas-estimation-safety-margin",
+		Usage: "Specifies the safety margin (as a fraction, e.g. 0.1 for 10%) to be added on top of the gas limit estimated by the observer (for transaction construction).",
+		Value: 0.1,
+	}
+
+	cliFlagSimulateBeforeSubmit = cli.BoolFlag{
+		Name:  "simulate-before-submit",
+		Usage: "Whether to simulate transactions (on the observer) before broadcasting them. Transactions that are predicted to fail are not broadcasted.",
+	}
+
+	cliFlagNonceFromMempool = cli.BoolFlag{
+		Name:  "nonce-from-mempool",
+		Usage: "Whether to consider the transactions of the sender in the observer's mempool when deciding the nonce (for transaction construction). The nonce is the highest one in the mempool, plus one (if greater than the account nonce).",
+	}
+
+	cliFlagReserveNonces = cli.BoolFlag{
+		Name:  "reserve-nonces",
+		Usage: "Whether to reserve nonces in-process (for transaction construction), so that concurrent constructions for the same sender get consecutive nonces.",
+	}
+
+	cliFlagNonceReservationTTLSeconds = cli.UintFlag{
+		Name:  "nonce-reservation-ttl-seconds",
+		Usage: "Specifies for how long (in seconds) a nonce stays reserved (see 'reserve-nonces').",
+		Value: 120,
+	}
+
+	cliFlagTrackSubmittedTransactions = cli.BoolFlag{
+		Name:  "track-submitted-transactions",
+		Usage: "Whether to track the transactions submitted through Rosetta, until their final status (pending, success, fail or invalid) is known.",
+	}
+
+	cliFlagTrackerMaxNumTransactions = cli.UintFlag{
+		Name:  "tracker-max-num-transactions",
+		Usage: "Specifies the maximum number of tracked transactions (see 'track-submitted-transactions'). When exceeded, the oldest ones are forgotten.",
+		Value: 10000,
+	}
+
+	cliFlagTrackerPollIntervalSeconds = cli.UintFlag{
+		Name:  "tracker-poll-interval-seconds",
+		Usage: "Specifies how often (in seconds) the observer is polled for the status of the tracked transactions (see 'track-submitted-transactions').",
+		Value: 6,
+	}
+
+	cliFlagTrackerWebhookUrl = cli.StringFlag{
+		Name:  "tracker-webhook-url",
+		Usage: "If set, the final status of each tracked transaction is POSTed (as JSON) to this URL (see 'track-submitted-transactions').",
+		Value: "",
+	}
+
+	cliFlagSubmitDedupWindowSeconds = cli.UintFlag{
+		Name:  "submit-dedup-window-seconds",
+		Usage: "Specifies for how long (in seconds) a successfully submitted transaction is remembered, so that re-submissions (e.g. retries) aren't broadcasted again. Zero disables deduplication.",
+		Value: 60,
+	}
+
+	cliFlagSubmitAuditLog = cli.StringFlag{
+		Name:  "submit-audit-log",
+		Usage: "If set, each submitted transaction (along with the timestamp, the outcome and the response of the observer) is appended to this file (JSON lines).",
+		Value: "",
+	}
+
+	cliFlagSubmitAuditLogMaxSizeMB = cli.UintFlag{
+		Name:  "submit-audit-log-max-size-mb",
+		Usage: "Specifies the size (in megabytes) at which the audit log (see 'submit-audit-log') is rotated.",
+		Value: 100,
+	}
+
+	cliFlagStrictEvents = cli.BoolFlag{
+		Name:  "strict-events",
+		Usage: "Whether to fail (instead of skipping, logging and counting) on unknown events, if they are balance-affecting (see 'balance-affecting-events'). Malformed events (handled by Rosetta) always cause a failure.",
+	}
+
+	cliFlagBalanceAffectingEvents = cli.StringFlag{
+		Name:  "balance-affecting-events",
+		Usage: "Specifies (comma-separated) the identifiers of events considered to be balance-affecting, in addition to the ones handled by Rosetta (see 'strict-events').",
+		Value: "",
+	}
+
+	cliFlagCheckInvariants = cli.StringFlag{
+		Name:  "check-invariants",
+		Usage: "Specifies (comma-separated) the invariants to check on transformed blocks: transfersNetToZero, feeWithinGasLimit, signsOfAmounts, uniqueTransactionsInBlock (see 'strict-invariants').",
+		Value: "",
+	}
+
+	cliFlagStrictInvariants = cli.BoolFlag{
+		Name:  "strict-invariants",
+		Usage: "Whether to fail (instead of logging and counting) on violated invariants (see 'check-invariants').",
+	}
+
+	cliFlagReconcileBalances = cli.BoolFlag{
+		Name:  "reconcile-balances",
+		Usage: "Whether to reconcile (in the background) the balances of accounts seen in recent blocks against their operations. Drifts are reported through logs and through the endpoint '/diagnostics/reconciliation'.",
+	}
+
+	cliFlagReconcilerIntervalSeconds = cli.UintFlag{
+		Name:  "reconciler-interval-seconds",
+		Usage: "Specifies how often (in seconds) a reconciliation round is started (see 'reconcile-balances').",
+		Value: 60,
+	}
+
+	cliFlagReconcilerNumBlocks = cli.UintFlag{
+		Name:  "reconciler-num-blocks",
+		Usage: "Specifies the number of (most recent, final) blocks whose operations are reconciled in a round (see 'reconcile-balances').",
+		Value: 10,
+	}
+
+	cliFlagReconcilerMaxNumAccounts = cli.UintFlag{
+		Name:  "reconciler-max-num-accounts",
+		Usage: "Specifies the maximum number of balances (account and currency), randomly sampled, to reconcile in a round (see 'reconcile-balances').",
+		Value: 10,
+	}
+
+	cliFlagReconcilerMaxRequestsPerSecond = cli.UintFlag{
+		Name:  "reconciler-max-requests-per-second",
+		Usage: "Specifies the maximum number of requests per second the reconciler issues against the observer (see 'reconcile-balances').",
+		Value: 5,
+	}
+
+	cliFlagEnableExplainEndpoint = cli.BoolFlag{
+		Name:  "enable-explain-endpoint",
+		Usage: "Whether to expose a (debug) endpoint that explains how the transactions of a block are transformed into Rosetta transactions.",
+	}
+
+	cliFlagGasLimitDelegate = cli.UintFlag{
+		Name:  "gas-limit-delegate",
+		Usage: "Specifies the gas limit for delegating to a staking provider (for transaction construction).",
+		Value: 12000000,
+	}
+
+	cliFlagGasLimitUndelegate = cli.UintFlag{
+		Name:  "gas-limit-undelegate",
+		Usage: "Specifies the gas limit for undelegating from a staking provider (for transaction construction).",
+		Value: 12000000,
+	}
+
+	cliFlagGasLimitClaimRewards = cli.UintFlag{
+		Name:  "gas-limit-claim-rewards",
+		Usage: "Specifies the gas limit for claiming delegation rewards (for transaction construction).",
+		Value: 6000000,
+	}
+
+	cliFlagGasLimitWithdraw = cli.UintFlag{
+		Name:  "gas-limit-withdraw",
+		Usage: "Specifies the gas limit for withdrawing undelegated funds (for transaction construction).",
+		Value: 12000000,
+	}
+
+	cliFlagGasLimitReDelegateRewards = cli.UintFlag{
+		Name:  "gas-limit-redelegate-rewards",
+		Usage: "Specifies the gas limit for redelegating rewards (for transaction construction).",
+		Value: 12000000,
+	}
+
+	cliFlagGasLimitCreateDelegationContract = cli.UintFlag{
+		Name:  "gas-limit-create-delegation-contract",
+		Usage: "Specifies the gas limit for creating a new delegation contract, through the delegation manager (for transaction construction).",
+		Value: 60000000,
+	}
+
 	cliFlagNativeCurrencySymbol = cli.StringFlag{
 		Name:  "native-currency",
 		Usage: "Specifies the symbol of the native currency (must be EGLD for mainnet, XeGLD for testnet and devnet).",
@@ -215,6 +386,36 @@ func getAllCliFlags() []cli.Flag {
 		cliFlagGasPerDataByte,
 		cliFlagGasPriceModifier,
 		cliFlagGasLimitCustomTransfer,
+		cliFlagGasLimitNFTTransfer,
+		cliFlagEstimateGasWithObserver,
+		cliFlagGasEstimationSafetyMargin,
+		cliFlagSimulateBeforeSubmit,
+		cliFlagNonceFromMempool,
+		cliFlagReserveNonces,
+		cliFlagNonceReservationTTLSeconds,
+		cliFlagTrackSubmittedTransactions,
+		cliFlagTrackerMaxNumTransactions,
+		cliFlagTrackerPollIntervalSeconds,
+		cliFlagTrackerWebhookUrl,
+		cliFlagSubmitDedupWindowSeconds,
+		cliFlagSubmitAuditLog,
+		cliFlagSubmitAuditLogMaxSizeMB,
+		cliFlagStrictEvents,
+		cliFlagBalanceAffectingEvents,
+		cliFlagCheckInvariants,
+		cliFlagStrictInvariants,
+		cliFlagReconcileBalances,
+		cliFlagReconcilerIntervalSeconds,
+		cliFlagReconcilerNumBlocks,
+		cliFlagReconcilerMaxNumAccounts,
+		cliFlagReconcilerMaxRequestsPerSecond,
+		cliFlagEnableExplainEndpoint,
+		cliFlagGasLimitDelegate,
+		cliFlagGasLimitUndelegate,
+		cliFlagGasLimitClaimRewards,
+		cliFlagGasLimitWithdraw,
+		cliFlagGasLimitReDelegateRewards,
+		cliFlagGasLimitCreateDelegationContract,
 		cliFlagNativeCurrencySymbol,
 		cliFlagFirstHistoricalEpoch,
 		cliFlagNumHistoricalEpochs,
@@ -227,67 +428,127 @@ func getAllCliFlags() []cli.Flag {
 }
 
 type parsedCliFlags struct {
-	port                        int
-	offline                     bool
-	logLevel                    string
-	logsFolder                  string
-	observerActualShard         uint32
-	observerProjectedShard      uint32
-	observerProjectedShardIsSet bool
-	observerHttpUrl             string
-	blockchainName              string
-	networkID                   string
-	networkName                 string
-	numShards                   uint32
-	genesisBlock                string
-	genesisTimestamp            int64
-	minGasPrice                 uint64
-	minGasLimit                 uint64
-	extraGasLimitGuardedTx      uint64
-	extraGasLimitRelayedTxV3    uint64
-	gasPerDataByte              uint64
-	gasPriceModifier            float64
-	gasLimitCustomTransfer      uint64
-	nativeCurrencySymbol        string
-	firstHistoricalEpoch        uint32
-	numHistoricalEpochs         uint32
-	shouldHandleContracts       bool
-	configFileCustomCurrencies  string
-	activationEpochSirius       uint32
-	activationEpochSpica        uint32
-	shouldEnablePprofEndpoints  bool
+	port                             int
+	offline                          bool
+	logLevel                         string
+	logsFolder                       string
+	observerActualShard              uint32
+	observerProjectedShard           uint32
+	observerProjectedShardIsSet      bool
+	observerHttpUrl                  string
+	blockchainName                   string
+	networkID                        string
+	networkName                      string
+	numShards                        uint32
+	genesisBlock                     string
+	genesisTimestamp                 int64
+	minGasPrice                      uint64
+	minGasLimit                      uint64
+	extraGasLimitGuardedTx           uint64
+	extraGasLimitRelayedTxV3         uint64
+	gasPerDataByte                   uint64
+	gasPriceModifier                 float64
+	gasLimitCustomTransfer           uint64
+	gasLimitNFTTransfer              uint64
+	estimateGasWithObserver          bool
+	gasEstimationSafetyMargin        float64
+	simulateBeforeSubmit             bool
+	nonceFromMempool                 bool
+	reserveNonces                    bool
+	nonceReservationTTLSeconds       uint32
+	trackSubmittedTransactions       bool
+	trackerMaxNumTransactions        uint32
+	trackerPollIntervalSeconds       uint32
+	trackerWebhookUrl                string
+	submitDedupWindowSeconds         uint32
+	submitAuditLog                   string
+	submitAuditLogMaxSizeMB          uint32
+	strictEvents                     bool
+	balanceAffectingEvents           []string
+	checkInvariants                  []string
+	strictInvariants                 bool
+	reconcileBalances                bool
+	reconcilerIntervalSeconds        uint32
+	reconcilerNumBlocks              uint32
+	reconcilerMaxNumAccounts         uint32
+	reconcilerMaxRequestsPerSecond   uint32
+	enableExplainEndpoint            bool
+	gasLimitDelegate                 uint64
+	gasLimitUndelegate               uint64
+	gasLimitClaimRewards             uint64
+	gasLimitWithdraw                 uint64
+	gasLimitReDelegateRewards        uint64
+	gasLimitCreateDelegationContract uint64
+	nativeCurrencySymbol             string
+	firstHistoricalEpoch             uint32
+	numHistoricalEpochs              uint32
+	shouldHandleContracts            bool
+	configFileCustomCurrencies       string
+	activationEpochSirius            uint32
+	activationEpochSpica             uint32
+	shouldEnablePprofEndpoints       bool
 }
 
 func getParsedCliFlags(ctx *cli.Context) parsedCliFlags {
 	return parsedCliFlags{
-		port:                        ctx.GlobalInt(cliFlagPort.Name),
-		offline:                     ctx.GlobalBool(cliFlagOffline.Name),
-		logLevel:                    ctx.GlobalString(cliFlagLogLevel.Name),
-		logsFolder:                  ctx.GlobalString(cliFlagLogsFolder.Name),
-		observerActualShard:         uint32(ctx.GlobalUint(cliFlagObserverActualShard.Name)),
-		observerProjectedShard:      uint32(ctx.GlobalUint(cliFlagObserverProjectedShard.Name)),
-		observerProjectedShardIsSet: ctx.GlobalIsSet(cliFlagObserverProjectedShard.Name),
-		observerHttpUrl:             ctx.GlobalString(cliFlagObserverHttpUrl.Name),
-		blockchainName:              ctx.GlobalString(cliFlagBlockchainName.Name),
-		networkID:                   ctx.GlobalString(cliFlagNetworkID.Name),
-		networkName:                 ctx.GlobalString(cliFlagNetworkName.Name),
-		numShards:                   uint32(ctx.GlobalUint(cliFlagNumShards.Name)),
-		genesisBlock:                ctx.GlobalString(cliFlagGenesisBlock.Name),
-		genesisTimestamp:            ctx.GlobalInt64(cliFlagGenesisTimestamp.Name),
-		minGasPrice:                 ctx.GlobalUint64(cliFlagMinGasPrice.Name),
-		minGasLimit:                 ctx.GlobalUint64(cliFlagMinGasLimit.Name),
-		extraGasLimitGuardedTx:      ctx.GlobalUint64(cliFlagExtraGasLimitGuardedTx.Name),
-		extraGasLimitRelayedTxV3:    ctx.GlobalUint64(cliFlagExtraGasLimitRelayedTxV3.Name),
-		gasPerDataByte:              ctx.GlobalUint64(cliFlagGasPerDataByte.Name),
-		gasPriceModifier:            ctx.GlobalFloat64(cliFlagGasPriceModifier.Name),
-		gasLimitCustomTransfer:      ctx.GlobalUint64(cliFlagGasLimitCustomTransfer.Name),
-		nativeCurrencySymbol:        ctx.GlobalString(cliFlagNativeCurrencySymbol.Name),
-		firstHistoricalEpoch:        uint32(ctx.GlobalUint(cliFlagFirstHistoricalEpoch.Name)),
-		numHistoricalEpochs:         uint32(ctx.GlobalUint(cliFlagNumHistoricalEpochs.Name)),
-		shouldHandleContracts:       ctx.GlobalBool(cliFlagShouldHandleContracts.Name),
-		configFileCustomCurrencies:  ctx.GlobalString(cliFlagConfigFileCustomCurrencies.Name),
-		activationEpochSirius:       uint32(ctx.GlobalUint(cliFlagActivationEpochSirius.Name)),
-		activationEpochSpica:        uint32(ctx.GlobalUint(cliFlagActivationEpochSpica.Name)),
-		shouldEnablePprofEndpoints:  ctx.GlobalBool(cliFlagShouldEnablePprofEndpoints.Name),
+		port:                             ctx.GlobalInt(cliFlagPort.Name),
+		offline:                          ctx.GlobalBool(cliFlagOffline.Name),
+		logLevel:                         ctx.GlobalString(cliFlagLogLevel.Name),
+		logsFolder:                       ctx.GlobalString(cliFlagLogsFolder.Name),
+		observerActualShard:              uint32(ctx.GlobalUint(cliFlagObserverActualShard.Name)),
+		observerProjectedShard:           uint32(ctx.GlobalUint(cliFlagObserverProjectedShard.Name)),
+		observerProjectedShardIsSet:      ctx.GlobalIsSet(cliFlagObserverProjectedShard.Name),
+		observerHttpUrl:                  ctx.GlobalString(cliFlagObserverHttpUrl.Name),
+		blockchainName:                   ctx.GlobalString(cliFlagBlockchainName.Name),
+		networkID:                        ctx.GlobalString(cliFlagNetworkID.Name),
+		networkName:                      ctx.GlobalString(cliFlagNetworkName.Name),
+		numShards:                        uint32(ctx.GlobalUint(cliFlagNumShards.Name)),
+		genesisBlock:                     ctx.GlobalString(cliFlagGenesisBlock.Name),
+		genesisTimestamp:                 ctx.GlobalInt64(cliFlagGenesisTimestamp.Name),
+		minGasPrice:                      ctx.GlobalUint64(cliFlagMinGasPrice.Name),
+		minGasLimit:                      ctx.GlobalUint64(cliFlagMinGasLimit.Name),
+		extraGasLimitGuardedTx:           ctx.GlobalUint64(cliFlagExtraGasLimitGuardedTx.Name),
+		extraGasLimitRelayedTxV3:         ctx.GlobalUint64(cliFlagExtraGasLimitRelayedTxV3.Name),
+		gasPerDataByte:                   ctx.GlobalUint64(cliFlagGasPerDataByte.Name),
+		gasPriceModifier:                 ctx.GlobalFloat64(cliFlagGasPriceModifier.Name),
+		gasLimitCustomTransfer:           ctx.GlobalUint64(cliFlagGasLimitCustomTransfer.Name),
+		gasLimitNFTTransfer:              ctx.GlobalUint64(cliFlagGasLimitNFTTransfer.Name),
+		estimateGasWithObserver:          ctx.GlobalBool(cliFlagEstimateGasWithObserver.Name),
+		gasEstimationSafetyMargin:        ctx.GlobalFloat64(cliFlagGasEstimationSafetyMargin.Name),
+		simulateBeforeSubmit:             ctx.GlobalBool(cliFlagSimulateBeforeSubmit.Name),
+		nonceFromMempool:                 ctx.GlobalBool(cliFlagNonceFromMempool.Name),
+		reserveNonces:                    ctx.GlobalBool(cliFlagReserveNonces.Name),
+		nonceReservationTTLSeconds:       uint32(ctx.GlobalUint(cliFlagNonceReservationTTLSeconds.Name)),
+		trackSubmittedTransactions:       ctx.GlobalBool(cliFlagTrackSubmittedTransactions.Name),
+		trackerMaxNumTransactions:        uint32(ctx.GlobalUint(cliFlagTrackerMaxNumTransactions.Name)),
+		trackerPollIntervalSeconds:       uint32(ctx.GlobalUint(cliFlagTrackerPollIntervalSeconds.Name)),
+		trackerWebhookUrl:                ctx.GlobalString(cliFlagTrackerWebhookUrl.Name),
+		submitDedupWindowSeconds:         uint32(ctx.GlobalUint(cliFlagSubmitDedupWindowSeconds.Name)),
+		submitAuditLog:                   ctx.GlobalString(cliFlagSubmitAuditLog.Name),
+		submitAuditLogMaxSizeMB:          uint32(ctx.GlobalUint(cliFlagSubmitAuditLogMaxSizeMB.Name)),
+		strictEvents:                     ctx.GlobalBool(cliFlagStrictEvents.Name),
+		balanceAffectingEvents:           parseCommaSeparatedList(ctx.GlobalString(cliFlagBalanceAffectingEvents.Name)),
+		checkInvariants:                  parseCommaSeparatedList(ctx.GlobalString(cliFlagCheckInvariants.Name)),
+		strictInvariants:                 ctx.GlobalBool(cliFlagStrictInvariants.Name),
+		reconcileBalances:                ctx.GlobalBool(cliFlagReconcileBalances.Name),
+		reconcilerIntervalSeconds:        uint32(ctx.GlobalUint(cliFlagReconcilerIntervalSeconds.Name)),
+		reconcilerNumBlocks:              uint32(ctx.GlobalUint(cliFlagReconcilerNumBlocks.Name)),
+		reconcilerMaxNumAccounts:         uint32(ctx.GlobalUint(cliFlagReconcilerMaxNumAccounts.Name)),
+		reconcilerMaxRequestsPerSecond:   uint32(ctx.GlobalUint(cliFlagReconcilerMaxRequestsPerSecond.Name)),
+		enableExplainEndpoint:            ctx.GlobalBool(cliFlagEnableExplainEndpoint.Name),
+		gasLimitDelegate:                 ctx.GlobalUint64(cliFlagGasLimitDelegate.Name),
+		gasLimitUndelegate:               ctx.GlobalUint64(cliFlagGasLimitUndelegate.Name),
+		gasLimitClaimRewards:             ctx.GlobalUint64(cliFlagGasLimitClaimRewards.Name),
+		gasLimitWithdraw:                 ctx.GlobalUint64(cliFlagGasLimitWithdraw.Name),
+		gasLimitReDelegateRewards:        ctx.GlobalUint64(cliFlagGasLimitReDelegateRewards.Name),
+		gasLimitCreateDelegationContract: ctx.GlobalUint64(cliFlagGasLimitCreateDelegationContract.Name),
+		nativeCurrencySymbol:             ctx.GlobalString(cliFlagNativeCurrencySymbol.Name),
+		firstHistoricalEpoch:             uint32(ctx.GlobalUint(cliFlagFirstHistoricalEpoch.Name)),
+		numHistoricalEpochs:              uint32(ctx.GlobalUint(cliFlagNumHistoricalEpochs.Name)),
+		shouldHandleContracts:            ctx.GlobalBool(cliFlagShouldHandleContracts.Name),
+		configFileCustomCurrencies:       ctx.GlobalString(cliFlagConfigFileCustomCurrencies.Name),
+		activationEpochSirius:            uint32(ctx.GlobalUint(cliFlagActivationEpochSirius.Name)),
+		activationEpochSpica:             uint32(ctx.GlobalUint(cliFlagActivationEpochSpica.Name)),
+		shouldEnablePprofEndpoints:       ctx.GlobalBool(cliFlagShouldEnablePprofEndpoints.Name),
 	}
 }
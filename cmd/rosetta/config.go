@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/multiversx/mx-chain-rosetta/server/resources"
 )
@@ -31,3 +32,17 @@ func loadConfigOfCustomCurrencies(configFile string) ([]resources.Currency, erro
 
 	return customCurrencies, nil
 }
+
+// parseCommaSeparatedList splits the given value by commas, trimming the items and dropping the empty ones
+func parseCommaSeparatedList(value string) []string {
+	items := make([]string, 0)
+
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if len(item) > 0 {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
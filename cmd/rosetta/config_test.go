@@ -48,3 +48,9 @@ func TestLoadConfigOfCustomCurrencies(t *testing.T) {
 		require.ErrorContains(t, err, "error when loading custom currencies from file")
 	})
 }
+
+func TestParseCommaSeparatedList(t *testing.T) {
+	require.Equal(t, []string{}, parseCommaSeparatedList(""))
+	require.Equal(t, []string{"a"}, parseCommaSeparatedList("a"))
+	require.Equal(t, []string{"a", "b", "c"}, parseCommaSeparatedList(" a, b,,c ,"))
+}
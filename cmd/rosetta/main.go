@@ -10,6 +10,7 @@ import (
 
 	"github.com/coinbase/rosetta-sdk-go/server"
 	"github.com/multiversx/mx-chain-rosetta/server/factory"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
 	"github.com/multiversx/mx-chain-rosetta/version"
 	"github.com/urfave/cli"
 )
@@ -61,30 +62,62 @@ func startRosetta(ctx *cli.Context) error {
 	log.Info("Starting Rosetta...", "middleware", version.RosettaMiddlewareVersion, "specification", version.RosettaVersion)
 
 	networkProvider, err := factory.CreateNetworkProvider(factory.ArgsCreateNetworkProvider{
-		IsOffline:                   cliFlags.offline,
-		NumShards:                   cliFlags.numShards,
-		ObservedActualShard:         cliFlags.observerActualShard,
-		ObservedProjectedShard:      cliFlags.observerProjectedShard,
-		ObservedProjectedShardIsSet: cliFlags.observerProjectedShardIsSet,
-		ObserverUrl:                 cliFlags.observerHttpUrl,
-		BlockchainName:              cliFlags.blockchainName,
-		NetworkID:                   cliFlags.networkID,
-		NetworkName:                 cliFlags.networkName,
-		GasPerDataByte:              cliFlags.gasPerDataByte,
-		GasPriceModifier:            cliFlags.gasPriceModifier,
-		GasLimitCustomTransfer:      cliFlags.gasLimitCustomTransfer,
-		MinGasPrice:                 cliFlags.minGasPrice,
-		MinGasLimit:                 cliFlags.minGasLimit,
-		ExtraGasLimitGuardedTx:      cliFlags.extraGasLimitGuardedTx,
-		ExtraGasLimitRelayedTxV3:    cliFlags.extraGasLimitRelayedTxV3,
-		NativeCurrencySymbol:        cliFlags.nativeCurrencySymbol,
-		CustomCurrencies:            customCurrencies,
-		GenesisBlockHash:            cliFlags.genesisBlock,
-		FirstHistoricalEpoch:        cliFlags.firstHistoricalEpoch,
-		NumHistoricalEpochs:         cliFlags.numHistoricalEpochs,
-		ShouldHandleContracts:       cliFlags.shouldHandleContracts,
-		ActivationEpochSirius:       cliFlags.activationEpochSirius,
-		ActivationEpochSpica:        cliFlags.activationEpochSpica,
+		IsOffline:                      cliFlags.offline,
+		NumShards:                      cliFlags.numShards,
+		ObservedActualShard:            cliFlags.observerActualShard,
+		ObservedProjectedShard:         cliFlags.observerProjectedShard,
+		ObservedProjectedShardIsSet:    cliFlags.observerProjectedShardIsSet,
+		ObserverUrl:                    cliFlags.observerHttpUrl,
+		BlockchainName:                 cliFlags.blockchainName,
+		NetworkID:                      cliFlags.networkID,
+		NetworkName:                    cliFlags.networkName,
+		GasPerDataByte:                 cliFlags.gasPerDataByte,
+		GasPriceModifier:               cliFlags.gasPriceModifier,
+		GasLimitCustomTransfer:         cliFlags.gasLimitCustomTransfer,
+		GasLimitNFTTransfer:            cliFlags.gasLimitNFTTransfer,
+		MinGasPrice:                    cliFlags.minGasPrice,
+		MinGasLimit:                    cliFlags.minGasLimit,
+		ExtraGasLimitGuardedTx:         cliFlags.extraGasLimitGuardedTx,
+		ExtraGasLimitRelayedTxV3:       cliFlags.extraGasLimitRelayedTxV3,
+		EstimateGasWithObserver:        cliFlags.estimateGasWithObserver,
+		GasEstimationSafetyMargin:      cliFlags.gasEstimationSafetyMargin,
+		SimulateBeforeSubmit:           cliFlags.simulateBeforeSubmit,
+		UseMempoolNonce:                cliFlags.nonceFromMempool,
+		ReserveNonces:                  cliFlags.reserveNonces,
+		NonceReservationTTLSeconds:     cliFlags.nonceReservationTTLSeconds,
+		TrackSubmittedTxs:              cliFlags.trackSubmittedTransactions,
+		TrackerMaxNumTxs:               cliFlags.trackerMaxNumTransactions,
+		TrackerPollIntervalSeconds:     cliFlags.trackerPollIntervalSeconds,
+		TrackerWebhookUrl:              cliFlags.trackerWebhookUrl,
+		SubmitDedupWindowSeconds:       cliFlags.submitDedupWindowSeconds,
+		SubmitAuditLogPath:             cliFlags.submitAuditLog,
+		SubmitAuditLogMaxSizeMB:        cliFlags.submitAuditLogMaxSizeMB,
+		StrictEvents:                   cliFlags.strictEvents,
+		BalanceAffectingEvents:         cliFlags.balanceAffectingEvents,
+		CheckedInvariants:              cliFlags.checkInvariants,
+		StrictInvariants:               cliFlags.strictInvariants,
+		ReconcileBalances:              cliFlags.reconcileBalances,
+		ReconcilerIntervalSeconds:      cliFlags.reconcilerIntervalSeconds,
+		ReconcilerNumBlocks:            cliFlags.reconcilerNumBlocks,
+		ReconcilerMaxNumAccounts:       cliFlags.reconcilerMaxNumAccounts,
+		ReconcilerMaxRequestsPerSecond: cliFlags.reconcilerMaxRequestsPerSecond,
+		EnableExplainEndpoint:          cliFlags.enableExplainEndpoint,
+		DelegationGasLimits: resources.DelegationGasLimits{
+			Delegate:                 cliFlags.gasLimitDelegate,
+			Undelegate:               cliFlags.gasLimitUndelegate,
+			ClaimRewards:             cliFlags.gasLimitClaimRewards,
+			Withdraw:                 cliFlags.gasLimitWithdraw,
+			ReDelegateRewards:        cliFlags.gasLimitReDelegateRewards,
+			CreateDelegationContract: cliFlags.gasLimitCreateDelegationContract,
+		},
+		NativeCurrencySymbol:  cliFlags.nativeCurrencySymbol,
+		CustomCurrencies:      customCurrencies,
+		GenesisBlockHash:      cliFlags.genesisBlock,
+		FirstHistoricalEpoch:  cliFlags.firstHistoricalEpoch,
+		NumHistoricalEpochs:   cliFlags.numHistoricalEpochs,
+		ShouldHandleContracts: cliFlags.shouldHandleContracts,
+		ActivationEpochSirius: cliFlags.activationEpochSirius,
+		ActivationEpochSpica:  cliFlags.activationEpochSpica,
 	})
 	if err != nil {
 		return err
@@ -92,7 +125,7 @@ func startRosetta(ctx *cli.Context) error {
 
 	networkProvider.LogDescription()
 
-	controllers, err := factory.CreateControllers(networkProvider)
+	controllers, closers, err := factory.CreateControllers(networkProvider)
 	if err != nil {
 		return err
 	}
@@ -125,6 +158,11 @@ func startRosetta(ctx *cli.Context) error {
 	defer cancel()
 	_ = httpServer.Shutdown(shutdownContext)
 	_ = httpServer.Close()
+
+	for _, closer := range closers {
+		_ = closer.Close()
+	}
+
 	_ = fileLogging.Close()
 
 	return nil
@@ -1,15 +1,19 @@
 package factory
 
 import (
+	"io"
+
 	"github.com/coinbase/rosetta-sdk-go/asserter"
 	"github.com/coinbase/rosetta-sdk-go/server"
 	"github.com/coinbase/rosetta-sdk-go/types"
 	"github.com/multiversx/mx-chain-rosetta/server/services"
 )
 
-func CreateControllers(networkProvider services.NetworkProvider) ([]server.Router, error) {
+// CreateControllers creates the controllers, along with the background components they rely on (to be closed on shutdown)
+func CreateControllers(networkProvider services.NetworkProvider) ([]server.Router, []io.Closer, error) {
 	if networkProvider.IsOffline() {
-		return createOfflineControllers(networkProvider)
+		controllers, err := createOfflineControllers(networkProvider)
+		return controllers, nil, err
 	}
 
 	return createOnlineControllers(networkProvider)
@@ -32,7 +36,9 @@ func createOfflineControllers(networkProvider services.NetworkProvider) ([]serve
 	blockController := server.NewBlockAPIController(offlineService, asserterInstance)
 	mempoolController := server.NewMempoolAPIController(offlineService, asserterInstance)
 
-	constructionService := services.NewConstructionService(networkProvider)
+	nonces := services.NewNonceReservations(networkProvider)
+	submitter := services.NewTransactionsSubmitter(networkProvider)
+	constructionService := services.NewConstructionService(networkProvider, nonces, submitter)
 	constructionController := server.NewConstructionAPIController(constructionService, asserterInstance)
 
 	return []server.Router{
@@ -44,12 +50,12 @@ func createOfflineControllers(networkProvider services.NetworkProvider) ([]serve
 	}, nil
 }
 
-func createOnlineControllers(networkProvider services.NetworkProvider) ([]server.Router, error) {
+func createOnlineControllers(networkProvider services.NetworkProvider) ([]server.Router, []io.Closer, error) {
 	log.Info("createOnlineControllers()")
 
 	asserterInstance, err := createAsserter(networkProvider)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	networkService := services.NewNetworkService(networkProvider)
@@ -58,22 +64,77 @@ func createOnlineControllers(networkProvider services.NetworkProvider) ([]server
 	accountService := services.NewAccountService(networkProvider)
 	accountController := server.NewAccountAPIController(accountService, asserterInstance)
 
-	blockService := services.NewBlockService(networkProvider)
+	txsTransformer, err := services.NewTransactionsTransformer(networkProvider)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	blockService := services.NewBlockService(networkProvider, txsTransformer)
 	blockController := server.NewBlockAPIController(blockService, asserterInstance)
 
 	mempoolService := services.NewMempoolService(networkProvider)
 	mempoolController := server.NewMempoolAPIController(mempoolService, asserterInstance)
 
-	constructionService := services.NewConstructionService(networkProvider)
+	nonces := services.NewNonceReservations(networkProvider)
+	submitter := services.NewTransactionsSubmitter(networkProvider)
+	constructionService := services.NewConstructionService(networkProvider, nonces, submitter)
 	constructionController := server.NewConstructionAPIController(constructionService, asserterInstance)
 
-	return []server.Router{
+	controllers := []server.Router{
 		networkController,
 		accountController,
 		blockController,
 		mempoolController,
 		constructionController,
-	}, nil
+		services.NewBatchSubmitController(submitter),
+		services.NewEventsDiagnosticsController(txsTransformer),
+	}
+
+	closers := make([]io.Closer, 0)
+
+	if networkProvider.GetNetworkConfig().ShouldReserveNonces {
+		controllers = append(controllers, services.NewNonceReservationsController(networkProvider, nonces))
+	}
+
+	auditLogPath := networkProvider.GetNetworkConfig().SubmitAuditLogPath
+	if len(auditLogPath) > 0 {
+		auditLog, err := services.NewSubmissionsAuditLog(auditLogPath, networkProvider.GetNetworkConfig().SubmitAuditLogMaxSizeMB)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		submitter.UseSubmissionsAuditLog(auditLog)
+		closers = append(closers, auditLog)
+		controllers = append(controllers, services.NewSubmissionsAuditController(auditLog))
+	}
+
+	if networkProvider.GetNetworkConfig().ShouldTrackSubmittedTxs {
+		tracker := services.NewSubmittedTransactionsTracker(networkProvider)
+		tracker.Start()
+
+		submitter.UseSubmittedTransactionsTracker(tracker)
+		closers = append(closers, tracker)
+		controllers = append(controllers, services.NewSubmittedTransactionsController(tracker))
+	}
+
+	if len(networkProvider.GetNetworkConfig().CheckedInvariants) > 0 {
+		controllers = append(controllers, services.NewInvariantsDiagnosticsController(txsTransformer))
+	}
+
+	if networkProvider.GetNetworkConfig().ShouldReconcileBalances {
+		reconciler := services.NewBalancesReconciler(networkProvider, txsTransformer)
+		reconciler.Start()
+
+		closers = append(closers, reconciler)
+		controllers = append(controllers, services.NewReconciliationController(reconciler))
+	}
+
+	if networkProvider.GetNetworkConfig().ShouldEnableExplainEndpoint {
+		explainer := services.NewBlockExplainer(networkProvider, txsTransformer)
+		controllers = append(controllers, services.NewBlockExplainController(explainer))
+	}
+
+	return controllers, closers, nil
 }
 
 func createAsserter(networkProvider services.NetworkProvider) (*asserter.Asserter, error) {
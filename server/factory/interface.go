@@ -23,18 +23,27 @@ type NetworkProvider interface {
 	GetGenesisBalances() ([]*resources.GenesisBalance, error)
 	GetNodeStatus() (*resources.AggregatedNodeStatus, error)
 	GetBlockByNonce(nonce uint64) (*api.Block, error)
+	GetRawBlockByNonce(nonce uint64) (*api.Block, error)
 	GetBlockByHash(hash string) (*api.Block, error)
 	GetAccount(address string) (*resources.AccountOnBlock, error)
+	GetAccountGuardianData(address string) (*resources.AccountGuardianDataOnBlock, error)
+	GetHighestMempoolNonceOfSender(address string) (uint64, bool, error)
+	ResolveUsername(username string) (string, error)
 	GetAccountBalance(address string, tokenIdentifier string, options resources.AccountQueryOptions) (*resources.AccountBalanceOnBlock, error)
 	IsAddressObserved(address string) (bool, error)
 	ComputeShardIdOfPubKey(pubkey []byte) uint32
 	ConvertPubKeyToAddress(pubkey []byte) string
 	ConvertAddressToPubKey(address string) ([]byte, error)
 	SendTransaction(tx *data.Transaction) (string, error)
+	SendTransactions(txs []*data.Transaction) (map[int]string, error)
 	ComputeTransactionHash(tx *data.Transaction) (string, error)
+	ComputeTransactionCost(tx *data.Transaction) (*resources.TransactionCost, error)
+	SimulateTransaction(tx *data.Transaction) (*transaction.SimulationResults, error)
 	ComputeReceiptHash(apiReceipt *transaction.ApiReceipt) (string, error)
 	ComputeTransactionFeeForMoveBalance(tx *transaction.ApiTransactionResult) *big.Int
 	GetMempoolTransactionByHash(hash string) (*transaction.ApiTransactionResult, error)
+	GetTransactionByHash(hash string) (*transaction.ApiTransactionResult, error)
+	GetProcessedTransactionStatus(hash string) (transaction.TxStatus, error)
 	IsReleaseSiriusActive(epoch uint32) bool
 	IsReleaseSpicaActive(epoch uint32) bool
 	LogDescription()
@@ -27,31 +27,56 @@ const (
 )
 
 type ArgsCreateNetworkProvider struct {
-	IsOffline                   bool
-	NumShards                   uint32
-	ObservedActualShard         uint32
-	ObservedProjectedShard      uint32
-	ObservedProjectedShardIsSet bool
-	ObserverUrl                 string
-	BlockchainName              string
-	NetworkID                   string
-	NetworkName                 string
-	GasPerDataByte              uint64
-	GasPriceModifier            float64
-	GasLimitCustomTransfer      uint64
-	MinGasPrice                 uint64
-	MinGasLimit                 uint64
-	ExtraGasLimitGuardedTx      uint64
-	ExtraGasLimitRelayedTxV3    uint64
-	NativeCurrencySymbol        string
-	CustomCurrencies            []resources.Currency
-	GenesisBlockHash            string
-	GenesisTimestamp            int64
-	FirstHistoricalEpoch        uint32
-	NumHistoricalEpochs         uint32
-	ShouldHandleContracts       bool
-	ActivationEpochSirius       uint32
-	ActivationEpochSpica        uint32
+	IsOffline                      bool
+	NumShards                      uint32
+	ObservedActualShard            uint32
+	ObservedProjectedShard         uint32
+	ObservedProjectedShardIsSet    bool
+	ObserverUrl                    string
+	BlockchainName                 string
+	NetworkID                      string
+	NetworkName                    string
+	GasPerDataByte                 uint64
+	GasPriceModifier               float64
+	GasLimitCustomTransfer         uint64
+	GasLimitNFTTransfer            uint64
+	MinGasPrice                    uint64
+	MinGasLimit                    uint64
+	ExtraGasLimitGuardedTx         uint64
+	ExtraGasLimitRelayedTxV3       uint64
+	EstimateGasWithObserver        bool
+	GasEstimationSafetyMargin      float64
+	SimulateBeforeSubmit           bool
+	UseMempoolNonce                bool
+	ReserveNonces                  bool
+	NonceReservationTTLSeconds     uint32
+	TrackSubmittedTxs              bool
+	TrackerMaxNumTxs               uint32
+	TrackerPollIntervalSeconds     uint32
+	TrackerWebhookUrl              string
+	SubmitDedupWindowSeconds       uint32
+	SubmitAuditLogPath             string
+	SubmitAuditLogMaxSizeMB        uint32
+	StrictEvents                   bool
+	BalanceAffectingEvents         []string
+	CheckedInvariants              []string
+	StrictInvariants               bool
+	ReconcileBalances              bool
+	ReconcilerIntervalSeconds      uint32
+	ReconcilerNumBlocks            uint32
+	ReconcilerMaxNumAccounts       uint32
+	ReconcilerMaxRequestsPerSecond uint32
+	EnableExplainEndpoint          bool
+	DelegationGasLimits            resources.DelegationGasLimits
+	NativeCurrencySymbol           string
+	CustomCurrencies               []resources.Currency
+	GenesisBlockHash               string
+	GenesisTimestamp               int64
+	FirstHistoricalEpoch           uint32
+	NumHistoricalEpochs            uint32
+	ShouldHandleContracts          bool
+	ActivationEpochSirius          uint32
+	ActivationEpochSpica           uint32
 }
 
 // CreateNetworkProvider creates a network provider
@@ -127,30 +152,55 @@ func CreateNetworkProvider(args ArgsCreateNetworkProvider) (NetworkProvider, err
 	}
 
 	return provider.NewNetworkProvider(provider.ArgsNewNetworkProvider{
-		IsOffline:                   args.IsOffline,
-		ObservedActualShard:         args.ObservedActualShard,
-		ObservedProjectedShard:      args.ObservedProjectedShard,
-		ObservedProjectedShardIsSet: args.ObservedProjectedShardIsSet,
-		ObserverUrl:                 args.ObserverUrl,
-		BlockchainName:              args.BlockchainName,
-		NetworkID:                   args.NetworkID,
-		NetworkName:                 args.NetworkName,
-		GasPerDataByte:              args.GasPerDataByte,
-		GasPriceModifier:            args.GasPriceModifier,
-		GasLimitCustomTransfer:      args.GasLimitCustomTransfer,
-		MinGasPrice:                 args.MinGasPrice,
-		MinGasLimit:                 args.MinGasLimit,
-		ExtraGasLimitGuardedTx:      args.ExtraGasLimitGuardedTx,
-		ExtraGasLimitRelayedTxV3:    args.ExtraGasLimitRelayedTxV3,
-		NativeCurrencySymbol:        args.NativeCurrencySymbol,
-		CustomCurrencies:            args.CustomCurrencies,
-		GenesisBlockHash:            args.GenesisBlockHash,
-		GenesisTimestamp:            args.GenesisTimestamp,
-		FirstHistoricalEpoch:        args.FirstHistoricalEpoch,
-		NumHistoricalEpochs:         args.NumHistoricalEpochs,
-		ShouldHandleContracts:       args.ShouldHandleContracts,
-		ActivationEpochSirius:       args.ActivationEpochSirius,
-		ActivationEpochSpica:        args.ActivationEpochSpica,
+		IsOffline:                      args.IsOffline,
+		ObservedActualShard:            args.ObservedActualShard,
+		ObservedProjectedShard:         args.ObservedProjectedShard,
+		ObservedProjectedShardIsSet:    args.ObservedProjectedShardIsSet,
+		ObserverUrl:                    args.ObserverUrl,
+		BlockchainName:                 args.BlockchainName,
+		NetworkID:                      args.NetworkID,
+		NetworkName:                    args.NetworkName,
+		GasPerDataByte:                 args.GasPerDataByte,
+		GasPriceModifier:               args.GasPriceModifier,
+		GasLimitCustomTransfer:         args.GasLimitCustomTransfer,
+		GasLimitNFTTransfer:            args.GasLimitNFTTransfer,
+		MinGasPrice:                    args.MinGasPrice,
+		MinGasLimit:                    args.MinGasLimit,
+		ExtraGasLimitGuardedTx:         args.ExtraGasLimitGuardedTx,
+		ExtraGasLimitRelayedTxV3:       args.ExtraGasLimitRelayedTxV3,
+		EstimateGasWithObserver:        args.EstimateGasWithObserver,
+		GasEstimationSafetyMargin:      args.GasEstimationSafetyMargin,
+		SimulateBeforeSubmit:           args.SimulateBeforeSubmit,
+		UseMempoolNonce:                args.UseMempoolNonce,
+		ReserveNonces:                  args.ReserveNonces,
+		NonceReservationTTLSeconds:     args.NonceReservationTTLSeconds,
+		TrackSubmittedTxs:              args.TrackSubmittedTxs,
+		TrackerMaxNumTxs:               args.TrackerMaxNumTxs,
+		TrackerPollIntervalSeconds:     args.TrackerPollIntervalSeconds,
+		TrackerWebhookUrl:              args.TrackerWebhookUrl,
+		SubmitDedupWindowSeconds:       args.SubmitDedupWindowSeconds,
+		SubmitAuditLogPath:             args.SubmitAuditLogPath,
+		SubmitAuditLogMaxSizeMB:        args.SubmitAuditLogMaxSizeMB,
+		StrictEvents:                   args.StrictEvents,
+		BalanceAffectingEvents:         args.BalanceAffectingEvents,
+		CheckedInvariants:              args.CheckedInvariants,
+		StrictInvariants:               args.StrictInvariants,
+		ReconcileBalances:              args.ReconcileBalances,
+		ReconcilerIntervalSeconds:      args.ReconcilerIntervalSeconds,
+		ReconcilerNumBlocks:            args.ReconcilerNumBlocks,
+		ReconcilerMaxNumAccounts:       args.ReconcilerMaxNumAccounts,
+		ReconcilerMaxRequestsPerSecond: args.ReconcilerMaxRequestsPerSecond,
+		EnableExplainEndpoint:          args.EnableExplainEndpoint,
+		DelegationGasLimits:            args.DelegationGasLimits,
+		NativeCurrencySymbol:           args.NativeCurrencySymbol,
+		CustomCurrencies:               args.CustomCurrencies,
+		GenesisBlockHash:               args.GenesisBlockHash,
+		GenesisTimestamp:               args.GenesisTimestamp,
+		FirstHistoricalEpoch:           args.FirstHistoricalEpoch,
+		NumHistoricalEpochs:            args.NumHistoricalEpochs,
+		ShouldHandleContracts:          args.ShouldHandleContracts,
+		ActivationEpochSirius:          args.ActivationEpochSirius,
+		ActivationEpochSpica:           args.ActivationEpochSpica,
 
 		ObserverFacade: &components.ObserverFacade{
 			Processor:            baseProcessor,
@@ -28,6 +28,60 @@ func (provider *networkProvider) GetAccount(address string) (*resources.AccountO
 	return data, nil
 }
 
+// GetAccountGuardianData gets the guardian data (e.g. whether the account is guarded, the active guardian) of an account, on the latest final block
+func (provider *networkProvider) GetAccountGuardianData(address string) (*resources.AccountGuardianDataOnBlock, error) {
+	url := buildUrlGetAccountGuardianData(address)
+	response := &resources.AccountGuardianDataApiResponse{}
+
+	err := provider.getResource(url, response)
+	if err != nil {
+		return nil, newErrCannotGetAccount(address, err)
+	}
+
+	data := &response.Data
+
+	log.Trace("GetAccountGuardianData()",
+		"address", address,
+		"guarded", data.GuardianData.Guarded,
+		"block", data.BlockCoordinates.Nonce,
+		"blockHash", data.BlockCoordinates.Hash,
+	)
+
+	return data, nil
+}
+
+// GetHighestMempoolNonceOfSender gets the highest nonce among the transactions of the sender, in the observer's mempool.
+// It also returns whether the sender has any transaction in the mempool.
+func (provider *networkProvider) GetHighestMempoolNonceOfSender(address string) (uint64, bool, error) {
+	url := buildUrlGetTransactionsPoolForSender(address)
+	response := &resources.TransactionsPoolForSenderApiResponse{}
+
+	err := provider.getResource(url, response)
+	if err != nil {
+		return 0, false, newErrCannotGetMempoolOfSender(address, err)
+	}
+
+	transactions := response.Data.TxPool.Transactions
+	if len(transactions) == 0 {
+		return 0, false, nil
+	}
+
+	highestNonce := uint64(0)
+	for _, tx := range transactions {
+		if tx.TxFields.Nonce > highestNonce {
+			highestNonce = tx.TxFields.Nonce
+		}
+	}
+
+	log.Trace("GetHighestMempoolNonceOfSender()",
+		"address", address,
+		"numTransactions", len(transactions),
+		"highestNonce", highestNonce,
+	)
+
+	return highestNonce, true, nil
+}
+
 // GetAccountNativeBalance gets the native balance by address
 func (provider *networkProvider) GetAccountBalance(address string, tokenIdentifier string, options resources.AccountQueryOptions) (*resources.AccountBalanceOnBlock, error) {
 	isNativeBalance := tokenIdentifier == provider.nativeCurrency.Symbol
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/multiversx/mx-chain-core-go/data/api"
 	"github.com/multiversx/mx-chain-rosetta/server/resources"
 	"github.com/multiversx/mx-chain-rosetta/testscommon"
 	"github.com/stretchr/testify/require"
@@ -53,6 +54,101 @@ func TestNetworkProvider_GetAccount(t *testing.T) {
 	})
 }
 
+func TestNetworkProvider_GetHighestMempoolNonceOfSender(t *testing.T) {
+	observerFacade := testscommon.NewObserverFacadeMock()
+	args := createDefaultArgsNewNetworkProvider()
+	args.ObserverFacade = observerFacade
+
+	provider, err := NewNetworkProvider(args)
+	require.Nil(t, err)
+	require.NotNil(t, provider)
+
+	t.Run("with success", func(t *testing.T) {
+		observerFacade.MockNextError = nil
+		observerFacade.MockGetResponse = resources.TransactionsPoolForSenderApiResponse{
+			Data: resources.TransactionsPoolForSenderApiResponsePayload{
+				TxPool: resources.TransactionsPoolForSender{
+					Transactions: []resources.TransactionInPool{
+						{TxFields: resources.TransactionInPoolFields{Nonce: 42}},
+						{TxFields: resources.TransactionInPoolFields{Nonce: 44}},
+						{TxFields: resources.TransactionInPoolFields{Nonce: 43}},
+					},
+				},
+			},
+		}
+
+		nonce, found, err := provider.GetHighestMempoolNonceOfSender(testscommon.TestAddressAlice)
+		require.Nil(t, err)
+		require.True(t, found)
+		require.Equal(t, uint64(44), nonce)
+		require.Equal(t, args.ObserverUrl, observerFacade.RecordedBaseUrl)
+		require.Equal(t, "/transaction/pool?by-sender=erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th&fields=nonce", observerFacade.RecordedPath)
+	})
+
+	t.Run("with success (no transactions in mempool)", func(t *testing.T) {
+		observerFacade.MockNextError = nil
+		observerFacade.MockGetResponse = resources.TransactionsPoolForSenderApiResponse{}
+
+		nonce, found, err := provider.GetHighestMempoolNonceOfSender(testscommon.TestAddressAlice)
+		require.Nil(t, err)
+		require.False(t, found)
+		require.Equal(t, uint64(0), nonce)
+	})
+
+	t.Run("with error", func(t *testing.T) {
+		observerFacade.MockNextError = errors.New("arbitrary error")
+		observerFacade.MockGetResponse = nil
+
+		_, found, err := provider.GetHighestMempoolNonceOfSender(testscommon.TestAddressAlice)
+		require.ErrorIs(t, err, errCannotGetMempoolOfSender)
+		require.False(t, found)
+	})
+}
+
+func TestNetworkProvider_GetAccountGuardianData(t *testing.T) {
+	observerFacade := testscommon.NewObserverFacadeMock()
+	args := createDefaultArgsNewNetworkProvider()
+	args.ObserverFacade = observerFacade
+
+	provider, err := NewNetworkProvider(args)
+	require.Nil(t, err)
+	require.NotNil(t, provider)
+
+	t.Run("with success", func(t *testing.T) {
+		observerFacade.MockNextError = nil
+		observerFacade.MockGetResponse = resources.AccountGuardianDataApiResponse{
+			Data: resources.AccountGuardianDataOnBlock{
+				GuardianData: api.GuardianData{
+					ActiveGuardian: &api.Guardian{
+						Address:         testscommon.TestAddressBob,
+						ActivationEpoch: 42,
+					},
+					Guarded: true,
+				},
+				BlockCoordinates: resources.BlockCoordinates{
+					Nonce: 1000,
+				},
+			},
+		}
+
+		guardianData, err := provider.GetAccountGuardianData(testscommon.TestAddressAlice)
+		require.Nil(t, err)
+		require.True(t, guardianData.GuardianData.Guarded)
+		require.Equal(t, testscommon.TestAddressBob, guardianData.GuardianData.ActiveGuardian.Address)
+		require.Equal(t, uint64(1000), guardianData.BlockCoordinates.Nonce)
+		require.Equal(t, "/address/erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th/guardian-data?onFinalBlock=true", observerFacade.RecordedPath)
+	})
+
+	t.Run("with error", func(t *testing.T) {
+		observerFacade.MockNextError = errors.New("arbitrary error")
+		observerFacade.MockGetResponse = nil
+
+		guardianData, err := provider.GetAccountGuardianData(testscommon.TestAddressAlice)
+		require.ErrorIs(t, err, errCannotGetAccount)
+		require.Nil(t, guardianData)
+	})
+}
+
 func TestNetworkProvider_GetAccountBalance(t *testing.T) {
 	observerFacade := testscommon.NewObserverFacadeMock()
 	args := createDefaultArgsNewNetworkProvider()
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
 )
 
 var errIsOffline = errors.New("server is in offline mode")
@@ -13,6 +15,10 @@ var errCannotGetTransaction = errors.New("cannot get transaction")
 var errCannotGetLatestBlockNonce = errors.New("cannot get latest block nonce, maybe the node didn't start syncing")
 var errInvalidCustomCurrencySymbol = errors.New("invalid custom currency symbol")
 var errCannotParseTokenIdentifier = errors.New("cannot parse token identifier")
+var errCannotComputeTransactionCost = errors.New("cannot compute transaction cost")
+var errCannotSimulateTransaction = errors.New("cannot simulate transaction")
+var errCannotGetMempoolOfSender = errors.New("cannot get mempool of sender")
+var errCannotResolveUsername = errors.New("cannot resolve username")
 
 func newErrCannotGetBlockByNonce(nonce uint64, innerError error) error {
 	return fmt.Errorf("%w: %v, nonce = %d", errCannotGetBlock, innerError, nonce)
@@ -38,6 +44,26 @@ func newErrCannotParseTokenIdentifier(tokenIdentifier string, innerError error)
 	return fmt.Errorf("%w: %v, tokenIdentifier = %s", errCannotParseTokenIdentifier, innerError, tokenIdentifier)
 }
 
+func newErrCannotComputeTransactionCost(innerError error) error {
+	return fmt.Errorf("%w: %v", errCannotComputeTransactionCost, innerError)
+}
+
+func newErrCannotSimulateTransaction(innerError error) error {
+	return fmt.Errorf("%w: %v", errCannotSimulateTransaction, innerError)
+}
+
+func newErrCannotGetMempoolOfSender(address string, innerError error) error {
+	return fmt.Errorf("%w: %v, address = %s", errCannotGetMempoolOfSender, innerError, address)
+}
+
+func newErrCannotResolveUsername(username string, innerError error) error {
+	return fmt.Errorf("%w: %v, username = %s", errCannotResolveUsername, innerError, username)
+}
+
+func newErrUsernameNotFound(username string) error {
+	return fmt.Errorf("%w: %s", resources.ErrUsernameNotFound, username)
+}
+
 // In proxy-go, the function CallGetRestEndPoint() returns an error message as the JSON content of the erroneous HTTP response.
 // Here, we attempt to decode that JSON and create an error with a "flat" error message.
 func convertStructuredApiErrToFlatErr(apiErr error) error {
@@ -8,10 +8,11 @@ import (
 
 type observerFacade interface {
 	CallGetRestEndPoint(baseUrl string, path string, value interface{}) (int, error)
+	CallPostRestEndPoint(baseUrl string, path string, data interface{}, response interface{}) (int, error)
 	ComputeShardId(pubKey []byte) uint32
 	SendTransaction(tx *data.Transaction) (int, string, error)
-	ComputeTransactionHash(tx *data.Transaction) (string, error)
 	GetTransactionByHashAndSenderAddress(hash string, sender string, withEvents bool) (*transaction.ApiTransactionResult, int, error)
+	GetProcessedTransactionStatus(txHash string) (*data.ProcessStatusResponse, error)
 	GetBlockByHash(shardID uint32, hash string, options common.BlockQueryOptions) (*data.BlockApiResponse, error)
 	GetBlockByNonce(shardID uint32, nonce uint64, options common.BlockQueryOptions) (*data.BlockApiResponse, error)
 }
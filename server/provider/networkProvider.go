@@ -21,30 +21,55 @@ import (
 var log = logger.GetOrCreate("server/provider")
 
 type ArgsNewNetworkProvider struct {
-	IsOffline                   bool
-	ObservedActualShard         uint32
-	ObservedProjectedShard      uint32
-	ObservedProjectedShardIsSet bool
-	ObserverUrl                 string
-	BlockchainName              string
-	NetworkID                   string
-	NetworkName                 string
-	GasPerDataByte              uint64
-	GasPriceModifier            float64
-	GasLimitCustomTransfer      uint64
-	MinGasPrice                 uint64
-	MinGasLimit                 uint64
-	ExtraGasLimitGuardedTx      uint64
-	ExtraGasLimitRelayedTxV3    uint64
-	NativeCurrencySymbol        string
-	CustomCurrencies            []resources.Currency
-	GenesisBlockHash            string
-	GenesisTimestamp            int64
-	FirstHistoricalEpoch        uint32
-	NumHistoricalEpochs         uint32
-	ShouldHandleContracts       bool
-	ActivationEpochSirius       uint32
-	ActivationEpochSpica        uint32
+	IsOffline                      bool
+	ObservedActualShard            uint32
+	ObservedProjectedShard         uint32
+	ObservedProjectedShardIsSet    bool
+	ObserverUrl                    string
+	BlockchainName                 string
+	NetworkID                      string
+	NetworkName                    string
+	GasPerDataByte                 uint64
+	GasPriceModifier               float64
+	GasLimitCustomTransfer         uint64
+	GasLimitNFTTransfer            uint64
+	MinGasPrice                    uint64
+	MinGasLimit                    uint64
+	ExtraGasLimitGuardedTx         uint64
+	ExtraGasLimitRelayedTxV3       uint64
+	EstimateGasWithObserver        bool
+	GasEstimationSafetyMargin      float64
+	SimulateBeforeSubmit           bool
+	UseMempoolNonce                bool
+	ReserveNonces                  bool
+	NonceReservationTTLSeconds     uint32
+	TrackSubmittedTxs              bool
+	TrackerMaxNumTxs               uint32
+	TrackerPollIntervalSeconds     uint32
+	TrackerWebhookUrl              string
+	SubmitDedupWindowSeconds       uint32
+	SubmitAuditLogPath             string
+	SubmitAuditLogMaxSizeMB        uint32
+	StrictEvents                   bool
+	BalanceAffectingEvents         []string
+	CheckedInvariants              []string
+	StrictInvariants               bool
+	ReconcileBalances              bool
+	ReconcilerIntervalSeconds      uint32
+	ReconcilerNumBlocks            uint32
+	ReconcilerMaxNumAccounts       uint32
+	ReconcilerMaxRequestsPerSecond uint32
+	EnableExplainEndpoint          bool
+	DelegationGasLimits            resources.DelegationGasLimits
+	NativeCurrencySymbol           string
+	CustomCurrencies               []resources.Currency
+	GenesisBlockHash               string
+	GenesisTimestamp               int64
+	FirstHistoricalEpoch           uint32
+	NumHistoricalEpochs            uint32
+	ShouldHandleContracts          bool
+	ActivationEpochSirius          uint32
+	ActivationEpochSpica           uint32
 
 	ObserverFacade observerFacade
 
@@ -125,10 +150,36 @@ func NewNetworkProvider(args ArgsNewNetworkProvider) (*networkProvider, error) {
 			GasPerDataByte:           args.GasPerDataByte,
 			GasPriceModifier:         args.GasPriceModifier,
 			GasLimitCustomTransfer:   args.GasLimitCustomTransfer,
+			GasLimitNFTTransfer:      args.GasLimitNFTTransfer,
 			MinGasPrice:              args.MinGasPrice,
 			MinGasLimit:              args.MinGasLimit,
 			ExtraGasLimitGuardedTx:   args.ExtraGasLimitGuardedTx,
 			ExtraGasLimitRelayedTxV3: args.ExtraGasLimitRelayedTxV3,
+
+			ShouldEstimateGasWithObserver:  args.EstimateGasWithObserver,
+			GasEstimationSafetyMargin:      args.GasEstimationSafetyMargin,
+			ShouldSimulateBeforeSubmit:     args.SimulateBeforeSubmit,
+			ShouldUseMempoolNonce:          args.UseMempoolNonce,
+			ShouldReserveNonces:            args.ReserveNonces,
+			NonceReservationTTLSeconds:     args.NonceReservationTTLSeconds,
+			ShouldTrackSubmittedTxs:        args.TrackSubmittedTxs,
+			TrackerMaxNumTxs:               args.TrackerMaxNumTxs,
+			TrackerPollIntervalSeconds:     args.TrackerPollIntervalSeconds,
+			TrackerWebhookUrl:              args.TrackerWebhookUrl,
+			SubmitDedupWindowSeconds:       args.SubmitDedupWindowSeconds,
+			SubmitAuditLogPath:             args.SubmitAuditLogPath,
+			SubmitAuditLogMaxSizeMB:        args.SubmitAuditLogMaxSizeMB,
+			ShouldUseStrictEventsMode:      args.StrictEvents,
+			BalanceAffectingEvents:         args.BalanceAffectingEvents,
+			CheckedInvariants:              args.CheckedInvariants,
+			ShouldUseStrictInvariantsMode:  args.StrictInvariants,
+			ShouldReconcileBalances:        args.ReconcileBalances,
+			ReconcilerIntervalSeconds:      args.ReconcilerIntervalSeconds,
+			ReconcilerNumBlocks:            args.ReconcilerNumBlocks,
+			ReconcilerMaxNumAccounts:       args.ReconcilerMaxNumAccounts,
+			ReconcilerMaxRequestsPerSecond: args.ReconcilerMaxRequestsPerSecond,
+			ShouldEnableExplainEndpoint:    args.EnableExplainEndpoint,
+			DelegationGasLimits:            args.DelegationGasLimits,
 		},
 
 		blocksCache: blocksCache,
@@ -207,6 +258,23 @@ func (provider *networkProvider) getBlockSummaryByNonce(nonce uint64) (resources
 
 // GetBlockByNonce gets a block by nonce
 func (provider *networkProvider) GetBlockByNonce(nonce uint64) (*api.Block, error) {
+	block, err := provider.GetRawBlockByNonce(nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	// The block (copy) returned by doGetBlockByNonce() is now mutated.
+	// The mutated copy is not held in a cache (not needed).
+	err = provider.simplifyBlockWithScheduledTransactions(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return block, nil
+}
+
+// GetRawBlockByNonce gets a block by nonce, as provided by the observer (i.e. without the simplification of scheduled miniblocks)
+func (provider *networkProvider) GetRawBlockByNonce(nonce uint64) (*api.Block, error) {
 	if provider.isOffline {
 		return nil, errIsOffline
 	}
@@ -222,14 +290,7 @@ func (provider *networkProvider) GetBlockByNonce(nonce uint64) (*api.Block, erro
 
 	block, err := provider.doGetBlockByNonce(nonce)
 	if err != nil {
-		log.Warn("GetBlockByNonce()", "nonce", nonce, "err", err)
-		return nil, err
-	}
-
-	// The block (copy) returned by doGetBlockByNonce() is now mutated.
-	// The mutated copy is not held in a cache (not needed).
-	err = provider.simplifyBlockWithScheduledTransactions(block)
-	if err != nil {
+		log.Warn("GetRawBlockByNonce()", "nonce", nonce, "err", err)
 		return nil, err
 	}
 
@@ -389,9 +450,21 @@ func (provider *networkProvider) ConvertAddressToPubKey(address string) ([]byte,
 	return provider.pubKeyConverter.Decode(address)
 }
 
-// ComputeTransactionHash computes the hash of a provided transaction
+// ComputeTransactionHash computes the hash of a provided transaction.
+// All fields are considered (including options, guardian and relayer), regardless of the transaction version.
 func (provider *networkProvider) ComputeTransactionHash(tx *data.Transaction) (string, error) {
-	return provider.observerFacade.ComputeTransactionHash(tx)
+	regularTx, err := provider.convertToRegularTransaction(tx)
+	if err != nil {
+		return "", err
+	}
+
+	txHash, err := core.CalculateHash(provider.marshalizerForHashing, provider.hasher, regularTx)
+	if err != nil {
+		return "", err
+	}
+
+	txHashHex := hex.EncodeToString(txHash)
+	return txHashHex, nil
 }
 
 func (provider *networkProvider) ComputeReceiptHash(apiReceipt *transaction.ApiReceipt) (string, error) {
@@ -454,6 +527,35 @@ func (provider *networkProvider) GetMempoolTransactionByHash(hash string) (*tran
 	return nil, nil
 }
 
+// GetTransactionByHash gets a transaction (from the pool or from the chain), along with its status
+func (provider *networkProvider) GetTransactionByHash(hash string) (*transaction.ApiTransactionResult, error) {
+	if provider.isOffline {
+		return nil, errIsOffline
+	}
+
+	tx, _, err := provider.observerFacade.GetTransactionByHashAndSenderAddress(hash, "", false)
+	if err != nil {
+		return nil, newErrCannotGetTransaction(hash, err)
+	}
+
+	return tx, nil
+}
+
+// GetProcessedTransactionStatus gets the status of a transaction, as computed by proxy-go (which also takes into account its contract results and logs).
+// Invalid transactions are reported as failed.
+func (provider *networkProvider) GetProcessedTransactionStatus(hash string) (transaction.TxStatus, error) {
+	if provider.isOffline {
+		return "", errIsOffline
+	}
+
+	response, err := provider.observerFacade.GetProcessedTransactionStatus(hash)
+	if err != nil {
+		return "", newErrCannotGetTransaction(hash, err)
+	}
+
+	return transaction.TxStatus(response.Status), nil
+}
+
 // ComputeTransactionFeeForMoveBalance computes the fee for a move-balance transaction
 func (provider *networkProvider) ComputeTransactionFeeForMoveBalance(tx *transaction.ApiTransactionResult) *big.Int {
 	minGasLimit := provider.networkConfig.MinGasLimit
@@ -501,6 +603,32 @@ func (provider *networkProvider) LogDescription() {
 		"shouldHandleContracts", provider.shouldHandleContracts,
 		"activationEpochSirius", provider.activationEpochSirius,
 		"activationEpochSpica", provider.activationEpochSpica,
+		"gasLimitCustomTransfer", provider.networkConfig.GasLimitCustomTransfer,
+		"gasLimitNFTTransfer", provider.networkConfig.GasLimitNFTTransfer,
+		"shouldEstimateGasWithObserver", provider.networkConfig.ShouldEstimateGasWithObserver,
+		"gasEstimationSafetyMargin", provider.networkConfig.GasEstimationSafetyMargin,
+		"shouldSimulateBeforeSubmit", provider.networkConfig.ShouldSimulateBeforeSubmit,
+		"shouldUseMempoolNonce", provider.networkConfig.ShouldUseMempoolNonce,
+		"shouldReserveNonces", provider.networkConfig.ShouldReserveNonces,
+		"nonceReservationTTLSeconds", provider.networkConfig.NonceReservationTTLSeconds,
+		"shouldTrackSubmittedTxs", provider.networkConfig.ShouldTrackSubmittedTxs,
+		"trackerMaxNumTxs", provider.networkConfig.TrackerMaxNumTxs,
+		"trackerPollIntervalSeconds", provider.networkConfig.TrackerPollIntervalSeconds,
+		"trackerWebhookUrl", provider.networkConfig.TrackerWebhookUrl,
+		"submitDedupWindowSeconds", provider.networkConfig.SubmitDedupWindowSeconds,
+		"submitAuditLogPath", provider.networkConfig.SubmitAuditLogPath,
+		"submitAuditLogMaxSizeMB", provider.networkConfig.SubmitAuditLogMaxSizeMB,
+		"shouldUseStrictEventsMode", provider.networkConfig.ShouldUseStrictEventsMode,
+		"balanceAffectingEvents", provider.networkConfig.BalanceAffectingEvents,
+		"checkedInvariants", provider.networkConfig.CheckedInvariants,
+		"shouldUseStrictInvariantsMode", provider.networkConfig.ShouldUseStrictInvariantsMode,
+		"shouldReconcileBalances", provider.networkConfig.ShouldReconcileBalances,
+		"reconcilerIntervalSeconds", provider.networkConfig.ReconcilerIntervalSeconds,
+		"reconcilerNumBlocks", provider.networkConfig.ReconcilerNumBlocks,
+		"reconcilerMaxNumAccounts", provider.networkConfig.ReconcilerMaxNumAccounts,
+		"reconcilerMaxRequestsPerSecond", provider.networkConfig.ReconcilerMaxRequestsPerSecond,
+		"shouldEnableExplainEndpoint", provider.networkConfig.ShouldEnableExplainEndpoint,
+		"delegationGasLimits", provider.networkConfig.DelegationGasLimits,
 		"nativeCurrency", provider.GetNativeCurrency().Symbol,
 		"customCurrencies", provider.GetCustomCurrenciesSymbols(),
 	)
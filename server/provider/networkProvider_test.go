@@ -27,11 +27,16 @@ func TestNewNetworkProvider(t *testing.T) {
 		GasPerDataByte:              1501,
 		GasPriceModifier:            0.01,
 		GasLimitCustomTransfer:      200000,
+		GasLimitNFTTransfer:         1000000,
 		MinGasPrice:                 1000000001,
 		MinGasLimit:                 50001,
 		ExtraGasLimitGuardedTx:      50002,
 		ExtraGasLimitRelayedTxV3:    50003,
-		NativeCurrencySymbol:        "XeGLD",
+		DelegationGasLimits: resources.DelegationGasLimits{
+			Delegate:     12000000,
+			ClaimRewards: 6000000,
+		},
+		NativeCurrencySymbol: "XeGLD",
 		CustomCurrencies: []resources.Currency{
 			{Symbol: "FOO-abcdef", Decimals: 6},
 			{Symbol: "BAR-abcdef", Decimals: 18},
@@ -61,10 +66,13 @@ func TestNewNetworkProvider(t *testing.T) {
 	assert.Equal(t, uint64(1501), provider.GetNetworkConfig().GasPerDataByte)
 	assert.Equal(t, 0.01, provider.GetNetworkConfig().GasPriceModifier)
 	assert.Equal(t, uint64(200000), provider.GetNetworkConfig().GasLimitCustomTransfer)
+	assert.Equal(t, uint64(1000000), provider.GetNetworkConfig().GasLimitNFTTransfer)
 	assert.Equal(t, uint64(1000000001), provider.GetNetworkConfig().MinGasPrice)
 	assert.Equal(t, uint64(50001), provider.GetNetworkConfig().MinGasLimit)
 	assert.Equal(t, uint64(50002), provider.GetNetworkConfig().ExtraGasLimitGuardedTx)
 	assert.Equal(t, uint64(50003), provider.GetNetworkConfig().ExtraGasLimitRelayedTxV3)
+	assert.Equal(t, uint64(12000000), provider.GetNetworkConfig().DelegationGasLimits.Delegate)
+	assert.Equal(t, uint64(6000000), provider.GetNetworkConfig().DelegationGasLimits.ClaimRewards)
 	assert.Equal(t, "XeGLD", provider.GetNativeCurrency().Symbol)
 	assert.Equal(t, []resources.Currency{
 		{Symbol: "FOO-abcdef", Decimals: 6},
@@ -284,6 +292,103 @@ func Test_ComputeTransactionFeeForMoveBalance(t *testing.T) {
 	})
 }
 
+func Test_ComputeTransactionHash(t *testing.T) {
+	args := createDefaultArgsNewNetworkProvider()
+	provider, err := NewNetworkProvider(args)
+	require.Nil(t, err)
+
+	t.Run("simple transaction", func(t *testing.T) {
+		tx := &data.Transaction{
+			Nonce:     42,
+			Value:     "1234",
+			Receiver:  testscommon.TestAddressBob,
+			Sender:    testscommon.TestAddressAlice,
+			GasPrice:  1000000000,
+			GasLimit:  50000,
+			ChainID:   "T",
+			Version:   1,
+			Signature: "aabb",
+		}
+
+		hash, err := provider.ComputeTransactionHash(tx)
+		require.Nil(t, err)
+		require.Equal(t, "8d82e0bc350d90d76332debe4b8c6bea0b47e3ec1f1a873fa3a46de088150d7d", hash)
+	})
+
+	t.Run("transaction signed with hash (options are considered)", func(t *testing.T) {
+		tx := &data.Transaction{
+			Nonce:     42,
+			Value:     "1234",
+			Receiver:  testscommon.TestAddressBob,
+			Sender:    testscommon.TestAddressAlice,
+			GasPrice:  1000000000,
+			GasLimit:  50000,
+			ChainID:   "T",
+			Version:   2,
+			Options:   1,
+			Signature: "aabb",
+		}
+
+		hash, err := provider.ComputeTransactionHash(tx)
+		require.Nil(t, err)
+		require.Equal(t, "83db9f1808010397519b7f9516e9598540cbd48553931de0481a96298e973530", hash)
+	})
+
+	t.Run("guarded transaction", func(t *testing.T) {
+		tx := &data.Transaction{
+			Nonce:             42,
+			Value:             "1234",
+			Receiver:          testscommon.TestAddressBob,
+			Sender:            testscommon.TestAddressAlice,
+			GasPrice:          1000000000,
+			GasLimit:          100000,
+			ChainID:           "T",
+			Version:           2,
+			Options:           2,
+			Signature:         "aabb",
+			GuardianAddr:      testscommon.TestAddressCarol,
+			GuardianSignature: "ccdd",
+		}
+
+		hash, err := provider.ComputeTransactionHash(tx)
+		require.Nil(t, err)
+		require.Equal(t, "6d82b49ff760606bb356ec0244c6744de715ed2f75ec0da7125601dd7cb5ff2c", hash)
+	})
+
+	t.Run("relayed V3 transaction", func(t *testing.T) {
+		tx := &data.Transaction{
+			Nonce:            42,
+			Value:            "1234",
+			Receiver:         testscommon.TestAddressBob,
+			Sender:           testscommon.TestAddressAlice,
+			GasPrice:         1000000000,
+			GasLimit:         100000,
+			ChainID:          "T",
+			Version:          2,
+			Signature:        "aabb",
+			RelayerAddr:      testscommon.TestAddressCarol,
+			RelayerSignature: "ccdd",
+		}
+
+		hash, err := provider.ComputeTransactionHash(tx)
+		require.Nil(t, err)
+		require.Equal(t, "e940dd66a47ded7b5789bbd91faf728e378a1e66a0f3a716cf9d5529ee1e1569", hash)
+	})
+
+	t.Run("with bad signature", func(t *testing.T) {
+		tx := &data.Transaction{
+			Value:     "1234",
+			Receiver:  testscommon.TestAddressBob,
+			Sender:    testscommon.TestAddressAlice,
+			Signature: "not hex",
+		}
+
+		hash, err := provider.ComputeTransactionHash(tx)
+		require.Error(t, err)
+		require.Empty(t, hash)
+	})
+}
+
 func TestNetworkProvider_IsAddressObserved(t *testing.T) {
 	t.Run("no projected shard, do not handle contracts", func(t *testing.T) {
 		args := createDefaultArgsNewNetworkProvider()
@@ -376,6 +481,53 @@ func TestNetworkProvider_IsAddressObserved(t *testing.T) {
 	})
 }
 
+func TestNetworkProvider_GetTransactionByHash(t *testing.T) {
+	observerFacade := testscommon.NewObserverFacadeMock()
+	observerFacade.MockTransactionsByHash["aaaa"] = &transaction.ApiTransactionResult{
+		Hash:       "aaaa",
+		Status:     transaction.TxStatusSuccess,
+		BlockNonce: 42,
+		BlockHash:  "bbbb",
+	}
+
+	args := createDefaultArgsNewNetworkProvider()
+	args.ObserverFacade = observerFacade
+
+	provider, err := NewNetworkProvider(args)
+	require.Nil(t, err)
+
+	tx, err := provider.GetTransactionByHash("aaaa")
+	require.Nil(t, err)
+	require.Equal(t, transaction.TxStatusSuccess, tx.Status)
+	require.Equal(t, uint64(42), tx.BlockNonce)
+
+	tx, err = provider.GetTransactionByHash("cccc")
+	require.ErrorIs(t, err, errCannotGetTransaction)
+	require.Nil(t, tx)
+}
+
+func TestNetworkProvider_GetProcessedTransactionStatus(t *testing.T) {
+	observerFacade := testscommon.NewObserverFacadeMock()
+	observerFacade.MockTransactionsByHash["aaaa"] = &transaction.ApiTransactionResult{
+		Hash:   "aaaa",
+		Status: transaction.TxStatusInvalid,
+	}
+
+	args := createDefaultArgsNewNetworkProvider()
+	args.ObserverFacade = observerFacade
+
+	provider, err := NewNetworkProvider(args)
+	require.Nil(t, err)
+
+	status, err := provider.GetProcessedTransactionStatus("aaaa")
+	require.Nil(t, err)
+	require.Equal(t, transaction.TxStatusFail, status)
+
+	status, err = provider.GetProcessedTransactionStatus("cccc")
+	require.ErrorIs(t, err, errCannotGetTransaction)
+	require.Empty(t, status)
+}
+
 func createDefaultArgsNewNetworkProvider() ArgsNewNetworkProvider {
 	return ArgsNewNetworkProvider{
 		IsOffline:                   false,
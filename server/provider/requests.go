@@ -16,6 +16,24 @@ func (provider *networkProvider) getResource(url string, response resourceApiRes
 	return nil
 }
 
+func (provider *networkProvider) postResource(url string, payload interface{}, response resourceApiResponseHandler) error {
+	if provider.isOffline {
+		return errIsOffline
+	}
+
+	_, err := provider.observerFacade.CallPostRestEndPoint(provider.observerUrl, url, payload, response)
+	if err != nil {
+		err = convertStructuredApiErrToFlatErr(err)
+		log.Warn("postResource()", "url", url, "err", err)
+		return err
+	}
+	if response.GetErrorMessage() != "" {
+		return errors.New(response.GetErrorMessage())
+	}
+
+	return nil
+}
+
 func (provider *networkProvider) getResourceWithErrConversion(url string, response resourceApiResponseHandler) error {
 	_, err := provider.observerFacade.CallGetRestEndPoint(provider.observerUrl, url, response)
 	if err != nil {
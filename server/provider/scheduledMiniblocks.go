@@ -64,6 +64,8 @@ func doSimplifyBlockWithScheduledTransactions(previousBlock *api.Block, block *a
 
 // gatherEffectiveTransactions gathers transactions whose effects (mutation of accounts state) are visible in the current block.
 // They are gathered from the previous, current and next block.
+// The order is deterministic: transactions of the current block come first (in miniblock order, then in their order within the miniblock),
+// followed by the contract results (found in the next block) of the transactions scheduled in the current block (in the order of their parent transactions).
 func gatherEffectiveTransactions(selfShard uint32, previousBlock *api.Block, currentBlock *api.Block, nextBlock *api.Block) []*transaction.ApiTransactionResult {
 	txsInCurrentBlock := gatherAllTransactions(currentBlock)
 
@@ -96,11 +98,11 @@ func gatherEffectiveTransactions(selfShard uint32, previousBlock *api.Block, cur
 	//	- previouslyExecutedResults																term (c)
 	//	+ currentlyExecutedResults																term (d)
 
-	effectiveTxsByHash := make(map[string]*transaction.ApiTransactionResult)
+	effectiveTxs := newOrderedTransactions()
 
 	// term (a)
 	for _, tx := range txsInCurrentBlock {
-		effectiveTxsByHash[tx.Hash] = tx
+		effectiveTxs.add(tx)
 	}
 
 	if len(scheduledTxsInPreviousBlock) > 0 {
@@ -108,30 +110,67 @@ func gatherEffectiveTransactions(selfShard uint32, previousBlock *api.Block, cur
 
 		// term (b)
 		for _, tx := range txsInPreviousBlock {
-			delete(effectiveTxsByHash, tx.Hash)
+			effectiveTxs.remove(tx.Hash)
 		}
 
 		// term (c)
 		for _, tx := range previouslyExecutedResults {
-			delete(effectiveTxsByHash, tx.Hash)
+			effectiveTxs.remove(tx.Hash)
 		}
 	}
 
 	if len(scheduledTxsInCurrentBlock) > 0 {
 		// term (d)
 		for _, tx := range currentlyExecutedResults {
-			effectiveTxsByHash[tx.Hash] = tx
+			effectiveTxs.add(tx)
 		}
 	}
 
-	// Collect & return the effective transactions.
-	effectiveTxs := make([]*transaction.ApiTransactionResult, 0, len(effectiveTxsByHash))
+	return effectiveTxs.toSlice()
+}
+
+// orderedTransactions is a set of transactions (by hash), which preserves the order of insertion.
+// A transaction added more than once (e.g. present in both a "scheduled" and an "invalid" miniblock) keeps the position of its first insertion, and the value of its last insertion.
+type orderedTransactions struct {
+	hashes []string
+	byHash map[string]*transaction.ApiTransactionResult
+	seen   map[string]struct{}
+}
+
+func newOrderedTransactions() *orderedTransactions {
+	return &orderedTransactions{
+		hashes: make([]string, 0),
+		byHash: make(map[string]*transaction.ApiTransactionResult),
+		seen:   make(map[string]struct{}),
+	}
+}
+
+func (txs *orderedTransactions) add(tx *transaction.ApiTransactionResult) {
+	_, alreadySeen := txs.seen[tx.Hash]
+	if !alreadySeen {
+		txs.seen[tx.Hash] = struct{}{}
+		txs.hashes = append(txs.hashes, tx.Hash)
+	}
+
+	txs.byHash[tx.Hash] = tx
+}
 
-	for _, tx := range effectiveTxsByHash {
-		effectiveTxs = append(effectiveTxs, tx)
+// remove removes a transaction (if present). Its position is retained, in case it is added again.
+func (txs *orderedTransactions) remove(hash string) {
+	delete(txs.byHash, hash)
+}
+
+func (txs *orderedTransactions) toSlice() []*transaction.ApiTransactionResult {
+	result := make([]*transaction.ApiTransactionResult, 0, len(txs.byHash))
+
+	for _, hash := range txs.hashes {
+		tx, ok := txs.byHash[hash]
+		if ok {
+			result = append(result, tx)
+		}
 	}
 
-	return effectiveTxs
+	return result
 }
 
 // findImmediatelyExecutingContractResults scans "maybeContractResults" for (immediately executing) contract results of "transactions"
@@ -1,7 +1,9 @@
 package provider
 
 import (
+	"encoding/json"
 	"errors"
+	"os"
 	"testing"
 
 	"github.com/multiversx/mx-chain-core-go/data/api"
@@ -102,6 +104,59 @@ func TestNetworkProviderSimplifyBlockWithScheduledTransactions(t *testing.T) {
 	require.Contains(t, txs, tx_c)
 }
 
+// TestNetworkProviderSimplifyBlockWithScheduledTransactions_IsDeterministic checks (against a golden file) the order of the effective transactions and receipts, over repeated calls.
+func TestNetworkProviderSimplifyBlockWithScheduledTransactions_IsDeterministic(t *testing.T) {
+	type goldenTransaction struct {
+		Hash string `json:"hash"`
+		Type string `json:"type"`
+	}
+
+	type golden struct {
+		Transactions []goldenTransaction `json:"transactions"`
+		Receipts     []string            `json:"receipts"`
+	}
+
+	expected := &golden{}
+	readTestJson(t, "testdata/blocks_with_scheduled_miniblocks.golden.json", expected)
+
+	for i := 0; i < 50; i++ {
+		// Blocks are read for each call, since the simplification alters the block.
+		var blocks []*api.Block
+		readTestJson(t, "testdata/blocks_with_scheduled_miniblocks.json", &blocks)
+
+		observerFacade := testscommon.NewObserverFacadeMock()
+		observerFacade.GetBlockByNonceCalled = func(shardID uint32, nonce uint64, options common.BlockQueryOptions) (*data.BlockApiResponse, error) {
+			for _, block := range blocks {
+				if block.Nonce == nonce {
+					return &data.BlockApiResponse{Data: data.BlockApiResponsePayload{Block: *block}}, nil
+				}
+			}
+
+			return nil, errors.New("unexpected request")
+		}
+
+		args := createDefaultArgsNewNetworkProvider()
+		args.ObserverFacade = observerFacade
+
+		provider, err := NewNetworkProvider(args)
+		require.Nil(t, err)
+
+		block := blocks[1]
+		err = provider.simplifyBlockWithScheduledTransactions(block)
+		require.Nil(t, err)
+
+		actual := &golden{}
+		for _, tx := range block.MiniBlocks[0].Transactions {
+			actual.Transactions = append(actual.Transactions, goldenTransaction{Hash: tx.Hash, Type: tx.Type})
+		}
+		for _, receipt := range block.MiniBlocks[1].Receipts {
+			actual.Receipts = append(actual.Receipts, receipt.TxHash)
+		}
+
+		require.Equal(t, expected, actual)
+	}
+}
+
 func TestGatherEffectiveTransactions(t *testing.T) {
 	selfShard := uint32(1)
 
@@ -371,3 +426,33 @@ func TestGatherAllReceipts(t *testing.T) {
 	receipts := gatherAllReceipts(block)
 	require.Len(t, receipts, 4)
 }
+
+func TestOrderedTransactions(t *testing.T) {
+	tx_a := &transaction.ApiTransactionResult{Hash: "aaaa"}
+	tx_b := &transaction.ApiTransactionResult{Hash: "bbbb"}
+	tx_b_invalid := &transaction.ApiTransactionResult{Hash: "bbbb", Type: string(transaction.TxTypeInvalid)}
+	tx_c := &transaction.ApiTransactionResult{Hash: "cccc"}
+
+	txs := newOrderedTransactions()
+	txs.add(tx_c)
+	txs.add(tx_b)
+	txs.add(tx_a)
+	txs.add(tx_b_invalid)
+	require.Equal(t, []*transaction.ApiTransactionResult{tx_c, tx_b_invalid, tx_a}, txs.toSlice())
+
+	txs.remove("cccc")
+	txs.remove("dddd")
+	require.Equal(t, []*transaction.ApiTransactionResult{tx_b_invalid, tx_a}, txs.toSlice())
+
+	// Once added again, a transaction is back at its original position.
+	txs.add(tx_c)
+	require.Equal(t, []*transaction.ApiTransactionResult{tx_c, tx_b_invalid, tx_a}, txs.toSlice())
+}
+
+func readTestJson(t *testing.T, filePath string, value interface{}) {
+	content, err := os.ReadFile(filePath)
+	require.Nil(t, err)
+
+	err = json.Unmarshal(content, value)
+	require.Nil(t, err)
+}
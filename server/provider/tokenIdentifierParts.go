@@ -44,3 +44,14 @@ func parseTokenIdentifierIntoParts(tokenIdentifier string) (*tokenIdentifierPart
 
 	return nil, newErrCannotParseTokenIdentifier(tokenIdentifier, nil)
 }
+
+// ParseTokenIdentifier splits a (possibly extended) token identifier into its base identifier (ticker and random sequence) and its nonce.
+// For fungible tokens, the nonce is 0.
+func ParseTokenIdentifier(tokenIdentifier string) (string, uint64, error) {
+	parts, err := parseTokenIdentifierIntoParts(tokenIdentifier)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return parts.tickerWithRandomSequence, parts.nonce, nil
+}
@@ -33,3 +33,18 @@ func TestParseTokenIdentifierIntoParts(t *testing.T) {
 		require.Nil(t, parts)
 	})
 }
+
+func TestParseTokenIdentifier(t *testing.T) {
+	baseIdentifier, nonce, err := ParseTokenIdentifier("ROSETTA-2c0a37")
+	require.Nil(t, err)
+	require.Equal(t, "ROSETTA-2c0a37", baseIdentifier)
+	require.Equal(t, uint64(0), nonce)
+
+	baseIdentifier, nonce, err = ParseTokenIdentifier("EXAMPLE-453bec-0a")
+	require.Nil(t, err)
+	require.Equal(t, "EXAMPLE-453bec", baseIdentifier)
+	require.Equal(t, uint64(10), nonce)
+
+	_, _, err = ParseTokenIdentifier("token")
+	require.ErrorIs(t, err, errCannotParseTokenIdentifier)
+}
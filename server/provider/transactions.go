@@ -1,7 +1,13 @@
 package provider
 
 import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+
 	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-proxy-go/data"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
 )
 
 // IsRelayedTxV3 checks whether the provided (API) transaction is relayed (V3).
@@ -19,3 +25,119 @@ func IsRelayedTxV3(tx *transaction.ApiTransactionResult) bool {
 
 	return true
 }
+
+// convertToRegularTransaction converts a (proxy) transaction to a regular (protocol) transaction, which is used for hashing.
+func (provider *networkProvider) convertToRegularTransaction(tx *data.Transaction) (*transaction.Transaction, error) {
+	value, ok := big.NewInt(0).SetString(tx.Value, 10)
+	if !ok {
+		return nil, errors.New("invalid transaction value")
+	}
+
+	receiver, err := provider.ConvertAddressToPubKey(tx.Receiver)
+	if err != nil {
+		return nil, err
+	}
+
+	sender, err := provider.ConvertAddressToPubKey(tx.Sender)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := hex.DecodeString(tx.Signature)
+	if err != nil {
+		return nil, err
+	}
+
+	regularTx := &transaction.Transaction{
+		Nonce:       tx.Nonce,
+		Value:       value,
+		RcvAddr:     receiver,
+		RcvUserName: tx.ReceiverUsername,
+		SndAddr:     sender,
+		SndUserName: tx.SenderUsername,
+		GasPrice:    tx.GasPrice,
+		GasLimit:    tx.GasLimit,
+		Data:        tx.Data,
+		ChainID:     []byte(tx.ChainID),
+		Version:     tx.Version,
+		Signature:   signature,
+		Options:     tx.Options,
+	}
+
+	if len(tx.GuardianAddr) > 0 {
+		regularTx.GuardianAddr, err = provider.ConvertAddressToPubKey(tx.GuardianAddr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	regularTx.GuardianSignature, err = hex.DecodeString(tx.GuardianSignature)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tx.RelayerAddr) > 0 {
+		regularTx.RelayerAddr, err = provider.ConvertAddressToPubKey(tx.RelayerAddr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	regularTx.RelayerSignature, err = hex.DecodeString(tx.RelayerSignature)
+	if err != nil {
+		return nil, err
+	}
+
+	return regularTx, nil
+}
+
+// ComputeTransactionCost asks the observer to simulate the (unsigned) transaction and estimate the gas units it would consume
+func (provider *networkProvider) ComputeTransactionCost(tx *data.Transaction) (*resources.TransactionCost, error) {
+	response := &resources.TransactionCostApiResponse{}
+
+	err := provider.postResource(urlPathComputeTransactionCost, tx, response)
+	if err != nil {
+		return nil, newErrCannotComputeTransactionCost(err)
+	}
+
+	cost := response.Data
+	if cost.GasUnits == 0 && len(cost.ReturnMessage) > 0 {
+		return nil, newErrCannotComputeTransactionCost(errors.New(cost.ReturnMessage))
+	}
+
+	return &cost, nil
+}
+
+// SimulateTransaction asks the observer to simulate the execution of a (signed) transaction, without broadcasting it.
+// A predicted failure is not an error; the caller should inspect the results (i.e. the status, the fail reason and the events).
+func (provider *networkProvider) SimulateTransaction(tx *data.Transaction) (*transaction.SimulationResults, error) {
+	response := &resources.TransactionSimulationApiResponse{}
+
+	err := provider.postResource(urlPathSimulateTransaction, tx, response)
+	if err != nil {
+		return nil, newErrCannotSimulateTransaction(err)
+	}
+
+	return &response.Data.Result, nil
+}
+
+// SendTransactions broadcasts a batch of already-signed transactions, through the observer's "send-multiple" route.
+// The hashes of the accepted transactions are returned, keyed by their index in the batch.
+// Transactions rejected by the observer (e.g. bad signature, bad nonce) are missing from the result.
+func (provider *networkProvider) SendTransactions(txs []*data.Transaction) (map[int]string, error) {
+	response := &resources.SendMultipleTransactionsApiResponse{}
+
+	err := provider.postResource(urlPathSendMultipleTransactions, txs, response)
+	if err != nil {
+		log.Warn("SendTransactions()", "numTxs", len(txs), "err", err)
+		return nil, err
+	}
+
+	hashes := response.Data.TxsHashes
+	if hashes == nil {
+		hashes = make(map[int]string)
+	}
+
+	log.Debug("SendTransactions()", "numTxs", len(txs), "numSent", response.Data.NumSent)
+	return hashes, nil
+}
@@ -0,0 +1,185 @@
+package provider
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-proxy-go/data"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkProvider_ComputeTransactionCost(t *testing.T) {
+	observerFacade := testscommon.NewObserverFacadeMock()
+	args := createDefaultArgsNewNetworkProvider()
+	args.ObserverFacade = observerFacade
+
+	provider, err := NewNetworkProvider(args)
+	require.Nil(t, err)
+
+	tx := &data.Transaction{
+		Sender:   testscommon.TestAddressAlice,
+		Receiver: testscommon.TestAddressOfContract,
+		Value:    "0",
+		Data:     []byte("add@01"),
+		ChainID:  "T",
+		Version:  1,
+	}
+
+	t.Run("with success", func(t *testing.T) {
+		observerFacade.MockNextError = nil
+		observerFacade.MockPostResponse = resources.TransactionCostApiResponse{
+			Data: resources.TransactionCost{
+				GasUnits: 1234567,
+			},
+		}
+
+		cost, err := provider.ComputeTransactionCost(tx)
+		require.Nil(t, err)
+		require.Equal(t, uint64(1234567), cost.GasUnits)
+		require.Equal(t, args.ObserverUrl, observerFacade.RecordedBaseUrl)
+		require.Equal(t, "/transaction/cost", observerFacade.RecordedPath)
+		require.Equal(t, tx, observerFacade.RecordedPostPayload)
+	})
+
+	t.Run("with failed simulation", func(t *testing.T) {
+		observerFacade.MockNextError = nil
+		observerFacade.MockPostResponse = resources.TransactionCostApiResponse{
+			Data: resources.TransactionCost{
+				ReturnMessage: "invalid function (not found)",
+			},
+		}
+
+		cost, err := provider.ComputeTransactionCost(tx)
+		require.ErrorIs(t, err, errCannotComputeTransactionCost)
+		require.ErrorContains(t, err, "invalid function (not found)")
+		require.Nil(t, cost)
+	})
+
+	t.Run("with error", func(t *testing.T) {
+		observerFacade.MockNextError = errors.New("arbitrary error")
+		observerFacade.MockPostResponse = nil
+
+		cost, err := provider.ComputeTransactionCost(tx)
+		require.ErrorIs(t, err, errCannotComputeTransactionCost)
+		require.Nil(t, cost)
+	})
+}
+
+func TestNetworkProvider_SimulateTransaction(t *testing.T) {
+	observerFacade := testscommon.NewObserverFacadeMock()
+	args := createDefaultArgsNewNetworkProvider()
+	args.ObserverFacade = observerFacade
+
+	provider, err := NewNetworkProvider(args)
+	require.Nil(t, err)
+
+	tx := &data.Transaction{
+		Sender:    testscommon.TestAddressAlice,
+		Receiver:  testscommon.TestAddressBob,
+		Value:     "0",
+		Data:      []byte("ESDTTransfer@544553542d616263646566@64"),
+		ChainID:   "T",
+		Version:   1,
+		Signature: "aabb",
+	}
+
+	t.Run("with success", func(t *testing.T) {
+		observerFacade.MockNextError = nil
+		observerFacade.MockPostResponse = resources.TransactionSimulationApiResponse{
+			Data: resources.TransactionSimulationApiResponsePayload{
+				Result: transaction.SimulationResults{
+					Status: transaction.TxStatusSuccess,
+					Hash:   "aaaa",
+				},
+			},
+		}
+
+		results, err := provider.SimulateTransaction(tx)
+		require.Nil(t, err)
+		require.Equal(t, transaction.TxStatusSuccess, results.Status)
+		require.Equal(t, "/transaction/simulate", observerFacade.RecordedPath)
+		require.Equal(t, tx, observerFacade.RecordedPostPayload)
+	})
+
+	t.Run("with predicted failure", func(t *testing.T) {
+		observerFacade.MockNextError = nil
+		observerFacade.MockPostResponse = resources.TransactionSimulationApiResponse{
+			Data: resources.TransactionSimulationApiResponsePayload{
+				Result: transaction.SimulationResults{
+					Status:     transaction.TxStatusFail,
+					FailReason: "insufficient funds",
+				},
+			},
+		}
+
+		results, err := provider.SimulateTransaction(tx)
+		require.Nil(t, err)
+		require.Equal(t, transaction.TxStatusFail, results.Status)
+		require.Equal(t, "insufficient funds", results.FailReason)
+	})
+
+	t.Run("with error", func(t *testing.T) {
+		observerFacade.MockNextError = errors.New("arbitrary error")
+		observerFacade.MockPostResponse = nil
+
+		results, err := provider.SimulateTransaction(tx)
+		require.ErrorIs(t, err, errCannotSimulateTransaction)
+		require.Nil(t, results)
+	})
+}
+
+func TestNetworkProvider_SendTransactions(t *testing.T) {
+	observerFacade := testscommon.NewObserverFacadeMock()
+	args := createDefaultArgsNewNetworkProvider()
+	args.ObserverFacade = observerFacade
+
+	provider, err := NewNetworkProvider(args)
+	require.Nil(t, err)
+
+	txs := []*data.Transaction{
+		{Sender: testscommon.TestAddressAlice, Receiver: testscommon.TestAddressBob, Nonce: 42, Value: "1", ChainID: "T", Version: 1, Signature: "aabb"},
+		{Sender: testscommon.TestAddressAlice, Receiver: testscommon.TestAddressBob, Nonce: 43, Value: "1", ChainID: "T", Version: 1, Signature: "aabb"},
+		{Sender: testscommon.TestAddressAlice, Receiver: testscommon.TestAddressBob, Nonce: 44, Value: "1", ChainID: "T", Version: 1, Signature: "aabb"},
+	}
+
+	t.Run("with success", func(t *testing.T) {
+		observerFacade.MockNextError = nil
+		observerFacade.MockPostResponse = map[string]interface{}{
+			"data": map[string]interface{}{
+				"txsSent":   2,
+				"txsHashes": map[string]string{"0": "aaaa", "2": "cccc"},
+			},
+		}
+
+		hashes, err := provider.SendTransactions(txs)
+		require.Nil(t, err)
+		require.Equal(t, map[int]string{0: "aaaa", 2: "cccc"}, hashes)
+		require.Equal(t, "/transaction/send-multiple", observerFacade.RecordedPath)
+		require.Equal(t, txs, observerFacade.RecordedPostPayload)
+	})
+
+	t.Run("with no transaction accepted", func(t *testing.T) {
+		observerFacade.MockNextError = nil
+		observerFacade.MockPostResponse = map[string]interface{}{
+			"data": map[string]interface{}{
+				"txsSent": 0,
+			},
+		}
+
+		hashes, err := provider.SendTransactions(txs)
+		require.Nil(t, err)
+		require.Empty(t, hashes)
+	})
+
+	t.Run("with error", func(t *testing.T) {
+		observerFacade.MockNextError = errors.New("arbitrary error")
+		observerFacade.MockPostResponse = nil
+
+		hashes, err := provider.SendTransactions(txs)
+		require.ErrorContains(t, err, "arbitrary error")
+		require.Nil(t, hashes)
+	})
+}
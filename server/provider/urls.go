@@ -17,6 +17,12 @@ var (
 	urlPathGetAccountNativeBalance              = "/address/%s"
 	urlPathGetAccountFungibleTokenBalance       = "/address/%s/esdt/%s"
 	urlPathGetAccountNonFungibleTokenBalance    = "/address/%s/nft/%s/nonce/%d"
+	urlPathGetAccountGuardianData               = "/address/%s/guardian-data"
+	urlPathComputeTransactionCost               = "/transaction/cost"
+	urlPathSimulateTransaction                  = "/transaction/simulate"
+	urlPathSendMultipleTransactions             = "/transaction/send-multiple"
+	urlPathGetTransactionsPoolForSender         = "/transaction/pool?by-sender=%s&fields=nonce"
+	urlPathQuerySmartContract                   = "/vm-values/query"
 	urlParameterAccountQueryOptionsOnFinalBlock = "onFinalBlock"
 	urlParameterAccountQueryOptionsBlockNonce   = "blockNonce"
 	urlParameterAccountQueryOptionsBlockHash    = "blockHash"
@@ -43,6 +49,15 @@ func buildUrlGetAccountNonFungibleTokenBalance(address string, tokenIdentifier s
 	return buildUrlWithAccountQueryOptions(fmt.Sprintf(urlPathGetAccountNonFungibleTokenBalance, address, tokenIdentifier, nonce), options)
 }
 
+func buildUrlGetAccountGuardianData(address string) string {
+	options := resources.NewAccountQueryOptionsOnFinalBlock()
+	return buildUrlWithAccountQueryOptions(fmt.Sprintf(urlPathGetAccountGuardianData, address), options)
+}
+
+func buildUrlGetTransactionsPoolForSender(address string) string {
+	return fmt.Sprintf(urlPathGetTransactionsPoolForSender, url.QueryEscape(address))
+}
+
 func buildUrlWithAccountQueryOptions(path string, options resources.AccountQueryOptions) string {
 	if options.OnFinalBlock {
 		return buildUrlWithQueryParameter(path, urlParameterAccountQueryOptionsOnFinalBlock, "true")
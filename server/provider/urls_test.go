@@ -13,6 +13,11 @@ func TestBuildUrlGetAccount(t *testing.T) {
 	require.Equal(t, "/address/erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th?onFinalBlock=true", url)
 }
 
+func TestBuildUrlGetAccountGuardianData(t *testing.T) {
+	url := buildUrlGetAccountGuardianData(testscommon.TestAddressAlice)
+	require.Equal(t, "/address/erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th/guardian-data?onFinalBlock=true", url)
+}
+
 func TestBuildUrlGetAccountNativeBalance(t *testing.T) {
 	optionsOnFinal := resources.NewAccountQueryOptionsOnFinalBlock()
 	optionsAtBlockNonce := resources.NewAccountQueryOptionsWithBlockNonce(7)
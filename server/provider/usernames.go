@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/hashing/keccak"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+)
+
+const dnsFunctionResolve = "resolve"
+const vmQueryReturnCodeOk = "ok"
+
+// The DNS contracts have been deployed (at genesis) by 256 deployers, whose addresses are derived from this one (see "genesis.InitialDNSAddress").
+var initialDnsDeployerAddress = bytes.Repeat([]byte{1}, 32)
+var dnsContractsVMType = []byte{5, 0}
+var dnsHasher = keccak.NewKeccak()
+
+// ResolveUsername resolves a username (e.g. "alice.elrond") to an address, by querying the appropriate DNS contract
+func (provider *networkProvider) ResolveUsername(username string) (string, error) {
+	dnsAddress := provider.ConvertPubKeyToAddress(computeDnsContractPubKeyOfUsername(username))
+
+	request := resources.VmQueryRequest{
+		ScAddress: dnsAddress,
+		FuncName:  dnsFunctionResolve,
+		Args:      []string{hex.EncodeToString([]byte(username))},
+	}
+
+	response := &resources.VmQueryApiResponse{}
+
+	err := provider.postResource(urlPathQuerySmartContract, request, response)
+	if err != nil {
+		return "", newErrCannotResolveUsername(username, err)
+	}
+
+	result := response.Data.Data
+	if result.ReturnCode != vmQueryReturnCodeOk {
+		return "", newErrCannotResolveUsername(username, fmt.Errorf("%s: %s", result.ReturnCode, result.ReturnMessage))
+	}
+
+	if len(result.ReturnData) == 0 || len(result.ReturnData[0]) == 0 {
+		return "", newErrUsernameNotFound(username)
+	}
+
+	pubKey := result.ReturnData[0]
+	if len(pubKey) != len(initialDnsDeployerAddress) {
+		return "", newErrCannotResolveUsername(username, errors.New("unexpected length of resolved public key"))
+	}
+
+	return provider.ConvertPubKeyToAddress(pubKey), nil
+}
+
+// computeDnsContractPubKeyOfUsername computes the address of the DNS contract responsible for the username.
+// The index of the DNS contract is given by the last byte of the (keccak) hash of the username.
+func computeDnsContractPubKeyOfUsername(username string) []byte {
+	hash := dnsHasher.Compute(username)
+	index := hash[len(hash)-1]
+
+	deployer := make([]byte, len(initialDnsDeployerAddress))
+	copy(deployer, initialDnsDeployerAddress)
+	deployer[len(deployer)-core.ShardIdentiferLen] = 0
+	deployer[len(deployer)-1] = index
+
+	return computeContractPubKey(deployer, 0, dnsContractsVMType)
+}
+
+// computeContractPubKey computes the address of a contract deployed by the given account, at the given nonce (same as the "NewAddress" hook of the protocol).
+func computeContractPubKey(deployer []byte, deployerNonce uint64, vmType []byte) []byte {
+	nonceAsBytes := make([]byte, 8)
+	binary.LittleEndian.PutUint64(nonceAsBytes, deployerNonce)
+
+	pubKey := dnsHasher.Compute(string(append(append([]byte{}, deployer...), nonceAsBytes...)))
+
+	prefix := make([]byte, core.NumInitCharactersForScAddress-core.VMTypeLen)
+	prefix = append(prefix, vmType...)
+
+	copy(pubKey[:core.NumInitCharactersForScAddress], prefix)
+	copy(pubKey[len(pubKey)-core.ShardIdentiferLen:], deployer[len(deployer)-core.ShardIdentiferLen:])
+
+	return pubKey
+}
@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeDnsContractPubKeyOfUsername(t *testing.T) {
+	t.Parallel()
+
+	for _, username := range []string{"alice.elrond", "bob.elrond", "carol.elrond"} {
+		pubKey := computeDnsContractPubKeyOfUsername(username)
+		usernameHash := dnsHasher.Compute(username)
+
+		require.Len(t, pubKey, 32)
+		require.True(t, core.IsSmartContractAddress(pubKey))
+		require.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0, 0, 5, 0}, pubKey[:10])
+		require.Equal(t, []byte{0, usernameHash[len(usernameHash)-1]}, pubKey[30:])
+	}
+
+	require.Equal(t, computeDnsContractPubKeyOfUsername("alice.elrond"), computeDnsContractPubKeyOfUsername("alice.elrond"))
+}
+
+func TestNetworkProvider_ResolveUsername(t *testing.T) {
+	observerFacade := testscommon.NewObserverFacadeMock()
+	args := createDefaultArgsNewNetworkProvider()
+	args.ObserverFacade = observerFacade
+
+	provider, err := NewNetworkProvider(args)
+	require.Nil(t, err)
+
+	alicePubKey, _ := provider.ConvertAddressToPubKey(testscommon.TestAddressAlice)
+
+	t.Run("with success", func(t *testing.T) {
+		observerFacade.MockNextError = nil
+		observerFacade.MockPostResponse = resources.VmQueryApiResponse{
+			Data: resources.VmQueryApiResponsePayload{
+				Data: resources.VmQueryResult{
+					ReturnCode: "ok",
+					ReturnData: [][]byte{alicePubKey},
+				},
+			},
+		}
+
+		address, err := provider.ResolveUsername("alice.elrond")
+		require.Nil(t, err)
+		require.Equal(t, testscommon.TestAddressAlice, address)
+		require.Equal(t, "/vm-values/query", observerFacade.RecordedPath)
+		require.Equal(t, resources.VmQueryRequest{
+			ScAddress: provider.ConvertPubKeyToAddress(computeDnsContractPubKeyOfUsername("alice.elrond")),
+			FuncName:  "resolve",
+			Args:      []string{"616c6963652e656c726f6e64"},
+		}, observerFacade.RecordedPostPayload)
+	})
+
+	t.Run("with username not found", func(t *testing.T) {
+		observerFacade.MockNextError = nil
+		observerFacade.MockPostResponse = resources.VmQueryApiResponse{
+			Data: resources.VmQueryApiResponsePayload{
+				Data: resources.VmQueryResult{
+					ReturnCode: "ok",
+					ReturnData: [][]byte{{}},
+				},
+			},
+		}
+
+		_, err := provider.ResolveUsername("nobody.elrond")
+		require.ErrorIs(t, err, resources.ErrUsernameNotFound)
+		require.NotErrorIs(t, err, errCannotResolveUsername)
+		require.ErrorContains(t, err, "username not found: nobody.elrond")
+	})
+
+	t.Run("with failed query", func(t *testing.T) {
+		observerFacade.MockNextError = nil
+		observerFacade.MockPostResponse = resources.VmQueryApiResponse{
+			Data: resources.VmQueryApiResponsePayload{
+				Data: resources.VmQueryResult{
+					ReturnCode:    "function not found",
+					ReturnMessage: "invalid function",
+				},
+			},
+		}
+
+		_, err := provider.ResolveUsername("alice.elrond")
+		require.ErrorIs(t, err, errCannotResolveUsername)
+		require.ErrorContains(t, err, "function not found: invalid function")
+	})
+
+	t.Run("with error", func(t *testing.T) {
+		observerFacade.MockNextError = errors.New("arbitrary error")
+		observerFacade.MockPostResponse = nil
+
+		_, err := provider.ResolveUsername("alice.elrond")
+		require.ErrorIs(t, err, errCannotResolveUsername)
+	})
+}
@@ -2,6 +2,7 @@ package resources
 
 import (
 	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/data/api"
 )
 
 // AccountApiResponse is an API resource
@@ -18,9 +19,10 @@ type AccountOnBlock struct {
 
 // Account defines an account resource
 type Account struct {
-	Address string `json:"address"`
-	Nonce   uint64 `json:"nonce"`
-	Balance string `json:"balance"`
+	Address   string `json:"address"`
+	Nonce     uint64 `json:"nonce"`
+	Balance   string `json:"balance"`
+	IsGuarded bool   `json:"isGuarded"`
 }
 
 // AccountESDTBalanceApiResponse is an API resource
@@ -47,3 +49,40 @@ type AccountBalanceOnBlock struct {
 	Nonce            core.OptionalUint64
 	BlockCoordinates BlockCoordinates
 }
+
+// AccountGuardianDataApiResponse is an API resource
+type AccountGuardianDataApiResponse struct {
+	resourceApiResponse
+	Data AccountGuardianDataOnBlock `json:"data"`
+}
+
+// AccountGuardianDataOnBlock defines an account resource
+type AccountGuardianDataOnBlock struct {
+	GuardianData     api.GuardianData `json:"guardianData"`
+	BlockCoordinates BlockCoordinates `json:"blockInfo"`
+}
+
+// VmQueryRequest is an API resource
+type VmQueryRequest struct {
+	ScAddress string   `json:"scAddress"`
+	FuncName  string   `json:"funcName"`
+	Args      []string `json:"args"`
+}
+
+// VmQueryApiResponse is an API resource
+type VmQueryApiResponse struct {
+	resourceApiResponse
+	Data VmQueryApiResponsePayload `json:"data"`
+}
+
+// VmQueryApiResponsePayload is an API resource
+type VmQueryApiResponsePayload struct {
+	Data VmQueryResult `json:"data"`
+}
+
+// VmQueryResult is an API resource
+type VmQueryResult struct {
+	ReturnData    [][]byte `json:"returnData"`
+	ReturnCode    string   `json:"returnCode"`
+	ReturnMessage string   `json:"returnMessage"`
+}
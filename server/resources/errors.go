@@ -0,0 +1,6 @@
+package resources
+
+import "errors"
+
+// ErrUsernameNotFound signals that a username is not registered in the DNS (as opposed to a failure to query the DNS)
+var ErrUsernameNotFound = errors.New("username not found")
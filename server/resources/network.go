@@ -10,8 +10,51 @@ type NetworkConfig struct {
 	GasPerDataByte           uint64
 	GasPriceModifier         float64
 	GasLimitCustomTransfer   uint64
+	GasLimitNFTTransfer      uint64
 	ExtraGasLimitGuardedTx   uint64
 	ExtraGasLimitRelayedTxV3 uint64
+
+	ShouldEstimateGasWithObserver bool
+	GasEstimationSafetyMargin     float64
+	ShouldSimulateBeforeSubmit    bool
+	ShouldUseMempoolNonce         bool
+	ShouldReserveNonces           bool
+	NonceReservationTTLSeconds    uint32
+
+	ShouldTrackSubmittedTxs    bool
+	TrackerMaxNumTxs           uint32
+	TrackerPollIntervalSeconds uint32
+	TrackerWebhookUrl          string
+
+	SubmitDedupWindowSeconds uint32
+	SubmitAuditLogPath       string
+	SubmitAuditLogMaxSizeMB  uint32
+
+	ShouldUseStrictEventsMode bool
+	BalanceAffectingEvents    []string
+
+	CheckedInvariants             []string
+	ShouldUseStrictInvariantsMode bool
+
+	ShouldReconcileBalances        bool
+	ReconcilerIntervalSeconds      uint32
+	ReconcilerNumBlocks            uint32
+	ReconcilerMaxNumAccounts       uint32
+	ReconcilerMaxRequestsPerSecond uint32
+
+	ShouldEnableExplainEndpoint bool
+
+	DelegationGasLimits DelegationGasLimits
+}
+
+// DelegationGasLimits holds the gas limits of the staking and delegation functions (for transaction construction)
+type DelegationGasLimits struct {
+	Delegate                 uint64
+	Undelegate               uint64
+	ClaimRewards             uint64
+	Withdraw                 uint64
+	ReDelegateRewards        uint64
+	CreateDelegationContract uint64
 }
 
 // NodeStatusApiResponse is an API resource
@@ -0,0 +1,64 @@
+package resources
+
+import "github.com/multiversx/mx-chain-core-go/data/transaction"
+
+// TransactionCostApiResponse is an API resource
+type TransactionCostApiResponse struct {
+	resourceApiResponse
+	Data TransactionCost `json:"data"`
+}
+
+// TransactionCost is an API resource
+type TransactionCost struct {
+	GasUnits      uint64 `json:"txGasUnits"`
+	ReturnMessage string `json:"returnMessage"`
+}
+
+// TransactionSimulationApiResponse is an API resource
+type TransactionSimulationApiResponse struct {
+	resourceApiResponse
+	Data TransactionSimulationApiResponsePayload `json:"data"`
+}
+
+// TransactionSimulationApiResponsePayload is an API resource
+type TransactionSimulationApiResponsePayload struct {
+	Result transaction.SimulationResults `json:"result"`
+}
+
+// SendMultipleTransactionsApiResponse is an API resource
+type SendMultipleTransactionsApiResponse struct {
+	resourceApiResponse
+	Data SendMultipleTransactionsApiResponsePayload `json:"data"`
+}
+
+// SendMultipleTransactionsApiResponsePayload is an API resource
+type SendMultipleTransactionsApiResponsePayload struct {
+	NumSent   uint64         `json:"txsSent"`
+	TxsHashes map[int]string `json:"txsHashes"`
+}
+
+// TransactionsPoolForSenderApiResponse is an API resource
+type TransactionsPoolForSenderApiResponse struct {
+	resourceApiResponse
+	Data TransactionsPoolForSenderApiResponsePayload `json:"data"`
+}
+
+// TransactionsPoolForSenderApiResponsePayload is an API resource
+type TransactionsPoolForSenderApiResponsePayload struct {
+	TxPool TransactionsPoolForSender `json:"txPool"`
+}
+
+// TransactionsPoolForSender is an API resource
+type TransactionsPoolForSender struct {
+	Transactions []TransactionInPool `json:"transactions"`
+}
+
+// TransactionInPool is an API resource
+type TransactionInPool struct {
+	TxFields TransactionInPoolFields `json:"txFields"`
+}
+
+// TransactionInPoolFields is an API resource
+type TransactionInPoolFields struct {
+	Nonce uint64 `json:"nonce"`
+}
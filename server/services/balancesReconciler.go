@@ -0,0 +1,448 @@
+package services
+
+import (
+	"math/big"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+)
+
+const (
+	defaultReconcilerInterval             = 60 * time.Second
+	defaultReconcilerNumBlocks            = 10
+	defaultReconcilerMaxNumAccounts       = 10
+	defaultReconcilerMaxRequestsPerSecond = 5
+	maxNumReconciliationDrifts            = 1000
+)
+
+// Number of requests issued against the observer (in the worst case) by the provider calls of the reconciler, accounted for by the rate limit.
+const (
+	// Node status, epoch start info, latest block, oldest block with historical state.
+	numObserverRequestsOfGetNodeStatus = 4
+	// Node status (latest nonce), the block itself, the previous and the next block (for scheduled miniblocks).
+	numObserverRequestsOfGetBlockByNonce   = 4
+	numObserverRequestsOfGetAccountBalance = 1
+)
+
+// balanceDrift describes a mismatch between the operations of an account (on a range of blocks) and the change of its balance, as provided by the observer
+type balanceDrift struct {
+	Address   string `json:"address"`
+	Currency  string `json:"currency"`
+	FromNonce uint64 `json:"from_nonce"`
+	ToNonce   uint64 `json:"to_nonce"`
+	// Sum of the amounts of the operations (in the blocks "fromNonce + 1" to "toNonce", inclusive).
+	Expected string `json:"expected"`
+	// Balance at "toNonce" minus balance at "fromNonce".
+	Actual     string `json:"actual"`
+	NumDrifts  uint64 `json:"num_drifts"`
+	DetectedAt int64  `json:"detected_at"`
+}
+
+// reconciliationStatus holds the counters of the reconciler, along with the drifts detected so far
+type reconciliationStatus struct {
+	NumRounds          uint64          `json:"num_rounds"`
+	NumCheckedBalances uint64          `json:"num_checked_balances"`
+	NumSkippedBalances uint64          `json:"num_skipped_balances"`
+	NumDrifts          uint64          `json:"num_drifts"`
+	NumErrors          uint64          `json:"num_errors"`
+	LastFromNonce      uint64          `json:"last_from_nonce"`
+	LastToNonce        uint64          `json:"last_to_nonce"`
+	LastRoundAt        int64           `json:"last_round_at"`
+	LastError          string          `json:"last_error,omitempty"`
+	Drifts             []*balanceDrift `json:"drifts"`
+}
+
+type argsNewBalancesReconciler struct {
+	provider             NetworkProvider
+	txsTransformer       *transactionsTransformer
+	interval             time.Duration
+	numBlocks            uint64
+	maxNumAccounts       int
+	maxRequestsPerSecond int
+}
+
+// balancesReconciler periodically samples accounts touched by recent blocks, and checks (against the observer) that their operations add up to the changes of their balances.
+// Requests to the observer are rate-limited: each provider call is accounted for by the number of requests it issues against the observer.
+type balancesReconciler struct {
+	provider         NetworkProvider
+	txsTransformer   *transactionsTransformer
+	interval         time.Duration
+	numBlocks        uint64
+	maxNumAccounts   int
+	requestsInterval time.Duration
+	getTime          func() time.Time
+	shuffle          func(n int, swap func(i, j int))
+
+	// Only accessed by the reconciliation loop.
+	nextRequestAt time.Time
+
+	mutex  sync.RWMutex
+	status reconciliationStatus
+	// Drifts, by account and currency.
+	drifts map[string]*balanceDrift
+
+	stopChan chan struct{}
+	stopOnce sync.Once
+}
+
+type accountAndCurrency struct {
+	address  string
+	currency string
+}
+
+// NewBalancesReconciler creates the reconciler of balances (shared by the background worker and the reconciliation controller)
+func NewBalancesReconciler(provider NetworkProvider, txsTransformer *transactionsTransformer) *balancesReconciler {
+	networkConfig := provider.GetNetworkConfig()
+
+	return newBalancesReconciler(argsNewBalancesReconciler{
+		provider:             provider,
+		txsTransformer:       txsTransformer,
+		interval:             time.Duration(networkConfig.ReconcilerIntervalSeconds) * time.Second,
+		numBlocks:            uint64(networkConfig.ReconcilerNumBlocks),
+		maxNumAccounts:       int(networkConfig.ReconcilerMaxNumAccounts),
+		maxRequestsPerSecond: int(networkConfig.ReconcilerMaxRequestsPerSecond),
+	})
+}
+
+func newBalancesReconciler(args argsNewBalancesReconciler) *balancesReconciler {
+	interval := args.interval
+	if interval <= 0 {
+		interval = defaultReconcilerInterval
+	}
+
+	numBlocks := args.numBlocks
+	if numBlocks == 0 {
+		numBlocks = defaultReconcilerNumBlocks
+	}
+
+	maxNumAccounts := args.maxNumAccounts
+	if maxNumAccounts <= 0 {
+		maxNumAccounts = defaultReconcilerMaxNumAccounts
+	}
+
+	maxRequestsPerSecond := args.maxRequestsPerSecond
+	if maxRequestsPerSecond <= 0 {
+		maxRequestsPerSecond = defaultReconcilerMaxRequestsPerSecond
+	}
+
+	return &balancesReconciler{
+		provider:         args.provider,
+		txsTransformer:   args.txsTransformer,
+		interval:         interval,
+		numBlocks:        numBlocks,
+		maxNumAccounts:   maxNumAccounts,
+		requestsInterval: time.Second / time.Duration(maxRequestsPerSecond),
+		getTime:          time.Now,
+		shuffle:          rand.Shuffle,
+		drifts:           make(map[string]*balanceDrift),
+		stopChan:         make(chan struct{}),
+	}
+}
+
+// Start reconciles (in the background, periodically), until "Close" is called
+func (reconciler *balancesReconciler) Start() {
+	log.Info("balancesReconciler.Start()", "interval", reconciler.interval, "numBlocks", reconciler.numBlocks, "maxNumAccounts", reconciler.maxNumAccounts, "requestsInterval", reconciler.requestsInterval)
+
+	go func() {
+		ticker := time.NewTicker(reconciler.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				reconciler.reconcileOnce()
+			case <-reconciler.stopChan:
+				return
+			}
+		}
+	}()
+}
+
+// Close stops the reconciliation (the current round is abandoned)
+func (reconciler *balancesReconciler) Close() error {
+	reconciler.stopOnce.Do(func() {
+		close(reconciler.stopChan)
+	})
+
+	return nil
+}
+
+// reconcileOnce reconciles the accounts (a sample) touched by the most recent (final) blocks.
+// Balances that cannot be fetched are skipped (the round goes on with the other accounts).
+func (reconciler *balancesReconciler) reconcileOnce() {
+	if !reconciler.waitBeforeRequests(numObserverRequestsOfGetNodeStatus) {
+		return
+	}
+
+	nodeStatus, err := reconciler.provider.GetNodeStatus()
+	if err != nil {
+		reconciler.onError("cannot get node status", err)
+		return
+	}
+
+	toNonce := nodeStatus.LatestBlock.Nonce
+	oldestNonce := nodeStatus.OldestBlockWithHistoricalState.Nonce
+	if toNonce <= oldestNonce {
+		return
+	}
+
+	fromNonce := oldestNonce
+	if toNonce-oldestNonce > reconciler.numBlocks {
+		fromNonce = toNonce - reconciler.numBlocks
+	}
+
+	expectedChanges, ok := reconciler.sumOperations(fromNonce, toNonce)
+	if !ok {
+		return
+	}
+
+	sample := reconciler.sampleAccounts(expectedChanges)
+
+	for _, key := range sample {
+		if !reconciler.waitBeforeRequests(2 * numObserverRequestsOfGetAccountBalance) {
+			return
+		}
+
+		actualChange, err := reconciler.getChangeOfBalance(key, fromNonce, toNonce)
+		if err != nil {
+			reconciler.recordSkip(key, err)
+			continue
+		}
+
+		reconciler.recordCheck(key, fromNonce, toNonce, expectedChanges[key], actualChange)
+	}
+
+	reconciler.recordRound(fromNonce, toNonce)
+}
+
+// sumOperations adds up the (successful) operations of the blocks "fromNonce + 1" to "toNonce" (inclusive), by account and currency
+func (reconciler *balancesReconciler) sumOperations(fromNonce uint64, toNonce uint64) (map[accountAndCurrency]*big.Int, bool) {
+	sums := make(map[accountAndCurrency]*big.Int)
+
+	for nonce := fromNonce + 1; nonce <= toNonce; nonce++ {
+		if !reconciler.waitBeforeRequests(numObserverRequestsOfGetBlockByNonce) {
+			return nil, false
+		}
+
+		block, err := reconciler.provider.GetBlockByNonce(nonce)
+		if err != nil {
+			reconciler.onError("cannot get block", err)
+			return nil, false
+		}
+
+		rosettaTxs, err := reconciler.txsTransformer.transformBlockTxs(block)
+		if err != nil {
+			reconciler.onError("cannot transform block", err)
+			return nil, false
+		}
+
+		for _, rosettaTx := range rosettaTxs {
+			for _, operation := range rosettaTx.Operations {
+				if operation.Status == nil || *operation.Status != opStatusSuccess {
+					continue
+				}
+
+				value, err := parseAmountOfOperation(operation)
+				if err != nil {
+					reconciler.onError("cannot parse amount", err)
+					return nil, false
+				}
+
+				key := accountAndCurrency{
+					address:  operation.Account.Address,
+					currency: operation.Amount.Currency.Symbol,
+				}
+
+				if _, ok := sums[key]; !ok {
+					sums[key] = big.NewInt(0)
+				}
+
+				sums[key].Add(sums[key], value)
+			}
+		}
+	}
+
+	return sums, true
+}
+
+func (reconciler *balancesReconciler) sampleAccounts(expectedChanges map[accountAndCurrency]*big.Int) []accountAndCurrency {
+	keys := make([]accountAndCurrency, 0, len(expectedChanges))
+	for key := range expectedChanges {
+		keys = append(keys, key)
+	}
+
+	// Sorted first, so that sampling only depends on the shuffling.
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].address != keys[j].address {
+			return keys[i].address < keys[j].address
+		}
+
+		return keys[i].currency < keys[j].currency
+	})
+
+	reconciler.shuffle(len(keys), func(i, j int) {
+		keys[i], keys[j] = keys[j], keys[i]
+	})
+
+	if len(keys) > reconciler.maxNumAccounts {
+		keys = keys[:reconciler.maxNumAccounts]
+	}
+
+	return keys
+}
+
+func (reconciler *balancesReconciler) getChangeOfBalance(key accountAndCurrency, fromNonce uint64, toNonce uint64) (*big.Int, error) {
+	balanceBefore, err := reconciler.getBalance(key, fromNonce)
+	if err != nil {
+		return nil, err
+	}
+
+	balanceAfter, err := reconciler.getBalance(key, toNonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return big.NewInt(0).Sub(balanceAfter, balanceBefore), nil
+}
+
+func (reconciler *balancesReconciler) getBalance(key accountAndCurrency, nonce uint64) (*big.Int, error) {
+	options := resources.NewAccountQueryOptionsWithBlockNonce(nonce)
+	accountBalance, err := reconciler.provider.GetAccountBalance(key.address, key.currency, options)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, ok := big.NewInt(0).SetString(accountBalance.Balance, 10)
+	if !ok {
+		return nil, errCannotParseBalance
+	}
+
+	return balance, nil
+}
+
+// waitBeforeRequests enforces the rate limit, given the number of requests (to the observer) about to be issued.
+// It returns false if the reconciler has been stopped in the meantime.
+func (reconciler *balancesReconciler) waitBeforeRequests(numRequests int) bool {
+	wait := reconciler.nextRequestAt.Sub(reconciler.getTime())
+	if wait > 0 {
+		select {
+		case <-time.After(wait):
+		case <-reconciler.stopChan:
+			return false
+		}
+	}
+
+	select {
+	case <-reconciler.stopChan:
+		return false
+	default:
+	}
+
+	reconciler.nextRequestAt = reconciler.getTime().Add(time.Duration(numRequests) * reconciler.requestsInterval)
+	return true
+}
+
+func (reconciler *balancesReconciler) recordCheck(key accountAndCurrency, fromNonce uint64, toNonce uint64, expected *big.Int, actual *big.Int) {
+	reconciler.mutex.Lock()
+	defer reconciler.mutex.Unlock()
+
+	reconciler.status.NumCheckedBalances++
+
+	if expected.Cmp(actual) == 0 {
+		return
+	}
+
+	log.Warn("balancesReconciler: drift detected",
+		"address", key.address,
+		"currency", key.currency,
+		"fromNonce", fromNonce,
+		"toNonce", toNonce,
+		"expected", expected.String(),
+		"actual", actual.String(),
+	)
+
+	reconciler.status.NumDrifts++
+
+	driftKey := key.address + "/" + key.currency
+	drift, ok := reconciler.drifts[driftKey]
+	if !ok {
+		if len(reconciler.drifts) >= maxNumReconciliationDrifts {
+			// Already counted (above), but not held.
+			return
+		}
+
+		drift = &balanceDrift{
+			Address:  key.address,
+			Currency: key.currency,
+		}
+
+		reconciler.drifts[driftKey] = drift
+	}
+
+	drift.FromNonce = fromNonce
+	drift.ToNonce = toNonce
+	drift.Expected = expected.String()
+	drift.Actual = actual.String()
+	drift.NumDrifts++
+	drift.DetectedAt = reconciler.getTime().UnixMilli()
+}
+
+func (reconciler *balancesReconciler) recordRound(fromNonce uint64, toNonce uint64) {
+	reconciler.mutex.Lock()
+	defer reconciler.mutex.Unlock()
+
+	reconciler.status.NumRounds++
+	reconciler.status.LastFromNonce = fromNonce
+	reconciler.status.LastToNonce = toNonce
+	reconciler.status.LastRoundAt = reconciler.getTime().UnixMilli()
+}
+
+// recordSkip records a balance that couldn't be checked (e.g. the observer couldn't provide it)
+func (reconciler *balancesReconciler) recordSkip(key accountAndCurrency, err error) {
+	log.Warn("balancesReconciler: cannot get balance, skipped", "address", key.address, "currency", key.currency, "err", err)
+
+	reconciler.mutex.Lock()
+	defer reconciler.mutex.Unlock()
+
+	reconciler.status.NumSkippedBalances++
+	reconciler.status.NumErrors++
+	reconciler.status.LastError = "cannot get balance: " + err.Error()
+}
+
+// onError records an error. The round is abandoned (it will be retried, on other blocks, in the next round).
+func (reconciler *balancesReconciler) onError(message string, err error) {
+	log.Warn("balancesReconciler: "+message, "err", err)
+
+	reconciler.mutex.Lock()
+	defer reconciler.mutex.Unlock()
+
+	reconciler.status.NumErrors++
+	reconciler.status.LastError = message + ": " + err.Error()
+}
+
+// getStatus returns a copy of the status (drifts are sorted by account and currency)
+func (reconciler *balancesReconciler) getStatus() reconciliationStatus {
+	reconciler.mutex.RLock()
+	defer reconciler.mutex.RUnlock()
+
+	status := reconciler.status
+	status.Drifts = make([]*balanceDrift, 0, len(reconciler.drifts))
+
+	for _, drift := range reconciler.drifts {
+		driftCopy := *drift
+		status.Drifts = append(status.Drifts, &driftCopy)
+	}
+
+	sort.Slice(status.Drifts, func(i, j int) bool {
+		if status.Drifts[i].Address != status.Drifts[j].Address {
+			return status.Drifts[i].Address < status.Drifts[j].Address
+		}
+
+		return status.Drifts[i].Currency < status.Drifts[j].Currency
+	})
+
+	return status
+}
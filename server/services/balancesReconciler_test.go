@@ -0,0 +1,227 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/data/api"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBalancesReconciler_ReconcileOnce(t *testing.T) {
+	t.Parallel()
+
+	// Bob is in the observed shard, Alice isn't.
+	blocksByNonce := map[uint64]*api.Block{
+		2: {
+			Nonce: 2,
+			MiniBlocks: []*api.MiniBlock{
+				{
+					Transactions: []*transaction.ApiTransactionResult{
+						{
+							Type:             string(transaction.TxTypeNormal),
+							Hash:             "aaaa",
+							Sender:           testscommon.TestAddressBob,
+							Receiver:         testscommon.TestAddressAlice,
+							Value:            "100",
+							InitiallyPaidFee: "50000000000000",
+						},
+					},
+				},
+			},
+		},
+		3: {
+			Nonce: 3,
+			MiniBlocks: []*api.MiniBlock{
+				{
+					Transactions: []*transaction.ApiTransactionResult{
+						{
+							Type:     string(transaction.TxTypeReward),
+							Hash:     "bbbb",
+							Receiver: testscommon.TestAddressBob,
+							Value:    "7",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	nodeStatus := &resources.AggregatedNodeStatus{
+		LatestBlock:                    resources.BlockSummary{Nonce: 3},
+		OldestBlockWithHistoricalState: resources.BlockSummary{Nonce: 1},
+	}
+
+	createReconciler := func(networkProvider NetworkProvider) *balancesReconciler {
+		reconciler := newBalancesReconciler(argsNewBalancesReconciler{
+			provider:             networkProvider,
+			txsTransformer:       newTransactionsTransformer(networkProvider),
+			maxRequestsPerSecond: 1000,
+		})
+
+		reconciler.shuffle = func(_ int, _ func(i, j int)) {}
+		return reconciler
+	}
+
+	balancesOfBob := func(balanceAtTo string) func(string, string, resources.AccountQueryOptions) (*resources.AccountBalanceOnBlock, error) {
+		return func(address string, tokenIdentifier string, options resources.AccountQueryOptions) (*resources.AccountBalanceOnBlock, error) {
+			if address != testscommon.TestAddressBob || tokenIdentifier != "XeGLD" {
+				return nil, fmt.Errorf("unexpected request: %s, %s", address, tokenIdentifier)
+			}
+
+			switch options.BlockNonce.Value {
+			case 1:
+				return &resources.AccountBalanceOnBlock{Balance: "1000000000000000"}, nil
+			case 3:
+				return &resources.AccountBalanceOnBlock{Balance: balanceAtTo}, nil
+			default:
+				return nil, fmt.Errorf("unexpected nonce: %d", options.BlockNonce.Value)
+			}
+		}
+	}
+
+	t.Run("without drift", func(t *testing.T) {
+		networkProvider := testscommon.NewNetworkProviderMock()
+		networkProvider.MockNodeStatus = nodeStatus
+		networkProvider.MockBlocksByNonce = blocksByNonce
+		networkProvider.GetAccountBalanceCalled = balancesOfBob("949999999999907")
+		reconciler := createReconciler(networkProvider)
+
+		reconciler.reconcileOnce()
+
+		status := reconciler.getStatus()
+		require.Equal(t, uint64(1), status.NumRounds)
+		require.Equal(t, uint64(1), status.NumCheckedBalances)
+		require.Equal(t, uint64(0), status.NumDrifts)
+		require.Equal(t, uint64(0), status.NumErrors)
+		require.Equal(t, uint64(1), status.LastFromNonce)
+		require.Equal(t, uint64(3), status.LastToNonce)
+		require.Empty(t, status.Drifts)
+	})
+
+	t.Run("with drift", func(t *testing.T) {
+		networkProvider := testscommon.NewNetworkProviderMock()
+		networkProvider.MockNodeStatus = nodeStatus
+		networkProvider.MockBlocksByNonce = blocksByNonce
+		networkProvider.GetAccountBalanceCalled = balancesOfBob("949999999999900")
+		reconciler := createReconciler(networkProvider)
+
+		reconciler.reconcileOnce()
+		reconciler.reconcileOnce()
+
+		status := reconciler.getStatus()
+		require.Equal(t, uint64(2), status.NumRounds)
+		require.Equal(t, uint64(2), status.NumCheckedBalances)
+		require.Equal(t, uint64(2), status.NumDrifts)
+		require.Len(t, status.Drifts, 1)
+		require.Equal(t, testscommon.TestAddressBob, status.Drifts[0].Address)
+		require.Equal(t, "XeGLD", status.Drifts[0].Currency)
+		require.Equal(t, uint64(1), status.Drifts[0].FromNonce)
+		require.Equal(t, uint64(3), status.Drifts[0].ToNonce)
+		require.Equal(t, "-50000000000093", status.Drifts[0].Expected)
+		require.Equal(t, "-50000000000100", status.Drifts[0].Actual)
+		require.Equal(t, uint64(2), status.Drifts[0].NumDrifts)
+	})
+
+	t.Run("with window limited by the number of blocks", func(t *testing.T) {
+		networkProvider := testscommon.NewNetworkProviderMock()
+		networkProvider.MockNodeStatus = nodeStatus
+		networkProvider.MockBlocksByNonce = blocksByNonce
+		networkProvider.GetAccountBalanceCalled = func(_ string, _ string, options resources.AccountQueryOptions) (*resources.AccountBalanceOnBlock, error) {
+			balances := map[uint64]string{2: "1000", 3: "1007"}
+			return &resources.AccountBalanceOnBlock{Balance: balances[options.BlockNonce.Value]}, nil
+		}
+
+		reconciler := createReconciler(networkProvider)
+		reconciler.numBlocks = 1
+
+		reconciler.reconcileOnce()
+
+		status := reconciler.getStatus()
+		require.Equal(t, uint64(2), status.LastFromNonce)
+		require.Equal(t, uint64(3), status.LastToNonce)
+		require.Equal(t, uint64(1), status.NumCheckedBalances)
+		require.Equal(t, uint64(0), status.NumDrifts)
+	})
+
+	t.Run("with sampling", func(t *testing.T) {
+		networkProvider := testscommon.NewNetworkProviderMock()
+		networkProvider.MockNodeStatus = nodeStatus
+		networkProvider.MockBlocksByNonce = blocksByNonce
+		reconciler := createReconciler(networkProvider)
+		reconciler.maxNumAccounts = 1
+
+		expectedChanges, ok := reconciler.sumOperations(1, 3)
+		require.True(t, ok)
+		require.Len(t, expectedChanges, 1)
+
+		expectedChanges[accountAndCurrency{address: testscommon.TestAddressCarol, currency: "XeGLD"}] = nil
+		require.Len(t, reconciler.sampleAccounts(expectedChanges), 1)
+	})
+
+	t.Run("with errors", func(t *testing.T) {
+		networkProvider := testscommon.NewNetworkProviderMock()
+		networkProvider.MockNodeStatus = nodeStatus
+		networkProvider.MockBlocksByNonce = blocksByNonce
+		networkProvider.GetAccountBalanceCalled = func(_ string, _ string, _ resources.AccountQueryOptions) (*resources.AccountBalanceOnBlock, error) {
+			return nil, errors.New("arbitrary error")
+		}
+
+		reconciler := createReconciler(networkProvider)
+		reconciler.reconcileOnce()
+
+		// The balance is skipped, but the round is completed.
+		status := reconciler.getStatus()
+		require.Equal(t, uint64(1), status.NumRounds)
+		require.Equal(t, uint64(0), status.NumCheckedBalances)
+		require.Equal(t, uint64(1), status.NumSkippedBalances)
+		require.Equal(t, uint64(1), status.NumErrors)
+		require.Equal(t, "cannot get balance: arbitrary error", status.LastError)
+		require.Equal(t, uint64(3), status.LastToNonce)
+	})
+
+	t.Run("when closed", func(t *testing.T) {
+		networkProvider := testscommon.NewNetworkProviderMock()
+		networkProvider.MockNodeStatus = nodeStatus
+		networkProvider.MockBlocksByNonce = blocksByNonce
+		reconciler := createReconciler(networkProvider)
+
+		err := reconciler.Close()
+		require.Nil(t, err)
+		reconciler.reconcileOnce()
+
+		status := reconciler.getStatus()
+		require.Equal(t, uint64(0), status.NumRounds)
+		require.Equal(t, uint64(0), status.NumErrors)
+	})
+}
+
+func TestBalancesReconciler_WaitBeforeRequests(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1700000000, 0)
+
+	reconciler := newBalancesReconciler(argsNewBalancesReconciler{
+		provider:             testscommon.NewNetworkProviderMock(),
+		maxRequestsPerSecond: 1000,
+	})
+	reconciler.getTime = func() time.Time {
+		return now
+	}
+
+	// Each provider call is accounted for by the number of requests it issues against the observer.
+	require.True(t, reconciler.waitBeforeRequests(numObserverRequestsOfGetBlockByNonce))
+	require.Equal(t, now.Add(4*time.Millisecond), reconciler.nextRequestAt)
+
+	now = now.Add(4 * time.Millisecond)
+	require.True(t, reconciler.waitBeforeRequests(numObserverRequestsOfGetAccountBalance))
+	require.Equal(t, now.Add(time.Millisecond), reconciler.nextRequestAt)
+
+	_ = reconciler.Close()
+	require.False(t, reconciler.waitBeforeRequests(numObserverRequestsOfGetAccountBalance))
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/coinbase/rosetta-sdk-go/server"
+)
+
+type batchSubmitController struct {
+	submitter *transactionsSubmitter
+	routes    []server.Route
+}
+
+type batchSubmitRequest struct {
+	SignedTransactions []string `json:"signed_transactions"`
+}
+
+type batchSubmitResponse struct {
+	Results []*batchSubmitResult `json:"results"`
+}
+
+type batchSubmitErrorResponse struct {
+	Message string `json:"message"`
+}
+
+// NewBatchSubmitController creates a controller (non-Rosetta routes) for submitting batches of signed transactions.
+// For the ordering guarantees (transactions of the same sender), see "transactionsSubmitter.submitBatch".
+func NewBatchSubmitController(submitter *transactionsSubmitter) *batchSubmitController {
+	controller := &batchSubmitController{
+		submitter: submitter,
+	}
+
+	controller.routes = []server.Route{
+		{
+			Method:      http.MethodPost,
+			Pattern:     "/construction/submit-batch",
+			HandlerFunc: controller.handleSubmitBatch,
+		},
+	}
+
+	return controller
+}
+
+// Routes returns the routes of the controller
+func (controller *batchSubmitController) Routes() server.Routes {
+	return controller.routes
+}
+
+func (controller *batchSubmitController) handleSubmitBatch(w http.ResponseWriter, r *http.Request) {
+	request := &batchSubmitRequest{}
+
+	err := json.NewDecoder(r.Body).Decode(request)
+	if err != nil {
+		server.EncodeJSONResponse(&batchSubmitErrorResponse{Message: err.Error()}, http.StatusBadRequest, w)
+		return
+	}
+
+	results, errTyped := controller.submitter.submitBatch(request.SignedTransactions)
+	if errTyped != nil {
+		server.EncodeJSONResponse(errTyped, http.StatusInternalServerError, w)
+		return
+	}
+
+	server.EncodeJSONResponse(&batchSubmitResponse{Results: results}, http.StatusOK, w)
+}
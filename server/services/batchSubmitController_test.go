@@ -0,0 +1,49 @@
+package services
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/multiversx/mx-chain-proxy-go/data"
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchSubmitController(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	networkProvider.SendTransactionsCalled = func(txs []*data.Transaction) (map[int]string, error) {
+		return map[int]string{0: "aaaa"}, nil
+	}
+
+	submitter := NewTransactionsSubmitter(networkProvider)
+	controller := NewBatchSubmitController(submitter)
+	require.Len(t, controller.Routes(), 1)
+
+	handler := controller.Routes()[0].HandlerFunc
+
+	doRequest := func(body string) (int, *batchSubmitResponse) {
+		request := httptest.NewRequest(http.MethodPost, "/construction/submit-batch", strings.NewReader(body))
+		recorder := httptest.NewRecorder()
+		handler(recorder, request)
+
+		response := &batchSubmitResponse{}
+		_ = json.Unmarshal(recorder.Body.Bytes(), response)
+		return recorder.Code, response
+	}
+
+	body, _ := json.Marshal(&batchSubmitRequest{SignedTransactions: createSignedTransactionsForBatch(42, 43)})
+
+	code, response := doRequest(string(body))
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, response.Results, 2)
+	require.Equal(t, "aaaa", response.Results[0].Hash)
+	require.Equal(t, int32(ErrUnableToSubmitTransaction), response.Results[1].Error.Code)
+
+	code, _ = doRequest("not json")
+	require.Equal(t, http.StatusBadRequest, code)
+}
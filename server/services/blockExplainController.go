@@ -0,0 +1,74 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/coinbase/rosetta-sdk-go/server"
+)
+
+type blockExplainController struct {
+	explainer *blockExplainer
+	routes    []server.Route
+}
+
+type blockExplainErrorResponse struct {
+	Message string `json:"message"`
+}
+
+// NewBlockExplainController creates a controller (non-Rosetta routes) for explaining (debugging) how the transactions of a block are transformed into Rosetta transactions
+func NewBlockExplainController(explainer *blockExplainer) *blockExplainController {
+	controller := &blockExplainController{
+		explainer: explainer,
+	}
+
+	controller.routes = []server.Route{
+		{
+			Method:      http.MethodPost,
+			Pattern:     "/block/explain",
+			HandlerFunc: controller.handleExplain,
+		},
+	}
+
+	return controller
+}
+
+// Routes returns the routes of the controller
+func (controller *blockExplainController) Routes() server.Routes {
+	return controller.routes
+}
+
+func (controller *blockExplainController) handleExplain(w http.ResponseWriter, r *http.Request) {
+	request := &blockExplanationRequest{}
+
+	err := json.NewDecoder(r.Body).Decode(request)
+	if err != nil {
+		controller.respondWithError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	explanation, err := controller.explainer.explainBlock(request)
+	if err != nil {
+		controller.respondWithError(w, decideStatusOfExplanationError(err), err)
+		return
+	}
+
+	server.EncodeJSONResponse(explanation, http.StatusOK, w)
+}
+
+func decideStatusOfExplanationError(err error) int {
+	isBadRequest := errors.Is(err, errNothingToExplain) ||
+		errors.Is(err, errCannotExplainGenesisBlock) ||
+		errors.Is(err, errTransactionNotInBlock)
+
+	if isBadRequest {
+		return http.StatusBadRequest
+	}
+
+	return http.StatusInternalServerError
+}
+
+func (controller *blockExplainController) respondWithError(w http.ResponseWriter, status int, err error) {
+	server.EncodeJSONResponse(&blockExplainErrorResponse{Message: err.Error()}, status, w)
+}
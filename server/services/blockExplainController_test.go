@@ -0,0 +1,174 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-core-go/data/api"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockExplainController(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	explainer := NewBlockExplainer(networkProvider, newTransactionsTransformer(networkProvider))
+	controller := NewBlockExplainController(explainer)
+	require.Len(t, controller.Routes(), 1)
+
+	txMoveBalance := &transaction.ApiTransactionResult{
+		Type:             string(transaction.TxTypeNormal),
+		Hash:             "aaaa",
+		Sender:           testscommon.TestAddressAlice,
+		Receiver:         testscommon.TestAddressBob,
+		Value:            "1234",
+		InitiallyPaidFee: "50000000000000",
+	}
+
+	txScheduled := &transaction.ApiTransactionResult{
+		Type:             string(transaction.TxTypeNormal),
+		Hash:             "bbbb",
+		Sender:           testscommon.TestAddressAlice,
+		Receiver:         testscommon.TestAddressCarol,
+		Value:            "5",
+		InitiallyPaidFee: "50000000000000",
+	}
+
+	scrWithZeroValue := &transaction.ApiTransactionResult{
+		Type:                    string(transaction.TxTypeUnsigned),
+		Hash:                    "cccc",
+		Sender:                  testscommon.TestAddressBob,
+		Receiver:                testscommon.TestAddressAlice,
+		Value:                   "0",
+		OriginalTransactionHash: "aaaa",
+	}
+
+	networkProvider.MockRawBlocksByNonce[7] = &api.Block{
+		Nonce: 7,
+		Hash:  "0007",
+		MiniBlocks: []*api.MiniBlock{
+			{Transactions: []*transaction.ApiTransactionResult{txMoveBalance, txScheduled}},
+		},
+	}
+
+	networkProvider.MockBlocksByNonce[7] = &api.Block{
+		Nonce: 7,
+		Hash:  "0007",
+		MiniBlocks: []*api.MiniBlock{
+			{Transactions: []*transaction.ApiTransactionResult{txMoveBalance}},
+			{Transactions: []*transaction.ApiTransactionResult{scrWithZeroValue}},
+		},
+	}
+
+	networkProvider.MockTransactionsByHash["bbbb"] = &transaction.ApiTransactionResult{Hash: "bbbb", BlockNonce: 7}
+	networkProvider.MockTransactionsByHash["ffff"] = &transaction.ApiTransactionResult{Hash: "ffff"}
+
+	doExplain := func(request string) (int, []byte) {
+		httpRequest := httptest.NewRequest(http.MethodPost, "/block/explain", bytes.NewBufferString(request))
+		recorder := httptest.NewRecorder()
+		controller.Routes()[0].HandlerFunc(recorder, httpRequest)
+		return recorder.Code, recorder.Body.Bytes()
+	}
+
+	parseExplanation := func(t *testing.T, body []byte) *blockExplanation {
+		explanation := &blockExplanation{}
+		err := json.Unmarshal(body, explanation)
+		require.Nil(t, err)
+		return explanation
+	}
+
+	getRules := func(explanation *blockExplanation) []string {
+		rules := make([]string, 0, len(explanation.Steps))
+		for _, step := range explanation.Steps {
+			rules = append(rules, step.Rule)
+		}
+
+		return rules
+	}
+
+	t.Run("whole block", func(t *testing.T) {
+		status, body := doExplain(`{"block_identifier": {"index": 7}}`)
+		require.Equal(t, http.StatusOK, status)
+
+		explanation := parseExplanation(t, body)
+		require.Equal(t, &types.BlockIdentifier{Index: 7, Hash: "0007"}, explanation.BlockIdentifier)
+		require.Len(t, explanation.RawMiniblocks, 1)
+		require.Len(t, explanation.EffectiveTransactions, 2)
+		require.Equal(t, []string{
+			ruleSimplifyBlockWithScheduledTransactions,
+			ruleFilterOperationsByAddress,
+			ruleFilterOperationsByAddress,
+			ruleFilterOutOperationsWithZeroAmount,
+			ruleFilterOutRosettaTransactionsWithNoOperations,
+		}, getRules(explanation))
+
+		require.Equal(t, []string{"bbbb"}, explanation.Steps[0].RemovedTransactions)
+		require.Equal(t, []string{"cccc"}, explanation.Steps[0].AddedTransactions)
+		// Alice isn't in the observed shard.
+		require.Equal(t, "aaaa", explanation.Steps[1].TransactionHash)
+		require.Equal(t, testscommon.TestAddressAlice, explanation.Steps[1].RemovedOperations[0].Account.Address)
+		require.Equal(t, "cccc", explanation.Steps[2].TransactionHash)
+		require.Equal(t, testscommon.TestAddressAlice, explanation.Steps[2].RemovedOperations[0].Account.Address)
+		require.Equal(t, "cccc", explanation.Steps[3].TransactionHash)
+		require.Len(t, explanation.Steps[3].RemovedOperations, 1)
+		require.Equal(t, []string{"cccc"}, explanation.Steps[4].RemovedTransactions)
+
+		require.Len(t, explanation.Transactions, 1)
+		require.Equal(t, "aaaa", explanation.Transactions[0].TransactionIdentifier.Hash)
+	})
+
+	t.Run("narrowed down to a transaction (with contract results)", func(t *testing.T) {
+		status, body := doExplain(`{"block_identifier": {"index": 7}, "transaction_hash": "aaaa"}`)
+		require.Equal(t, http.StatusOK, status)
+
+		explanation := parseExplanation(t, body)
+		require.Len(t, explanation.RawMiniblocks, 1)
+		require.Len(t, explanation.RawMiniblocks[0].Transactions, 1)
+		require.Len(t, explanation.EffectiveTransactions, 2)
+		require.Equal(t, []string{
+			ruleSimplifyBlockWithScheduledTransactions,
+			ruleFilterOperationsByAddress,
+			ruleFilterOperationsByAddress,
+			ruleFilterOutOperationsWithZeroAmount,
+			ruleFilterOutRosettaTransactionsWithNoOperations,
+		}, getRules(explanation))
+		require.Empty(t, explanation.Steps[0].RemovedTransactions)
+		require.Equal(t, []string{"cccc"}, explanation.Steps[0].AddedTransactions)
+		require.Len(t, explanation.Transactions, 1)
+	})
+
+	t.Run("narrowed down to a (scheduled) transaction, block given by the transaction", func(t *testing.T) {
+		status, body := doExplain(`{"transaction_hash": "bbbb"}`)
+		require.Equal(t, http.StatusOK, status)
+
+		explanation := parseExplanation(t, body)
+		require.Equal(t, int64(7), explanation.BlockIdentifier.Index)
+		require.Len(t, explanation.RawMiniblocks, 1)
+		require.Equal(t, "bbbb", explanation.RawMiniblocks[0].Transactions[0].Hash)
+		require.Empty(t, explanation.EffectiveTransactions)
+		require.Equal(t, []string{ruleSimplifyBlockWithScheduledTransactions}, getRules(explanation))
+		require.Equal(t, []string{"bbbb"}, explanation.Steps[0].RemovedTransactions)
+		require.Empty(t, explanation.Steps[0].AddedTransactions)
+		require.Empty(t, explanation.Transactions)
+	})
+
+	t.Run("with bad requests", func(t *testing.T) {
+		status, _ := doExplain(`{}`)
+		require.Equal(t, http.StatusBadRequest, status)
+
+		status, _ = doExplain(`{"block_identifier": {"index": 0}}`)
+		require.Equal(t, http.StatusBadRequest, status)
+
+		status, _ = doExplain(`{"transaction_hash": "ffff"}`)
+		require.Equal(t, http.StatusBadRequest, status)
+
+		status, _ = doExplain(`{"block_identifier": {"index": 8}}`)
+		require.Equal(t, http.StatusInternalServerError, status)
+	})
+}
@@ -0,0 +1,231 @@
+package services
+
+import (
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-core-go/data/api"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+)
+
+type blockExplanationRequest struct {
+	BlockIdentifier *types.PartialBlockIdentifier `json:"block_identifier,omitempty"`
+	TransactionHash string                        `json:"transaction_hash,omitempty"`
+}
+
+// blockExplanation describes how the transactions of a block (as provided by the observer) are transformed into Rosetta transactions
+type blockExplanation struct {
+	BlockIdentifier       *types.BlockIdentifier              `json:"block_identifier"`
+	RawMiniblocks         []*api.MiniBlock                    `json:"raw_miniblocks"`
+	EffectiveTransactions []*transaction.ApiTransactionResult `json:"effective_transactions"`
+	Steps                 []*transformationStep               `json:"steps"`
+	Transactions          []*types.Transaction                `json:"transactions"`
+}
+
+// blockExplainer explains how blocks (as provided by the observer) are transformed into Rosetta blocks
+type blockExplainer struct {
+	provider       NetworkProvider
+	extension      *networkProviderExtension
+	txsTransformer *transactionsTransformer
+}
+
+// NewBlockExplainer creates a new instance of blockExplainer
+func NewBlockExplainer(provider NetworkProvider, txsTransformer *transactionsTransformer) *blockExplainer {
+	return &blockExplainer{
+		provider:       provider,
+		extension:      newNetworkProviderExtension(provider),
+		txsTransformer: txsTransformer,
+	}
+}
+
+// explainBlock explains the transformation of a block. If a transaction hash is given, the explanation is narrowed down to that transaction (and its contract results, receipts etc.).
+func (explainer *blockExplainer) explainBlock(request *blockExplanationRequest) (*blockExplanation, error) {
+	nonce, err := explainer.decideNonceOfBlockToExplain(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(nonce) == explainer.extension.getGenesisBlockIdentifier().Index {
+		return nil, errCannotExplainGenesisBlock
+	}
+
+	rawBlock, err := explainer.provider.GetRawBlockByNonce(nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := explainer.provider.GetBlockByNonce(nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	effectiveTxs := gatherTransactionsOfBlock(block)
+
+	trace := newTransformationTrace()
+	trace.recordSimplificationOfBlock(rawBlock, effectiveTxs)
+
+	rosettaTxs, err := explainer.txsTransformer.transformBlockTxsWithTrace(block, trace)
+	if err != nil {
+		return nil, err
+	}
+
+	explanation := &blockExplanation{
+		BlockIdentifier:       blockToIdentifier(block),
+		RawMiniblocks:         rawBlock.MiniBlocks,
+		EffectiveTransactions: effectiveTxs,
+		Steps:                 trace.steps,
+		Transactions:          rosettaTxs,
+	}
+
+	if len(request.TransactionHash) > 0 {
+		relatedHashes, err := explainer.gatherHashesRelatedToTransaction(request.TransactionHash, rawBlock, block)
+		if err != nil {
+			return nil, err
+		}
+
+		explanation.narrowDown(request.TransactionHash, relatedHashes)
+	}
+
+	return explanation, nil
+}
+
+func (explainer *blockExplainer) decideNonceOfBlockToExplain(request *blockExplanationRequest) (uint64, error) {
+	blockIdentifier := request.BlockIdentifier
+	if blockIdentifier != nil && blockIdentifier.Index != nil {
+		return uint64(*blockIdentifier.Index), nil
+	}
+
+	if blockIdentifier != nil && blockIdentifier.Hash != nil {
+		block, err := explainer.provider.GetBlockByHash(*blockIdentifier.Hash)
+		if err != nil {
+			return 0, err
+		}
+
+		return block.Nonce, nil
+	}
+
+	if len(request.TransactionHash) > 0 {
+		tx, err := explainer.provider.GetTransactionByHash(request.TransactionHash)
+		if err != nil {
+			return 0, err
+		}
+		if tx.BlockNonce == 0 {
+			return 0, errTransactionNotInBlock
+		}
+
+		return tx.BlockNonce, nil
+	}
+
+	return 0, errNothingToExplain
+}
+
+// gatherHashesRelatedToTransaction returns the hash of the transaction, along with the hashes of its contract results and receipts (held in the block)
+func (explainer *blockExplainer) gatherHashesRelatedToTransaction(txHash string, blocks ...*api.Block) (map[string]struct{}, error) {
+	related := map[string]struct{}{txHash: {}}
+
+	for _, block := range blocks {
+		for _, miniblock := range block.MiniBlocks {
+			for _, tx := range miniblock.Transactions {
+				if tx.OriginalTransactionHash == txHash {
+					related[tx.Hash] = struct{}{}
+				}
+			}
+
+			for _, receipt := range miniblock.Receipts {
+				if receipt.TxHash != txHash {
+					continue
+				}
+
+				receiptHash, err := explainer.provider.ComputeReceiptHash(receipt)
+				if err != nil {
+					return nil, err
+				}
+
+				related[receiptHash] = struct{}{}
+			}
+		}
+	}
+
+	return related, nil
+}
+
+func (explanation *blockExplanation) narrowDown(txHash string, relatedHashes map[string]struct{}) {
+	isRelated := func(hash string) bool {
+		_, ok := relatedHashes[hash]
+		return ok
+	}
+
+	rawMiniblocks := make([]*api.MiniBlock, 0)
+	for _, miniblock := range explanation.RawMiniblocks {
+		miniblockCopy := *miniblock
+		miniblockCopy.Transactions = filterTransactionsByHash(miniblock.Transactions, isRelated)
+		miniblockCopy.Receipts = make([]*transaction.ApiReceipt, 0)
+		for _, receipt := range miniblock.Receipts {
+			if receipt.TxHash == txHash {
+				miniblockCopy.Receipts = append(miniblockCopy.Receipts, receipt)
+			}
+		}
+
+		if len(miniblockCopy.Transactions) > 0 || len(miniblockCopy.Receipts) > 0 {
+			rawMiniblocks = append(rawMiniblocks, &miniblockCopy)
+		}
+	}
+
+	explanation.RawMiniblocks = rawMiniblocks
+	explanation.EffectiveTransactions = filterTransactionsByHash(explanation.EffectiveTransactions, isRelated)
+
+	steps := make([]*transformationStep, 0)
+	for _, step := range explanation.Steps {
+		stepCopy := *step
+		stepCopy.RemovedTransactions = filterStrings(step.RemovedTransactions, isRelated)
+		stepCopy.AddedTransactions = filterStrings(step.AddedTransactions, isRelated)
+
+		isRelatedStep := isRelated(step.TransactionHash) || len(stepCopy.RemovedTransactions) > 0 || len(stepCopy.AddedTransactions) > 0
+		if isRelatedStep {
+			steps = append(steps, &stepCopy)
+		}
+	}
+
+	explanation.Steps = steps
+
+	rosettaTxs := make([]*types.Transaction, 0)
+	for _, rosettaTx := range explanation.Transactions {
+		if isRelated(rosettaTx.TransactionIdentifier.Hash) {
+			rosettaTxs = append(rosettaTxs, rosettaTx)
+		}
+	}
+
+	explanation.Transactions = rosettaTxs
+}
+
+func gatherTransactionsOfBlock(block *api.Block) []*transaction.ApiTransactionResult {
+	txs := make([]*transaction.ApiTransactionResult, 0)
+
+	for _, miniblock := range block.MiniBlocks {
+		txs = append(txs, miniblock.Transactions...)
+	}
+
+	return txs
+}
+
+func filterTransactionsByHash(txs []*transaction.ApiTransactionResult, predicate func(hash string) bool) []*transaction.ApiTransactionResult {
+	result := make([]*transaction.ApiTransactionResult, 0, len(txs))
+
+	for _, tx := range txs {
+		if predicate(tx.Hash) {
+			result = append(result, tx)
+		}
+	}
+
+	return result
+}
+
+func filterStrings(items []string, predicate func(item string) bool) []string {
+	result := make([]string, 0, len(items))
+
+	for _, item := range items {
+		if predicate(item) {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
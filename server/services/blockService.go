@@ -22,15 +22,16 @@ type blockService struct {
 	genesisBlockMutex sync.RWMutex
 }
 
-// NewBlockService will create a new instance of blockService
-func NewBlockService(provider NetworkProvider) server.BlockAPIServicer {
+// NewBlockService will create a new instance of blockService.
+// The transactions transformer is shared with the extension controllers (non-Rosetta routes), e.g. for diagnostics.
+func NewBlockService(provider NetworkProvider, txsTransformer *transactionsTransformer) server.BlockAPIServicer {
 	extension := newNetworkProviderExtension(provider)
 
 	return &blockService{
 		provider:       provider,
 		extension:      extension,
 		errFactory:     newErrFactory(),
-		txsTransformer: newTransactionsTransformer(provider),
+		txsTransformer: txsTransformer,
 	}
 }
 
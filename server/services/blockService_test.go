@@ -52,7 +52,7 @@ func TestBlockService_BlockByIndex(t *testing.T) {
 		MiniBlocks:    []*api.MiniBlock{{Transactions: []*transaction.ApiTransactionResult{}}},
 	}
 
-	service := NewBlockService(networkProvider)
+	service := NewBlockService(networkProvider, newTransactionsTransformer(networkProvider))
 
 	blockSeven := &types.Block{
 		BlockIdentifier:       &types.BlockIdentifier{Index: 7, Hash: "0007"},
@@ -6,10 +6,14 @@ import (
 	"time"
 
 	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
 )
 
 var (
 	transactionVersion                                    = 1
+	transactionVersionWithOptions                         = 2
+	transactionOptionSignedWithHash                       = transaction.MaskSignedWithHash
+	transactionOptionGuarded                              = transaction.MaskGuardedTransaction
 	transactionProcessingTypeRelayedV1                    = "RelayedTx"
 	transactionProcessingTypeBuiltInFunctionCall          = "BuiltInFunctionCall"
 	transactionProcessingTypeMoveBalance                  = "MoveBalance"
@@ -18,19 +22,29 @@ var (
 	amountZero                                            = "0"
 	builtInFunctionClaimDeveloperRewards                  = core.BuiltInFunctionClaimDeveloperRewards
 	builtInFunctionESDTTransfer                           = core.BuiltInFunctionESDTTransfer
+	builtInFunctionESDTNFTTransfer                        = core.BuiltInFunctionESDTNFTTransfer
+	builtInFunctionMultiESDTNFTTransfer                   = core.BuiltInFunctionMultiESDTNFTTransfer
 	refundGasMessage                                      = "refundedGas"
 	argumentsSeparator                                    = "@"
 	sendingValueToNonPayableContractDataPrefix            = argumentsSeparator + hex.EncodeToString([]byte("sending value to non payable contract"))
 	emptyHash                                             = strings.Repeat("0", 64)
 	nodeVersionForOfflineRosetta                          = "N / A"
 	systemContractDeployAddress                           = "erd1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq6gq4hu"
+	delegationManagerContractAddress                      = "erd1qqqqqqqqqqqqqqqpqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqylllslmq6y6"
 	nativeAsESDTIdentifier                                = "EGLD-000000"
+	gasEstimationMethodProvided                           = "provided"
+	gasEstimationMethodObserver                           = "observer"
+	gasEstimationMethodStatic                             = "static"
+	gasEstimationMethodConfiguration                      = "configuration"
 	durationAlarmThresholdBlockServiceGetBlock            = time.Duration(500) * time.Millisecond
 	durationAlarmThresholdAccountServiceGetAccountBalance = time.Duration(500) * time.Millisecond
 )
 
 var (
 	transactionEventSignalError                             = core.SignalErrorOperation
+	transactionEventInternalVMErrors                        = core.InternalVMErrorsOperation
+	transactionEventWriteLog                                = core.WriteLogIdentifier
+	transactionEventCompletedTx                             = core.CompletedTxEventIdentifier
 	transactionEventSCDeploy                                = core.SCDeployIdentifier
 	transactionEventTransferValueOnly                       = "transferValueOnly"
 	transactionEventESDTTransfer                            = "ESDTTransfer"
@@ -49,17 +63,3 @@ var (
 	transactionEventDataExecuteOnDestContext = "ExecuteOnDestContext"
 	transactionEventDataAsyncCall            = "AsyncCall"
 )
-
-var (
-	numTopicsOfEventESDTTransfer                    = 4
-	numTopicsPerTransferOfEventMultiESDTNFTTransfer = 3
-	numTopicsOfEventESDTLocalBurn                   = 3
-	numTopicsOfEventESDTLocalMint                   = 3
-	numTopicsOfEventESDTWipe                        = 4
-	numTopicsOfEventESDTNFTCreate                   = 4
-	numTopicsOfEventESDTNFTBurn                     = 3
-	numTopicsOfEventESDTNFTAddQuantity              = 3
-	numTopicsOfEventSCDeployBeforeSirius            = 2
-	numTopicsOfEventClaimDeveloperRewards           = 2
-	numTopicsOfEventTransferValueOnlyAfterSirius    = 2
-)
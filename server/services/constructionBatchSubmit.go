@@ -0,0 +1,110 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-proxy-go/data"
+)
+
+const maxNumTransactionsInBatch = 1000
+
+var errTransactionNotAccepted = errors.New("transaction not accepted by the observer (e.g. bad signature, bad nonce, insufficient balance)")
+
+// batchSubmitResult is the outcome of submitting one of the transactions of a batch: either a hash, or an error
+type batchSubmitResult struct {
+	Index int          `json:"index"`
+	Hash  string       `json:"hash,omitempty"`
+	Error *types.Error `json:"error,omitempty"`
+}
+
+// submitBatch submits a batch of signed transactions (each one in the format expected by "/construction/submit"), in a single request to the observer.
+// A result is returned for each transaction, in the order of the batch. A failure of one transaction does not prevent the others from being submitted.
+//
+// Ordering: the transactions are forwarded to the observer in the order of the batch (thus, the transactions of a sender, in the order given).
+// Though, the mempool handles the transactions of a sender by nonce (not by arrival), and a rejected transaction does not cause the rejection of the
+// subsequent ones of the same sender: those with higher nonces are accepted, but remain pending until the nonce gap is filled (e.g. by a resubmission).
+func (submitter *transactionsSubmitter) submitBatch(signedTransactions []string) ([]*batchSubmitResult, *types.Error) {
+	if submitter.provider.IsOffline() {
+		return nil, submitter.errFactory.newErr(ErrOfflineMode)
+	}
+
+	if len(signedTransactions) > maxNumTransactionsInBatch {
+		err := fmt.Errorf("too many transactions in batch: %d (maximum is %d)", len(signedTransactions), maxNumTransactionsInBatch)
+		return nil, submitter.errFactory.newErrWithOriginal(ErrMalformedValue, err)
+	}
+
+	log.Debug("transactionsSubmitter.submitBatch()", "numTransactions", len(signedTransactions))
+
+	results := make([]*batchSubmitResult, len(signedTransactions))
+	txsToSend := make([]*data.Transaction, 0, len(signedTransactions))
+	indicesOfTxsToSend := make([]int, 0, len(signedTransactions))
+	computedHashes := make([]string, len(signedTransactions))
+
+	for i, signedTransaction := range signedTransactions {
+		results[i] = &batchSubmitResult{Index: i}
+
+		tx, err := getTxFromRequest(signedTransaction)
+		if err != nil {
+			submitter.auditSubmission(signedTransaction, nil, "", submissionOutcomeRejected, err.Error())
+			results[i].Error = submitter.errFactory.newErrWithOriginal(ErrMalformedValue, err)
+			continue
+		}
+
+		computedHash, previousHash, isDuplicate := submitter.reserveSubmission(tx)
+		if isDuplicate {
+			submitter.auditSubmission(signedTransaction, tx, previousHash, submissionOutcomeDeduplicated, "")
+			results[i].Hash = previousHash
+			continue
+		}
+
+		computedHashes[i] = computedHash
+
+		if submitter.provider.GetNetworkConfig().ShouldSimulateBeforeSubmit {
+			errTyped := submitter.simulateBeforeSubmit(tx)
+			if errTyped != nil {
+				submitter.onSubmissionFailed(computedHash)
+				submitter.auditSubmission(signedTransaction, tx, computedHash, submissionOutcomeRejected, describeErrorForAudit(errTyped))
+				results[i].Error = errTyped
+				continue
+			}
+		}
+
+		txsToSend = append(txsToSend, tx)
+		indicesOfTxsToSend = append(indicesOfTxsToSend, i)
+	}
+
+	if len(txsToSend) == 0 {
+		return results, nil
+	}
+
+	hashes, err := submitter.provider.SendTransactions(txsToSend)
+
+	for indexInRequest, indexInBatch := range indicesOfTxsToSend {
+		signedTransaction := signedTransactions[indexInBatch]
+		tx := txsToSend[indexInRequest]
+		computedHash := computedHashes[indexInBatch]
+
+		if err != nil {
+			submitter.onSubmissionFailed(computedHash)
+			submitter.auditSubmission(signedTransaction, tx, computedHash, submissionOutcomeFailed, err.Error())
+			results[indexInBatch].Error = submitter.errFactory.newErrWithOriginal(ErrUnableToSubmitTransaction, err)
+			continue
+		}
+
+		hash, ok := hashes[indexInRequest]
+		if !ok {
+			submitter.onSubmissionFailed(computedHash)
+			submitter.auditSubmission(signedTransaction, tx, computedHash, submissionOutcomeFailed, errTransactionNotAccepted.Error())
+			results[indexInBatch].Error = submitter.errFactory.newErrWithOriginal(ErrUnableToSubmitTransaction, errTransactionNotAccepted)
+			continue
+		}
+
+		submitter.auditSubmission(signedTransaction, tx, hash, submissionOutcomeSubmitted, hash)
+		results[indexInBatch].Hash = hash
+		submitter.onSubmitted(computedHash, hash)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-proxy-go/data"
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+func createSignedTransactionsForBatch(nonces ...uint64) []string {
+	signedTransactions := make([]string, 0, len(nonces))
+
+	for _, nonce := range nonces {
+		signedTransactions = append(signedTransactions, fmt.Sprintf(
+			`{"nonce":%d,"value":"1234","receiver":"%s","sender":"%s","gasPrice":1000000000,"gasLimit":50000,"signature":"aabb","chainID":"T","version":1}`,
+			nonce, testscommon.TestAddressBob, testscommon.TestAddressAlice,
+		))
+	}
+
+	return signedTransactions
+}
+
+func TestConstructionService_SubmitBatch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("with all transactions accepted", func(t *testing.T) {
+		t.Parallel()
+
+		networkProvider := testscommon.NewNetworkProviderMock()
+
+		var calledWithTransactions []*data.Transaction
+		networkProvider.SendTransactionsCalled = func(txs []*data.Transaction) (map[int]string, error) {
+			calledWithTransactions = txs
+			return map[int]string{0: "aaaa", 1: "bbbb", 2: "cccc"}, nil
+		}
+
+		submitter := NewTransactionsSubmitter(networkProvider)
+
+		results, errTyped := submitter.submitBatch(createSignedTransactionsForBatch(42, 43, 44))
+		require.Nil(t, errTyped)
+		require.Equal(t, []*batchSubmitResult{
+			{Index: 0, Hash: "aaaa"},
+			{Index: 1, Hash: "bbbb"},
+			{Index: 2, Hash: "cccc"},
+		}, results)
+
+		// Order of the batch is preserved.
+		require.Len(t, calledWithTransactions, 3)
+		require.Equal(t, uint64(42), calledWithTransactions[0].Nonce)
+		require.Equal(t, uint64(43), calledWithTransactions[1].Nonce)
+		require.Equal(t, uint64(44), calledWithTransactions[2].Nonce)
+	})
+
+	t.Run("with malformed and rejected transactions", func(t *testing.T) {
+		t.Parallel()
+
+		networkProvider := testscommon.NewNetworkProviderMock()
+
+		var calledWithTransactions []*data.Transaction
+		networkProvider.SendTransactionsCalled = func(txs []*data.Transaction) (map[int]string, error) {
+			calledWithTransactions = txs
+			// Second transaction (of those sent) is rejected by the observer.
+			return map[int]string{0: "aaaa", 2: "cccc"}, nil
+		}
+
+		submitter := NewTransactionsSubmitter(networkProvider)
+
+		signedTransactions := createSignedTransactionsForBatch(42, 43, 44)
+		signedTransactions = append(signedTransactions[:1], append([]string{"{not a transaction"}, signedTransactions[1:]...)...)
+
+		results, errTyped := submitter.submitBatch(signedTransactions)
+		require.Nil(t, errTyped)
+		require.Len(t, results, 4)
+		require.Len(t, calledWithTransactions, 3)
+
+		require.Equal(t, "aaaa", results[0].Hash)
+		require.Nil(t, results[0].Error)
+
+		require.Equal(t, 1, results[1].Index)
+		require.Empty(t, results[1].Hash)
+		require.Equal(t, int32(ErrMalformedValue), results[1].Error.Code)
+
+		require.Equal(t, 2, results[2].Index)
+		require.Empty(t, results[2].Hash)
+		require.Equal(t, int32(ErrUnableToSubmitTransaction), results[2].Error.Code)
+		require.Equal(t, errTransactionNotAccepted.Error(), results[2].Error.Details["originalError"])
+
+		require.Equal(t, 3, results[3].Index)
+		require.Equal(t, "cccc", results[3].Hash)
+	})
+
+	t.Run("with failed request", func(t *testing.T) {
+		t.Parallel()
+
+		networkProvider := testscommon.NewNetworkProviderMock()
+		networkProvider.SendTransactionsCalled = func(txs []*data.Transaction) (map[int]string, error) {
+			return nil, errors.New("arbitrary error")
+		}
+
+		submitter := NewTransactionsSubmitter(networkProvider)
+
+		results, errTyped := submitter.submitBatch(createSignedTransactionsForBatch(42, 43))
+		require.Nil(t, errTyped)
+		require.Len(t, results, 2)
+
+		for _, result := range results {
+			require.Empty(t, result.Hash)
+			require.Equal(t, int32(ErrUnableToSubmitTransaction), result.Error.Code)
+			require.Equal(t, "arbitrary error", result.Error.Details["originalError"])
+		}
+	})
+
+	t.Run("with simulation predicting failure", func(t *testing.T) {
+		t.Parallel()
+
+		networkProvider := testscommon.NewNetworkProviderMock()
+		networkProvider.MockNetworkConfig.ShouldSimulateBeforeSubmit = true
+		networkProvider.SimulateTransactionCalled = func(tx *data.Transaction) (*transaction.SimulationResults, error) {
+			if tx.Nonce == 43 {
+				return &transaction.SimulationResults{Status: transaction.TxStatusFail, FailReason: "insufficient funds"}, nil
+			}
+
+			return &transaction.SimulationResults{Status: transaction.TxStatusSuccess}, nil
+		}
+
+		var calledWithTransactions []*data.Transaction
+		networkProvider.SendTransactionsCalled = func(txs []*data.Transaction) (map[int]string, error) {
+			calledWithTransactions = txs
+			return map[int]string{0: "aaaa", 1: "cccc"}, nil
+		}
+
+		submitter := NewTransactionsSubmitter(networkProvider)
+
+		results, errTyped := submitter.submitBatch(createSignedTransactionsForBatch(42, 43, 44))
+		require.Nil(t, errTyped)
+		require.Len(t, calledWithTransactions, 2)
+		require.Equal(t, "aaaa", results[0].Hash)
+		require.Equal(t, int32(ErrTransactionWouldFail), results[1].Error.Code)
+		require.Equal(t, "cccc", results[2].Hash)
+	})
+
+	t.Run("with too many transactions", func(t *testing.T) {
+		t.Parallel()
+
+		networkProvider := testscommon.NewNetworkProviderMock()
+		submitter := NewTransactionsSubmitter(networkProvider)
+
+		results, errTyped := submitter.submitBatch(make([]string, maxNumTransactionsInBatch+1))
+		require.Nil(t, results)
+		require.Equal(t, int32(ErrMalformedValue), errTyped.Code)
+	})
+
+	t.Run("in offline mode", func(t *testing.T) {
+		t.Parallel()
+
+		networkProvider := testscommon.NewNetworkProviderMock()
+		networkProvider.MockIsOffline = true
+		submitter := NewTransactionsSubmitter(networkProvider)
+
+		results, errTyped := submitter.submitBatch(createSignedTransactionsForBatch(42))
+		require.Nil(t, results)
+		require.Equal(t, int32(ErrOfflineMode), errTyped.Code)
+	})
+}
@@ -0,0 +1,240 @@
+package services
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+)
+
+// defaultCodeMetadata marks deployed contracts as upgradeable and readable (by other contracts)
+const defaultCodeMetadata = "0500"
+
+// vmTypeWasm is the (hex-encoded) type of the virtual machine, to be specified when deploying a contract
+const vmTypeWasm = "0500"
+
+// constructionContractCall describes a call to a smart contract (a function and its hex-encoded arguments)
+type constructionContractCall struct {
+	Function  string   `json:"function"`
+	Arguments []string `json:"arguments,omitempty"`
+}
+
+// constructionContractDeploy describes a smart contract deployment (the hex-encoded code, code metadata and arguments)
+type constructionContractDeploy struct {
+	Code         string   `json:"code"`
+	CodeMetadata string   `json:"codeMetadata,omitempty"`
+	Arguments    []string `json:"arguments,omitempty"`
+}
+
+func (call *constructionContractCall) validate() error {
+	if len(call.Function) == 0 {
+		return errors.New("missing option: 'contractCall.function'")
+	}
+	if strings.Contains(call.Function, argumentsSeparator) {
+		return fmt.Errorf("bad option: 'contractCall.function' must not contain '%s'", argumentsSeparator)
+	}
+
+	return validateContractArguments("contractCall", call.Arguments)
+}
+
+func (deploy *constructionContractDeploy) validate() error {
+	if len(deploy.Code) == 0 {
+		return errors.New("missing option: 'contractDeploy.code'")
+	}
+	if !isHexString(deploy.Code) {
+		return errors.New("bad option: 'contractDeploy.code' must be hex-encoded")
+	}
+	if len(deploy.CodeMetadata) > 0 && !isHexString(deploy.CodeMetadata) {
+		return errors.New("bad option: 'contractDeploy.codeMetadata' must be hex-encoded")
+	}
+
+	return validateContractArguments("contractDeploy", deploy.Arguments)
+}
+
+func (deploy *constructionContractDeploy) getCodeMetadata() string {
+	if len(deploy.CodeMetadata) == 0 {
+		return defaultCodeMetadata
+	}
+
+	return deploy.CodeMetadata
+}
+
+func validateContractArguments(optionName string, arguments []string) error {
+	for i, argument := range arguments {
+		if !isHexString(argument) {
+			return fmt.Errorf("bad option: '%s.arguments[%d]' must be hex-encoded", optionName, i)
+		}
+	}
+
+	return nil
+}
+
+func isHexString(value string) bool {
+	_, err := hex.DecodeString(value)
+	return err == nil
+}
+
+// computeDataForContractCall computes the data field of a contract call.
+// If tokens are transferred along with the call, the function and its arguments are appended to the data of the transfer.
+func computeDataForContractCall(transferData []byte, call *constructionContractCall) []byte {
+	function := call.Function
+	if len(transferData) > 0 {
+		function = stringToHex(call.Function)
+	}
+
+	parts := make([]string, 0, len(call.Arguments)+2)
+	if len(transferData) > 0 {
+		parts = append(parts, string(transferData))
+	}
+
+	parts = append(parts, function)
+	parts = append(parts, call.Arguments...)
+
+	return []byte(strings.Join(parts, argumentsSeparator))
+}
+
+// computeDataForContractDeploy computes the data field of a contract deployment: "code@vmType@codeMetadata@arguments..."
+func computeDataForContractDeploy(deploy *constructionContractDeploy) []byte {
+	parts := []string{deploy.Code, vmTypeWasm, deploy.getCodeMetadata()}
+	parts = append(parts, deploy.Arguments...)
+
+	return []byte(strings.Join(parts, argumentsSeparator))
+}
+
+// parseContractDeploy parses the data field of a contract deployment
+func parseContractDeploy(txData string) (*constructionContractDeploy, error) {
+	parts := strings.Split(txData, argumentsSeparator)
+	if len(parts) < 3 {
+		return nil, errors.New("cannot parse data of contract deployment")
+	}
+
+	return &constructionContractDeploy{
+		Code:         parts[0],
+		CodeMetadata: parts[2],
+		Arguments:    parts[3:],
+	}, nil
+}
+
+// separateContractOperation returns the operations other than "SmartContractCall" and "SmartContractDeploy", and the contract operation (if any).
+func separateContractOperation(operations []*types.Operation) ([]*types.Operation, *types.Operation, error) {
+	otherOperations := make([]*types.Operation, 0, len(operations))
+	var contractOperation *types.Operation
+
+	for _, operation := range operations {
+		if operation.Type != opSmartContractCall && operation.Type != opSmartContractDeploy {
+			otherOperations = append(otherOperations, operation)
+			continue
+		}
+
+		if contractOperation != nil {
+			return nil, nil, errors.New("at most one contract operation is supported")
+		}
+
+		contractOperation = operation
+	}
+
+	return otherOperations, contractOperation, nil
+}
+
+// prepareContractInteraction places the contract call or deployment (given either in the request metadata or as an operation) on the options.
+// For contract calls, it returns the address of the contract (if provided as metadata of the operation).
+func prepareContractInteraction(contractOperation *types.Operation, requestMetadata *constructionPreprocessMetadata, responseOptions *constructionOptions) (string, error) {
+	responseOptions.ContractCall = requestMetadata.ContractCall
+	responseOptions.ContractDeploy = requestMetadata.ContractDeploy
+
+	if contractOperation == nil {
+		return "", nil
+	}
+
+	if contractOperation.Type == opSmartContractDeploy {
+		if responseOptions.ContractDeploy == nil {
+			responseOptions.ContractDeploy = &constructionContractDeploy{}
+			err := fromObjectsMap(contractOperation.Metadata, responseOptions.ContractDeploy)
+			if err != nil {
+				return "", err
+			}
+		}
+
+		return "", nil
+	}
+
+	if responseOptions.ContractCall == nil {
+		responseOptions.ContractCall = &constructionContractCall{}
+		err := fromObjectsMap(contractOperation.Metadata, responseOptions.ContractCall)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	contract, _ := contractOperation.Metadata["contract"].(string)
+	return contract, nil
+}
+
+// prepareOptionsOfContractInteraction prepares the sender, the receiver and the (optional) single transfer of a contract interaction.
+func prepareOptionsOfContractInteraction(operations []*types.Operation, contractOperation *types.Operation, contract string, requestMetadata *constructionPreprocessMetadata, responseOptions *constructionOptions) error {
+	responseOptions.Sender = requestMetadata.Sender
+	responseOptions.Receiver = requestMetadata.Receiver
+	responseOptions.Amount = requestMetadata.Amount
+	responseOptions.CurrencySymbol = requestMetadata.CurrencySymbol
+
+	if len(operations) > 0 {
+		// Fallback: get "sender", "amount" and "currencySymbol" from the first (transfer) operation
+		if len(responseOptions.Sender) == 0 {
+			responseOptions.Sender = operations[0].Account.Address
+		}
+		if len(responseOptions.Amount) == 0 {
+			responseOptions.Amount = getMagnitudeOfAmount(operations[0].Amount.Value)
+		}
+		if len(responseOptions.CurrencySymbol) == 0 {
+			responseOptions.CurrencySymbol = operations[0].Amount.Currency.Symbol
+		}
+	}
+
+	if len(responseOptions.Sender) == 0 {
+		// Fallback: get "sender" from the contract operation
+		if contractOperation == nil {
+			return errors.New("cannot prepare sender")
+		}
+		responseOptions.Sender = contractOperation.Account.Address
+	}
+
+	if len(responseOptions.Receiver) == 0 && responseOptions.ContractCall != nil {
+		// Fallback: the receiver is the contract
+		if len(contract) == 0 {
+			return errors.New("cannot prepare receiver")
+		}
+		responseOptions.Receiver = contract
+	}
+
+	return nil
+}
+
+func (service *constructionService) createContractCallOperation(sender string, contract string, call *constructionContractCall) (*types.Operation, error) {
+	metadata, err := toObjectsMap(call)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata["contract"] = contract
+
+	return &types.Operation{
+		Type:     opSmartContractCall,
+		Account:  addressToAccountIdentifier(sender),
+		Metadata: metadata,
+	}, nil
+}
+
+func (service *constructionService) createContractDeployOperation(sender string, deploy *constructionContractDeploy) (*types.Operation, error) {
+	metadata, err := toObjectsMap(deploy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.Operation{
+		Type:     opSmartContractDeploy,
+		Account:  addressToAccountIdentifier(sender),
+		Metadata: metadata,
+	}, nil
+}
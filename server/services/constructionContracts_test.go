@@ -0,0 +1,79 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConstructionContractCall_Validate(t *testing.T) {
+	t.Parallel()
+
+	require.ErrorContains(t, (&constructionContractCall{}).validate(), "missing option: 'contractCall.function'")
+	require.ErrorContains(t, (&constructionContractCall{Function: "add@07"}).validate(), "bad option: 'contractCall.function' must not contain '@'")
+	require.ErrorContains(t, (&constructionContractCall{Function: "add", Arguments: []string{"07", "xyz"}}).validate(), "bad option: 'contractCall.arguments[1]' must be hex-encoded")
+	require.Nil(t, (&constructionContractCall{Function: "add", Arguments: []string{"07", ""}}).validate())
+}
+
+func TestConstructionContractDeploy_Validate(t *testing.T) {
+	t.Parallel()
+
+	require.ErrorContains(t, (&constructionContractDeploy{}).validate(), "missing option: 'contractDeploy.code'")
+	require.ErrorContains(t, (&constructionContractDeploy{Code: "0061736"}).validate(), "bad option: 'contractDeploy.code' must be hex-encoded")
+	require.ErrorContains(t, (&constructionContractDeploy{Code: "0061736d", CodeMetadata: "zz"}).validate(), "bad option: 'contractDeploy.codeMetadata' must be hex-encoded")
+	require.ErrorContains(t, (&constructionContractDeploy{Code: "0061736d", Arguments: []string{"x"}}).validate(), "bad option: 'contractDeploy.arguments[0]' must be hex-encoded")
+	require.Nil(t, (&constructionContractDeploy{Code: "0061736d", CodeMetadata: "0100", Arguments: []string{"2a"}}).validate())
+}
+
+func TestComputeDataForContractCall(t *testing.T) {
+	t.Parallel()
+
+	call := &constructionContractCall{
+		Function:  "add",
+		Arguments: []string{"07", "2a"},
+	}
+
+	require.Equal(t, []byte("add@07@2a"), computeDataForContractCall(nil, call))
+	require.Equal(t, []byte("add"), computeDataForContractCall(nil, &constructionContractCall{Function: "add"}))
+	require.Equal(t, []byte("ESDTTransfer@544553542d616263646566@64@616464@07@2a"), computeDataForContractCall([]byte("ESDTTransfer@544553542d616263646566@64"), call))
+}
+
+func TestComputeDataForContractDeploy(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, []byte("0061736d@0500@0500"), computeDataForContractDeploy(&constructionContractDeploy{Code: "0061736d"}))
+	require.Equal(t, []byte("0061736d@0500@0100@2a@07"), computeDataForContractDeploy(&constructionContractDeploy{Code: "0061736d", CodeMetadata: "0100", Arguments: []string{"2a", "07"}}))
+}
+
+func TestParseContractDeploy(t *testing.T) {
+	t.Parallel()
+
+	deploy, err := parseContractDeploy("0061736d@0500@0100@2a@07")
+	require.Nil(t, err)
+	require.Equal(t, &constructionContractDeploy{Code: "0061736d", CodeMetadata: "0100", Arguments: []string{"2a", "07"}}, deploy)
+
+	_, err = parseContractDeploy("0061736d@0500")
+	require.ErrorContains(t, err, "cannot parse data of contract deployment")
+}
+
+func TestSeparateContractOperation(t *testing.T) {
+	t.Parallel()
+
+	transfer := &types.Operation{Type: opTransfer}
+	call := &types.Operation{Type: opSmartContractCall}
+	deploy := &types.Operation{Type: opSmartContractDeploy}
+
+	otherOperations, contractOperation, err := separateContractOperation([]*types.Operation{transfer, call, transfer})
+	require.Nil(t, err)
+	require.Equal(t, []*types.Operation{transfer, transfer}, otherOperations)
+	require.Equal(t, call, contractOperation)
+
+	otherOperations, contractOperation, err = separateContractOperation([]*types.Operation{transfer})
+	require.Nil(t, err)
+	require.Equal(t, []*types.Operation{transfer}, otherOperations)
+	require.Nil(t, contractOperation)
+
+	_, _, err = separateContractOperation([]*types.Operation{call, deploy})
+	require.ErrorContains(t, err, "at most one contract operation is supported")
+}
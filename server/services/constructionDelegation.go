@@ -0,0 +1,364 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-go/vm"
+)
+
+const (
+	delegationFunctionDelegate                    = "delegate"
+	delegationFunctionUndelegate                  = "unDelegate"
+	delegationFunctionClaimRewards                = "claimRewards"
+	delegationFunctionWithdraw                    = "withdraw"
+	delegationFunctionReDelegateRewards           = "reDelegateRewards"
+	delegationFunctionCreateNewDelegationContract = "createNewDelegationContract"
+)
+
+const (
+	delegationContractAddressPrefixLength = 10
+	delegationContractAddressSuffixLength = 3
+)
+
+var delegationFunctionsByOperationType = map[string]string{
+	opDelegate:                 delegationFunctionDelegate,
+	opUndelegate:               delegationFunctionUndelegate,
+	opClaimRewards:             delegationFunctionClaimRewards,
+	opWithdraw:                 delegationFunctionWithdraw,
+	opReDelegateRewards:        delegationFunctionReDelegateRewards,
+	opCreateDelegationContract: delegationFunctionCreateNewDelegationContract,
+}
+
+var operationTypesByDelegationFunction = map[string]string{
+	delegationFunctionDelegate:                    opDelegate,
+	delegationFunctionUndelegate:                  opUndelegate,
+	delegationFunctionClaimRewards:                opClaimRewards,
+	delegationFunctionWithdraw:                    opWithdraw,
+	delegationFunctionReDelegateRewards:           opReDelegateRewards,
+	delegationFunctionCreateNewDelegationContract: opCreateDelegationContract,
+}
+
+// constructionDelegation describes a call to a delegation contract (or to the delegation manager, for creating a new delegation contract).
+// The amount is the value to delegate (for "delegate"), the value to undelegate (for "unDelegate") or the initial stake (for "createNewDelegationContract").
+// The total delegation cap and the service fee (in hundredths of a percent) are only used for "createNewDelegationContract".
+type constructionDelegation struct {
+	Function           string `json:"function"`
+	Amount             string `json:"amount,omitempty"`
+	TotalDelegationCap string `json:"totalDelegationCap,omitempty"`
+	ServiceFee         string `json:"serviceFee,omitempty"`
+}
+
+func (delegation *constructionDelegation) validate() error {
+	if len(delegation.Function) == 0 {
+		return errors.New("missing option: 'delegation.function'")
+	}
+
+	_, ok := operationTypesByDelegationFunction[delegation.Function]
+	if !ok {
+		return fmt.Errorf("bad option: unknown 'delegation.function' %s", delegation.Function)
+	}
+
+	if delegation.requiresAmount() {
+		if len(delegation.Amount) == 0 {
+			return errors.New("missing option: 'delegation.amount'")
+		}
+		if !isPositiveInteger(delegation.Amount) {
+			return errors.New("bad option: 'delegation.amount' must be a positive integer")
+		}
+	} else if len(delegation.Amount) > 0 {
+		return fmt.Errorf("for delegation function '%s', option 'delegation.amount' must be empty", delegation.Function)
+	}
+
+	if delegation.isCreation() {
+		if !isNonNegativeInteger(delegation.TotalDelegationCap) {
+			return errors.New("bad option: 'delegation.totalDelegationCap' must be a non-negative integer (zero means uncapped)")
+		}
+		if !isNonNegativeInteger(delegation.ServiceFee) {
+			return errors.New("bad option: 'delegation.serviceFee' must be a non-negative integer")
+		}
+	} else if len(delegation.TotalDelegationCap) > 0 || len(delegation.ServiceFee) > 0 {
+		return fmt.Errorf("for delegation function '%s', options 'delegation.totalDelegationCap' and 'delegation.serviceFee' must be empty", delegation.Function)
+	}
+
+	return nil
+}
+
+func (delegation *constructionDelegation) requiresAmount() bool {
+	return delegation.transfersValue() || delegation.Function == delegationFunctionUndelegate
+}
+
+// transfersValue returns true for the functions that require value (native currency) to be transferred along with the call
+func (delegation *constructionDelegation) transfersValue() bool {
+	return delegation.Function == delegationFunctionDelegate || delegation.isCreation()
+}
+
+func (delegation *constructionDelegation) isCreation() bool {
+	return delegation.Function == delegationFunctionCreateNewDelegationContract
+}
+
+// getValue returns the value (native currency) to be transferred along with the call
+func (delegation *constructionDelegation) getValue() string {
+	if delegation.transfersValue() {
+		return delegation.Amount
+	}
+
+	return amountZero
+}
+
+func isPositiveInteger(value string) bool {
+	number, ok := big.NewInt(0).SetString(value, 10)
+	return ok && number.Sign() > 0
+}
+
+func isNonNegativeInteger(value string) bool {
+	number, ok := big.NewInt(0).SetString(value, 10)
+	return ok && number.Sign() >= 0
+}
+
+// computeDataForDelegation computes the data field of a delegation transaction, e.g. "delegate", "unDelegate@amount" or "createNewDelegationContract@cap@fee"
+func computeDataForDelegation(delegation *constructionDelegation) []byte {
+	parts := []string{delegation.Function}
+
+	switch delegation.Function {
+	case delegationFunctionUndelegate:
+		parts = append(parts, amountToHex(delegation.Amount))
+	case delegationFunctionCreateNewDelegationContract:
+		parts = append(parts, amountToHex(delegation.TotalDelegationCap), amountToHex(delegation.ServiceFee))
+	}
+
+	return []byte(strings.Join(parts, argumentsSeparator))
+}
+
+// parseDelegation parses the data field (and the value) of a delegation transaction.
+// It returns nil if the data does not start with a delegation function.
+func parseDelegation(txData string, value string) (*constructionDelegation, error) {
+	parts := strings.Split(txData, argumentsSeparator)
+	function := parts[0]
+	arguments := parts[1:]
+
+	_, ok := operationTypesByDelegationFunction[function]
+	if !ok {
+		return nil, nil
+	}
+
+	delegation := &constructionDelegation{
+		Function: function,
+	}
+
+	numExpectedArguments := 0
+	switch function {
+	case delegationFunctionUndelegate:
+		numExpectedArguments = 1
+	case delegationFunctionCreateNewDelegationContract:
+		numExpectedArguments = 2
+	}
+
+	if len(arguments) != numExpectedArguments {
+		return nil, fmt.Errorf("cannot parse data of delegation function '%s': unexpected number of arguments", function)
+	}
+
+	var err error
+
+	switch function {
+	case delegationFunctionUndelegate:
+		delegation.Amount, err = hexToAmount(arguments[0])
+	case delegationFunctionCreateNewDelegationContract:
+		delegation.TotalDelegationCap, err = hexToAmount(arguments[0])
+		if err == nil {
+			delegation.ServiceFee, err = hexToAmount(arguments[1])
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse arguments of delegation function '%s': %w", function, err)
+	}
+
+	if delegation.transfersValue() {
+		delegation.Amount = value
+	}
+
+	return delegation, nil
+}
+
+// separateDelegationOperation returns the operations other than the delegation ones (e.g. "Delegate", "ClaimRewards"), and the delegation operation (if any).
+func separateDelegationOperation(operations []*types.Operation) ([]*types.Operation, *types.Operation, error) {
+	otherOperations := make([]*types.Operation, 0, len(operations))
+	var delegationOperation *types.Operation
+
+	for _, operation := range operations {
+		_, isDelegation := delegationFunctionsByOperationType[operation.Type]
+		if !isDelegation {
+			otherOperations = append(otherOperations, operation)
+			continue
+		}
+
+		if delegationOperation != nil {
+			return nil, nil, errors.New("at most one delegation operation is supported")
+		}
+
+		delegationOperation = operation
+	}
+
+	return otherOperations, delegationOperation, nil
+}
+
+// prepareDelegation places the delegation (given either in the request metadata or as an operation) on the options.
+// It returns the address of the delegation contract (if provided as metadata of the operation).
+func prepareDelegation(delegationOperation *types.Operation, requestMetadata *constructionPreprocessMetadata, responseOptions *constructionOptions) (string, error) {
+	responseOptions.Delegation = requestMetadata.Delegation
+
+	if delegationOperation == nil {
+		return "", nil
+	}
+
+	if responseOptions.Delegation == nil {
+		responseOptions.Delegation = &constructionDelegation{}
+		err := fromObjectsMap(delegationOperation.Metadata, responseOptions.Delegation)
+		if err != nil {
+			return "", err
+		}
+
+		// The function is given by the type of the operation.
+		responseOptions.Delegation.Function = delegationFunctionsByOperationType[delegationOperation.Type]
+	}
+
+	contract, _ := delegationOperation.Metadata["contract"].(string)
+	return contract, nil
+}
+
+// prepareOptionsOfDelegation prepares the sender and the receiver (the delegation contract or the delegation manager) of a delegation transaction.
+// The (optional) transfer operations only serve as a fallback for the sender and for the delegated amount.
+func prepareOptionsOfDelegation(operations []*types.Operation, delegationOperation *types.Operation, contract string, requestMetadata *constructionPreprocessMetadata, responseOptions *constructionOptions) error {
+	responseOptions.Sender = requestMetadata.Sender
+	responseOptions.Receiver = requestMetadata.Receiver
+	responseOptions.Amount = requestMetadata.Amount
+	responseOptions.CurrencySymbol = requestMetadata.CurrencySymbol
+
+	delegation := responseOptions.Delegation
+
+	if len(responseOptions.Sender) == 0 {
+		// Fallback: get "sender" from the delegation operation, or from the first (transfer) operation
+		if delegationOperation != nil {
+			responseOptions.Sender = delegationOperation.Account.Address
+		} else if len(operations) > 0 {
+			responseOptions.Sender = operations[0].Account.Address
+		} else {
+			return errors.New("cannot prepare sender")
+		}
+	}
+
+	if len(responseOptions.Receiver) == 0 {
+		// Fallback: the receiver is the delegation manager (when creating a delegation contract), or the delegation contract
+		if delegation.isCreation() {
+			responseOptions.Receiver = delegationManagerContractAddress
+		} else if len(contract) > 0 {
+			responseOptions.Receiver = contract
+		} else {
+			return errors.New("cannot prepare receiver")
+		}
+	}
+
+	if len(delegation.Amount) == 0 && delegation.transfersValue() && len(operations) > 0 {
+		// Fallback: get "delegation.amount" from the first (transfer) operation
+		delegation.Amount = getMagnitudeOfAmount(operations[0].Amount.Value)
+	}
+
+	return nil
+}
+
+// isDelegationContractAddress returns true for the delegation manager and for the delegation contracts
+// (other system smart contracts living in the metachain, e.g. staking, ESDT or governance, are excluded).
+func (service *constructionService) isDelegationContractAddress(address string) bool {
+	if address == delegationManagerContractAddress {
+		return true
+	}
+
+	pubKey, err := service.provider.ConvertAddressToPubKey(address)
+	if err != nil {
+		return false
+	}
+
+	return isDelegationContractPubKey(pubKey)
+}
+
+// isDelegationContractPubKey checks the layout of the addresses assigned (by the delegation manager) to delegation contracts:
+// they share the prefix and the suffix of the first delegation contract, and hold a (non-zero) counter in between.
+func isDelegationContractPubKey(pubKey []byte) bool {
+	firstContract := vm.FirstDelegationSCAddress
+	if len(pubKey) != len(firstContract) {
+		return false
+	}
+
+	if bytes.Equal(pubKey, vm.JailingAddress) || bytes.Equal(pubKey, vm.EndOfEpochAddress) {
+		return false
+	}
+
+	prefixLength := delegationContractAddressPrefixLength
+	suffixStart := len(firstContract) - delegationContractAddressSuffixLength
+
+	hasPrefix := bytes.Equal(pubKey[:prefixLength], firstContract[:prefixLength])
+	hasSuffix := bytes.Equal(pubKey[suffixStart:], firstContract[suffixStart:])
+	counter := big.NewInt(0).SetBytes(pubKey[prefixLength:suffixStart])
+
+	return hasPrefix && hasSuffix && counter.Sign() > 0
+}
+
+// parseDelegationOfPreparedTx recovers the delegation of a prepared transaction, if any.
+// Only calls towards delegation contracts (or towards the delegation manager, for creating a delegation contract) are considered.
+func (service *constructionService) parseDelegationOfPreparedTx(txData string, value string, receiver string) (*constructionDelegation, error) {
+	if !service.isDelegationContractAddress(receiver) {
+		return nil, nil
+	}
+
+	delegation, err := parseDelegation(txData, value)
+	if err != nil || delegation == nil {
+		return nil, err
+	}
+
+	isReceiverDelegationManager := receiver == delegationManagerContractAddress
+	if delegation.isCreation() != isReceiverDelegationManager {
+		return nil, nil
+	}
+
+	return delegation, nil
+}
+
+func (service *constructionService) getGasLimitOfDelegation(function string) uint64 {
+	gasLimits := service.provider.GetNetworkConfig().DelegationGasLimits
+
+	switch function {
+	case delegationFunctionDelegate:
+		return gasLimits.Delegate
+	case delegationFunctionUndelegate:
+		return gasLimits.Undelegate
+	case delegationFunctionClaimRewards:
+		return gasLimits.ClaimRewards
+	case delegationFunctionWithdraw:
+		return gasLimits.Withdraw
+	case delegationFunctionReDelegateRewards:
+		return gasLimits.ReDelegateRewards
+	case delegationFunctionCreateNewDelegationContract:
+		return gasLimits.CreateDelegationContract
+	default:
+		return 0
+	}
+}
+
+func (service *constructionService) createDelegationOperation(sender string, contract string, delegation *constructionDelegation) (*types.Operation, error) {
+	metadata, err := toObjectsMap(delegation)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata["contract"] = contract
+
+	return &types.Operation{
+		Type:     operationTypesByDelegationFunction[delegation.Function],
+		Account:  addressToAccountIdentifier(sender),
+		Metadata: metadata,
+	}, nil
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-go/vm"
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConstructionDelegation_Validate(t *testing.T) {
+	t.Parallel()
+
+	require.ErrorContains(t, (&constructionDelegation{}).validate(), "missing option: 'delegation.function'")
+	require.ErrorContains(t, (&constructionDelegation{Function: "stake"}).validate(), "bad option: unknown 'delegation.function' stake")
+	require.ErrorContains(t, (&constructionDelegation{Function: "delegate"}).validate(), "missing option: 'delegation.amount'")
+	require.ErrorContains(t, (&constructionDelegation{Function: "unDelegate", Amount: "-5"}).validate(), "bad option: 'delegation.amount' must be a positive integer")
+	require.ErrorContains(t, (&constructionDelegation{Function: "claimRewards", Amount: "5"}).validate(), "for delegation function 'claimRewards', option 'delegation.amount' must be empty")
+	require.ErrorContains(t, (&constructionDelegation{Function: "createNewDelegationContract", Amount: "5", ServiceFee: "1000"}).validate(), "bad option: 'delegation.totalDelegationCap' must be a non-negative integer (zero means uncapped)")
+	require.ErrorContains(t, (&constructionDelegation{Function: "createNewDelegationContract", Amount: "5", TotalDelegationCap: "0", ServiceFee: "x"}).validate(), "bad option: 'delegation.serviceFee' must be a non-negative integer")
+	require.ErrorContains(t, (&constructionDelegation{Function: "withdraw", ServiceFee: "1000"}).validate(), "for delegation function 'withdraw', options 'delegation.totalDelegationCap' and 'delegation.serviceFee' must be empty")
+
+	require.Nil(t, (&constructionDelegation{Function: "delegate", Amount: "1000000000000000000"}).validate())
+	require.Nil(t, (&constructionDelegation{Function: "unDelegate", Amount: "1000000000000000000"}).validate())
+	require.Nil(t, (&constructionDelegation{Function: "claimRewards"}).validate())
+	require.Nil(t, (&constructionDelegation{Function: "withdraw"}).validate())
+	require.Nil(t, (&constructionDelegation{Function: "reDelegateRewards"}).validate())
+	require.Nil(t, (&constructionDelegation{Function: "createNewDelegationContract", Amount: "1250000000000000000000", TotalDelegationCap: "0", ServiceFee: "1000"}).validate())
+}
+
+func TestComputeDataForDelegation(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, []byte("delegate"), computeDataForDelegation(&constructionDelegation{Function: "delegate", Amount: "1000000000000000000"}))
+	require.Equal(t, []byte("unDelegate@0de0b6b3a7640000"), computeDataForDelegation(&constructionDelegation{Function: "unDelegate", Amount: "1000000000000000000"}))
+	require.Equal(t, []byte("claimRewards"), computeDataForDelegation(&constructionDelegation{Function: "claimRewards"}))
+	require.Equal(t, []byte("withdraw"), computeDataForDelegation(&constructionDelegation{Function: "withdraw"}))
+	require.Equal(t, []byte("reDelegateRewards"), computeDataForDelegation(&constructionDelegation{Function: "reDelegateRewards"}))
+	require.Equal(t, []byte("createNewDelegationContract@@03e8"), computeDataForDelegation(&constructionDelegation{Function: "createNewDelegationContract", Amount: "1250000000000000000000", TotalDelegationCap: "0", ServiceFee: "1000"}))
+}
+
+func TestParseDelegation(t *testing.T) {
+	t.Parallel()
+
+	t.Run("not a delegation", func(t *testing.T) {
+		delegation, err := parseDelegation("add@07", "0")
+		require.Nil(t, err)
+		require.Nil(t, delegation)
+
+		delegation, err = parseDelegation("", "1000")
+		require.Nil(t, err)
+		require.Nil(t, delegation)
+	})
+
+	t.Run("delegate", func(t *testing.T) {
+		delegation, err := parseDelegation("delegate", "1000000000000000000")
+		require.Nil(t, err)
+		require.Equal(t, &constructionDelegation{Function: "delegate", Amount: "1000000000000000000"}, delegation)
+	})
+
+	t.Run("unDelegate", func(t *testing.T) {
+		delegation, err := parseDelegation("unDelegate@0de0b6b3a7640000", "0")
+		require.Nil(t, err)
+		require.Equal(t, &constructionDelegation{Function: "unDelegate", Amount: "1000000000000000000"}, delegation)
+	})
+
+	t.Run("claimRewards", func(t *testing.T) {
+		delegation, err := parseDelegation("claimRewards", "0")
+		require.Nil(t, err)
+		require.Equal(t, &constructionDelegation{Function: "claimRewards"}, delegation)
+	})
+
+	t.Run("createNewDelegationContract", func(t *testing.T) {
+		delegation, err := parseDelegation("createNewDelegationContract@@03e8", "1250000000000000000000")
+		require.Nil(t, err)
+		require.Equal(t, &constructionDelegation{Function: "createNewDelegationContract", Amount: "1250000000000000000000", TotalDelegationCap: "0", ServiceFee: "1000"}, delegation)
+	})
+
+	t.Run("with unexpected arguments", func(t *testing.T) {
+		_, err := parseDelegation("withdraw@01", "0")
+		require.ErrorContains(t, err, "cannot parse data of delegation function 'withdraw': unexpected number of arguments")
+
+		_, err = parseDelegation("unDelegate", "0")
+		require.ErrorContains(t, err, "cannot parse data of delegation function 'unDelegate': unexpected number of arguments")
+	})
+
+	t.Run("with bad arguments", func(t *testing.T) {
+		_, err := parseDelegation("unDelegate@xyz", "0")
+		require.ErrorContains(t, err, "cannot parse arguments of delegation function 'unDelegate'")
+	})
+}
+
+func TestSeparateDelegationOperation(t *testing.T) {
+	t.Parallel()
+
+	transfer := &types.Operation{Type: opTransfer}
+	delegate := &types.Operation{Type: opDelegate}
+	claimRewards := &types.Operation{Type: opClaimRewards}
+
+	otherOperations, delegationOperation, err := separateDelegationOperation([]*types.Operation{transfer, delegate, transfer})
+	require.Nil(t, err)
+	require.Equal(t, []*types.Operation{transfer, transfer}, otherOperations)
+	require.Equal(t, delegate, delegationOperation)
+
+	otherOperations, delegationOperation, err = separateDelegationOperation([]*types.Operation{transfer})
+	require.Nil(t, err)
+	require.Equal(t, []*types.Operation{transfer}, otherOperations)
+	require.Nil(t, delegationOperation)
+
+	_, _, err = separateDelegationOperation([]*types.Operation{delegate, claimRewards})
+	require.ErrorContains(t, err, "at most one delegation operation is supported")
+}
+
+func TestConstructionService_IsDelegationContractAddress(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	service := createConstructionService(networkProvider)
+
+	require.True(t, service.isDelegationContractAddress(delegationManagerContractAddress))
+	require.True(t, service.isDelegationContractAddress("erd1qqqqqqqqqqqqqqqpqqqqqqqqqqqqqqqqqqqqqqqqqqqqqtllllls002zgc"))
+	require.True(t, service.isDelegationContractAddress(networkProvider.ConvertPubKeyToAddress(vm.FirstDelegationSCAddress)))
+	require.False(t, service.isDelegationContractAddress(networkProvider.ConvertPubKeyToAddress(vm.StakingSCAddress)))
+	require.False(t, service.isDelegationContractAddress(networkProvider.ConvertPubKeyToAddress(vm.ValidatorSCAddress)))
+	require.False(t, service.isDelegationContractAddress(networkProvider.ConvertPubKeyToAddress(vm.ESDTSCAddress)))
+	require.False(t, service.isDelegationContractAddress(networkProvider.ConvertPubKeyToAddress(vm.GovernanceSCAddress)))
+	require.False(t, service.isDelegationContractAddress(networkProvider.ConvertPubKeyToAddress(vm.JailingAddress)))
+	require.False(t, service.isDelegationContractAddress(networkProvider.ConvertPubKeyToAddress(vm.EndOfEpochAddress)))
+	require.False(t, service.isDelegationContractAddress(testscommon.TestContractFooShard0.Address))
+	require.False(t, service.isDelegationContractAddress(testscommon.TestAddressAlice))
+	require.False(t, service.isDelegationContractAddress(systemContractDeployAddress))
+	require.False(t, service.isDelegationContractAddress("metachain"))
+}
+
+func TestConstructionService_GetGasLimitOfDelegation(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	service := createConstructionService(networkProvider)
+
+	require.Equal(t, uint64(12000000), service.getGasLimitOfDelegation("delegate"))
+	require.Equal(t, uint64(6000000), service.getGasLimitOfDelegation("claimRewards"))
+	require.Equal(t, uint64(60000000), service.getGasLimitOfDelegation("createNewDelegationContract"))
+	require.Equal(t, uint64(0), service.getGasLimitOfDelegation("unknown"))
+}
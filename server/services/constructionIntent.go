@@ -0,0 +1,248 @@
+package services
+
+import (
+	"encoding/hex"
+	"unicode/utf8"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-proxy-go/data"
+)
+
+const (
+	intentKindNativeTransfer      = "nativeTransfer"
+	intentKindCustomTransfer      = "customTransfer"
+	intentKindNonFungibleTransfer = "nonFungibleTransfer"
+	intentKindMultiTransfer       = "multiTransfer"
+	intentKindBuiltInFunction     = "builtInFunction"
+	intentKindContractCall        = "contractCall"
+	intentKindContractDeploy      = "contractDeploy"
+	intentKindDelegation          = "delegation"
+)
+
+const (
+	argumentTypeString  = "string"
+	argumentTypeNumber  = "number"
+	argumentTypeAddress = "address"
+	argumentTypeBytes   = "bytes"
+)
+
+const addressPubKeyLength = 32
+
+// transactionIntent describes what a prepared transaction does, as decoded from its data field (returned, for information, by "/construction/parse").
+type transactionIntent struct {
+	Kind              string            `json:"kind"`
+	Function          string            `json:"function,omitempty"`
+	IsBuiltInFunction bool              `json:"isBuiltInFunction,omitempty"`
+	Arguments         []*intentArgument `json:"arguments,omitempty"`
+	Sender            string            `json:"sender"`
+	Receiver          string            `json:"receiver"`
+	Value             string            `json:"value"`
+	Guardian          string            `json:"guardian,omitempty"`
+	Relayer           string            `json:"relayer,omitempty"`
+
+	// ContractCall is the contract call that follows a token transfer (if any).
+	ContractCall *constructionContractCall `json:"contractCall,omitempty"`
+}
+
+// intentArgument is a decoded argument of a function call. Arguments that cannot be decoded as expected are kept as they are (hex-encoded).
+type intentArgument struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type argumentSchema struct {
+	name         string
+	argumentType string
+}
+
+// builtInFunctionSchema describes the arguments of a built-in function.
+// The arguments that follow the fixed ones are either repeated (if "repeated" is set), or form a contract call (for token transfers).
+type builtInFunctionSchema struct {
+	arguments  []argumentSchema
+	repeated   []argumentSchema
+	isTransfer bool
+}
+
+var builtInFunctionsSchemas = map[string]*builtInFunctionSchema{
+	core.BuiltInFunctionESDTTransfer: {
+		arguments:  []argumentSchema{{"tokenIdentifier", argumentTypeString}, {"amount", argumentTypeNumber}},
+		isTransfer: true,
+	},
+	core.BuiltInFunctionESDTNFTTransfer: {
+		arguments:  []argumentSchema{{"tokenIdentifier", argumentTypeString}, {"nonce", argumentTypeNumber}, {"amount", argumentTypeNumber}, {"receiver", argumentTypeAddress}},
+		isTransfer: true,
+	},
+	core.BuiltInFunctionMultiESDTNFTTransfer: {
+		// The arguments of the transfers are decoded separately (they depend on the number of transfers).
+		arguments:  []argumentSchema{{"receiver", argumentTypeAddress}, {"numTransfers", argumentTypeNumber}},
+		isTransfer: true,
+	},
+	core.BuiltInFunctionESDTLocalMint: {
+		arguments: []argumentSchema{{"tokenIdentifier", argumentTypeString}, {"amount", argumentTypeNumber}},
+	},
+	core.BuiltInFunctionESDTLocalBurn: {
+		arguments: []argumentSchema{{"tokenIdentifier", argumentTypeString}, {"amount", argumentTypeNumber}},
+	},
+	core.BuiltInFunctionESDTNFTCreate: {
+		arguments: []argumentSchema{{"tokenIdentifier", argumentTypeString}, {"quantity", argumentTypeNumber}, {"name", argumentTypeString}, {"royalties", argumentTypeNumber}, {"hash", argumentTypeBytes}, {"attributes", argumentTypeBytes}},
+		repeated:  []argumentSchema{{"uri", argumentTypeString}},
+	},
+	core.BuiltInFunctionESDTNFTAddQuantity: {
+		arguments: []argumentSchema{{"tokenIdentifier", argumentTypeString}, {"nonce", argumentTypeNumber}, {"quantity", argumentTypeNumber}},
+	},
+	core.BuiltInFunctionESDTNFTBurn: {
+		arguments: []argumentSchema{{"tokenIdentifier", argumentTypeString}, {"nonce", argumentTypeNumber}, {"quantity", argumentTypeNumber}},
+	},
+	core.BuiltInFunctionESDTNFTAddURI: {
+		arguments: []argumentSchema{{"tokenIdentifier", argumentTypeString}, {"nonce", argumentTypeNumber}},
+		repeated:  []argumentSchema{{"uri", argumentTypeString}},
+	},
+	core.BuiltInFunctionESDTNFTUpdateAttributes: {
+		arguments: []argumentSchema{{"tokenIdentifier", argumentTypeString}, {"nonce", argumentTypeNumber}, {"attributes", argumentTypeBytes}},
+	},
+	core.BuiltInFunctionClaimDeveloperRewards: {},
+	core.BuiltInFunctionChangeOwnerAddress: {
+		arguments: []argumentSchema{{"newOwner", argumentTypeAddress}},
+	},
+	core.BuiltInFunctionSetUserName: {
+		arguments: []argumentSchema{{"username", argumentTypeString}},
+	},
+	core.BuiltInFunctionSaveKeyValue: {
+		repeated: []argumentSchema{{"key", argumentTypeBytes}, {"value", argumentTypeBytes}},
+	},
+	core.BuiltInFunctionSetGuardian: {
+		arguments: []argumentSchema{{"guardian", argumentTypeAddress}, {"serviceID", argumentTypeString}},
+	},
+	core.BuiltInFunctionGuardAccount:   {},
+	core.BuiltInFunctionUnGuardAccount: {},
+}
+
+// multiTransferArgumentsSchema describes the arguments of each transfer of a MultiESDTNFTTransfer
+var multiTransferArgumentsSchema = []argumentSchema{{"tokenIdentifier", argumentTypeString}, {"nonce", argumentTypeNumber}, {"amount", argumentTypeNumber}}
+
+// createIntentOfPreparedTx describes what a prepared transaction does, given its (parsed) data field: the built-in function (if any) and its (decoded) arguments,
+// the actual receiver (e.g. the receiver of a token transfer sent to self), and the contract call (if any).
+func (service *constructionService) createIntentOfPreparedTx(tx *data.Transaction, parsed *preparedTxData) *transactionIntent {
+	intent := &transactionIntent{
+		Kind:              parsed.kind,
+		Function:          parsed.function,
+		IsBuiltInFunction: parsed.isBuiltInFunction,
+		Sender:            tx.Sender,
+		Receiver:          parsed.receiver,
+		Value:             coalesceAmount(tx.Value),
+		Guardian:          tx.GuardianAddr,
+		Relayer:           tx.RelayerAddr,
+	}
+
+	switch {
+	case parsed.contractDeploy != nil:
+		intent.Arguments = append(intent.Arguments, &intentArgument{Name: "codeMetadata", Type: argumentTypeBytes, Value: parsed.contractDeploy.CodeMetadata})
+		intent.Arguments = append(intent.Arguments, service.decodeArguments(parsed.contractDeploy.Arguments, nil, []argumentSchema{{"argument", argumentTypeBytes}})...)
+	case parsed.isBuiltInFunction:
+		intent.Arguments = service.decodeArgumentsOfBuiltInFunction(parsed.function, builtInFunctionsSchemas[parsed.function], parsed.arguments)
+		if parsed.isTokenTransfer() {
+			intent.ContractCall = parsed.contractCall
+		}
+	case len(parsed.function) > 0:
+		intent.Arguments = service.decodeArguments(parsed.arguments, nil, []argumentSchema{{"argument", argumentTypeBytes}})
+	}
+
+	return intent
+}
+
+// addIntentToParsedMetadata adds (in the metadata returned by "/construction/parse") the decoded intent of the transaction, as "intent"
+func (service *constructionService) addIntentToParsedMetadata(tx *data.Transaction, parsed *preparedTxData, metadata objectsMap) (objectsMap, error) {
+	intent, err := toObjectsMap(service.createIntentOfPreparedTx(tx, parsed))
+	if err != nil {
+		return nil, err
+	}
+
+	// The metadata might be shared with an operation (e.g. for contract calls), thus it is copied.
+	result := make(objectsMap, len(metadata)+1)
+	for key, value := range metadata {
+		result[key] = value
+	}
+
+	result["intent"] = intent
+	return result, nil
+}
+
+func decideIntentKindOfBuiltInFunction(function string) string {
+	switch function {
+	case core.BuiltInFunctionESDTTransfer:
+		return intentKindCustomTransfer
+	case core.BuiltInFunctionESDTNFTTransfer:
+		return intentKindNonFungibleTransfer
+	case core.BuiltInFunctionMultiESDTNFTTransfer:
+		return intentKindMultiTransfer
+	default:
+		return intentKindBuiltInFunction
+	}
+}
+
+func (service *constructionService) decodeArgumentsOfBuiltInFunction(function string, schema *builtInFunctionSchema, arguments []string) []*intentArgument {
+	if function == core.BuiltInFunctionMultiESDTNFTTransfer {
+		return service.decodeArguments(arguments, schema.arguments, multiTransferArgumentsSchema)
+	}
+
+	repeated := schema.repeated
+	if len(repeated) == 0 {
+		repeated = []argumentSchema{{"argument", argumentTypeBytes}}
+	}
+
+	return service.decodeArguments(arguments, schema.arguments, repeated)
+}
+
+// decodeArguments decodes the arguments given the fixed ones, then the repeated ones (cyclically).
+func (service *constructionService) decodeArguments(arguments []string, fixed []argumentSchema, repeated []argumentSchema) []*intentArgument {
+	decoded := make([]*intentArgument, 0, len(arguments))
+
+	for i, argument := range arguments {
+		var schema argumentSchema
+		if i < len(fixed) {
+			schema = fixed[i]
+		} else {
+			schema = repeated[(i-len(fixed))%len(repeated)]
+		}
+
+		decoded = append(decoded, service.decodeArgument(argument, schema))
+	}
+
+	return decoded
+}
+
+func (service *constructionService) decodeArgument(argument string, schema argumentSchema) *intentArgument {
+	undecoded := &intentArgument{Name: schema.name, Type: argumentTypeBytes, Value: argument}
+
+	switch schema.argumentType {
+	case argumentTypeString:
+		value, err := hex.DecodeString(argument)
+		if err != nil || !utf8.Valid(value) {
+			return undecoded
+		}
+
+		return &intentArgument{Name: schema.name, Type: argumentTypeString, Value: string(value)}
+	case argumentTypeNumber:
+		value, err := hexToAmount(argument)
+		if err != nil {
+			return undecoded
+		}
+
+		return &intentArgument{Name: schema.name, Type: argumentTypeNumber, Value: value}
+	case argumentTypeAddress:
+		pubKey, err := hex.DecodeString(argument)
+		if err != nil || len(pubKey) != addressPubKeyLength {
+			return undecoded
+		}
+
+		address := service.provider.ConvertPubKeyToAddress(pubKey)
+		if len(address) == 0 {
+			return undecoded
+		}
+
+		return &intentArgument{Name: schema.name, Type: argumentTypeAddress, Value: address}
+	default:
+		return undecoded
+	}
+}
@@ -0,0 +1,268 @@
+package services
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/multiversx/mx-chain-proxy-go/data"
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConstructionService_CreateIntentOfPreparedTx(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	service := createConstructionService(networkProvider)
+
+	bobPubKeyHex := hex.EncodeToString(testscommon.TestPubKeyBob)
+
+	t.Run("native transfer, without data", func(t *testing.T) {
+		t.Parallel()
+
+		intent := parseAndCreateIntentOfPreparedTx(t, service, &data.Transaction{
+			Sender:   testscommon.TestAddressAlice,
+			Receiver: testscommon.TestAddressBob,
+			Value:    "1234",
+		})
+
+		require.Equal(t, &transactionIntent{
+			Kind:     intentKindNativeTransfer,
+			Sender:   testscommon.TestAddressAlice,
+			Receiver: testscommon.TestAddressBob,
+			Value:    "1234",
+		}, intent)
+	})
+
+	t.Run("native transfer, with a note, guarded and relayed", func(t *testing.T) {
+		t.Parallel()
+
+		intent := parseAndCreateIntentOfPreparedTx(t, service, &data.Transaction{
+			Sender:       testscommon.TestAddressAlice,
+			Receiver:     testscommon.TestAddressBob,
+			Value:        "1234",
+			Data:         []byte("hello"),
+			GuardianAddr: testscommon.TestAddressCarol,
+			RelayerAddr:  testscommon.TestUserBShard0.Address,
+		})
+
+		require.Equal(t, &transactionIntent{
+			Kind:     intentKindNativeTransfer,
+			Sender:   testscommon.TestAddressAlice,
+			Receiver: testscommon.TestAddressBob,
+			Value:    "1234",
+			Guardian: testscommon.TestAddressCarol,
+			Relayer:  testscommon.TestUserBShard0.Address,
+		}, intent)
+	})
+
+	t.Run("custom currency transfer", func(t *testing.T) {
+		t.Parallel()
+
+		intent := parseAndCreateIntentOfPreparedTx(t, service, &data.Transaction{
+			Sender:   testscommon.TestAddressAlice,
+			Receiver: testscommon.TestAddressBob,
+			Value:    "0",
+			Data:     []byte("ESDTTransfer@544553542d616263646566@64"),
+		})
+
+		require.Equal(t, &transactionIntent{
+			Kind:              intentKindCustomTransfer,
+			Function:          "ESDTTransfer",
+			IsBuiltInFunction: true,
+			Arguments: []*intentArgument{
+				{Name: "tokenIdentifier", Type: argumentTypeString, Value: "TEST-abcdef"},
+				{Name: "amount", Type: argumentTypeNumber, Value: "100"},
+			},
+			Sender:   testscommon.TestAddressAlice,
+			Receiver: testscommon.TestAddressBob,
+			Value:    "0",
+		}, intent)
+	})
+
+	t.Run("non-fungible transfer (sent to self), followed by a contract call", func(t *testing.T) {
+		t.Parallel()
+
+		intent := parseAndCreateIntentOfPreparedTx(t, service, &data.Transaction{
+			Sender:   testscommon.TestAddressAlice,
+			Receiver: testscommon.TestAddressAlice,
+			Value:    "0",
+			Data:     []byte(fmt.Sprintf("ESDTNFTTransfer@4e46542d616263646566@0a@01@%s@%s@07", bobPubKeyHex, hex.EncodeToString([]byte("stake")))),
+		})
+
+		require.Equal(t, &transactionIntent{
+			Kind:              intentKindNonFungibleTransfer,
+			Function:          "ESDTNFTTransfer",
+			IsBuiltInFunction: true,
+			Arguments: []*intentArgument{
+				{Name: "tokenIdentifier", Type: argumentTypeString, Value: "NFT-abcdef"},
+				{Name: "nonce", Type: argumentTypeNumber, Value: "10"},
+				{Name: "amount", Type: argumentTypeNumber, Value: "1"},
+				{Name: "receiver", Type: argumentTypeAddress, Value: testscommon.TestAddressBob},
+			},
+			Sender:   testscommon.TestAddressAlice,
+			Receiver: testscommon.TestAddressBob,
+			Value:    "0",
+			ContractCall: &constructionContractCall{
+				Function:  "stake",
+				Arguments: []string{"07"},
+			},
+		}, intent)
+	})
+
+	t.Run("multi-token transfer (sent to self)", func(t *testing.T) {
+		t.Parallel()
+
+		intent := parseAndCreateIntentOfPreparedTx(t, service, &data.Transaction{
+			Sender:   testscommon.TestAddressAlice,
+			Receiver: testscommon.TestAddressAlice,
+			Value:    "0",
+			Data:     []byte(fmt.Sprintf("MultiESDTNFTTransfer@%s@02@544553542d616263646566@@64@45474c442d303030303030@@0a", bobPubKeyHex)),
+		})
+
+		require.Equal(t, intentKindMultiTransfer, intent.Kind)
+		require.Equal(t, testscommon.TestAddressBob, intent.Receiver)
+		require.Nil(t, intent.ContractCall)
+		require.Equal(t, []*intentArgument{
+			{Name: "receiver", Type: argumentTypeAddress, Value: testscommon.TestAddressBob},
+			{Name: "numTransfers", Type: argumentTypeNumber, Value: "2"},
+			{Name: "tokenIdentifier", Type: argumentTypeString, Value: "TEST-abcdef"},
+			{Name: "nonce", Type: argumentTypeNumber, Value: "0"},
+			{Name: "amount", Type: argumentTypeNumber, Value: "100"},
+			{Name: "tokenIdentifier", Type: argumentTypeString, Value: "EGLD-000000"},
+			{Name: "nonce", Type: argumentTypeNumber, Value: "0"},
+			{Name: "amount", Type: argumentTypeNumber, Value: "10"},
+		}, intent.Arguments)
+	})
+
+	t.Run("multi-token transfer, with a number of transfers not backed by arguments", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := service.parsePreparedTx(&data.Transaction{
+			Sender:   testscommon.TestAddressAlice,
+			Receiver: testscommon.TestAddressAlice,
+			Value:    "0",
+			Data:     []byte(fmt.Sprintf("MultiESDTNFTTransfer@%s@ffffffffffffffff@aa@bb", bobPubKeyHex)),
+		})
+
+		require.ErrorContains(t, err, "bad number of arguments for multi-token transfer")
+	})
+
+	t.Run("other built-in functions", func(t *testing.T) {
+		t.Parallel()
+
+		intent := parseAndCreateIntentOfPreparedTx(t, service, &data.Transaction{
+			Sender:   testscommon.TestAddressAlice,
+			Receiver: testscommon.TestAddressAlice,
+			Value:    "0",
+			Data:     []byte("ESDTNFTAddURI@4e46542d616263646566@0a@68747470733a2f2f61@68747470733a2f2f62"),
+		})
+
+		require.Equal(t, intentKindBuiltInFunction, intent.Kind)
+		require.True(t, intent.IsBuiltInFunction)
+		require.Equal(t, testscommon.TestAddressAlice, intent.Receiver)
+		require.Equal(t, []*intentArgument{
+			{Name: "tokenIdentifier", Type: argumentTypeString, Value: "NFT-abcdef"},
+			{Name: "nonce", Type: argumentTypeNumber, Value: "10"},
+			{Name: "uri", Type: argumentTypeString, Value: "https://a"},
+			{Name: "uri", Type: argumentTypeString, Value: "https://b"},
+		}, intent.Arguments)
+
+		intent = parseAndCreateIntentOfPreparedTx(t, service, &data.Transaction{
+			Sender:   testscommon.TestAddressAlice,
+			Receiver: testscommon.TestAddressAlice,
+			Value:    "0",
+			Data:     []byte(fmt.Sprintf("SetGuardian@%s@%s", hex.EncodeToString(testscommon.TestPubKeyCarol), hex.EncodeToString([]byte("service")))),
+		})
+
+		require.Equal(t, []*intentArgument{
+			{Name: "guardian", Type: argumentTypeAddress, Value: testscommon.TestAddressCarol},
+			{Name: "serviceID", Type: argumentTypeString, Value: "service"},
+		}, intent.Arguments)
+	})
+
+	t.Run("built-in function, with arguments that cannot be decoded", func(t *testing.T) {
+		t.Parallel()
+
+		intent := parseAndCreateIntentOfPreparedTx(t, service, &data.Transaction{
+			Sender:   testscommon.TestAddressAlice,
+			Receiver: testscommon.TestAddressAlice,
+			Value:    "0",
+			Data:     []byte("ChangeOwnerAddress@aabb@ccdd"),
+		})
+
+		require.Equal(t, []*intentArgument{
+			{Name: "newOwner", Type: argumentTypeBytes, Value: "aabb"},
+			{Name: "argument", Type: argumentTypeBytes, Value: "ccdd"},
+		}, intent.Arguments)
+	})
+
+	t.Run("contract call", func(t *testing.T) {
+		t.Parallel()
+
+		intent := parseAndCreateIntentOfPreparedTx(t, service, &data.Transaction{
+			Sender:   testscommon.TestAddressAlice,
+			Receiver: testscommon.TestContractFooShard0.Address,
+			Value:    "1234",
+			Data:     []byte("add@07"),
+		})
+
+		require.Equal(t, intentKindContractCall, intent.Kind)
+		require.Equal(t, "add", intent.Function)
+		require.False(t, intent.IsBuiltInFunction)
+		require.Equal(t, []*intentArgument{{Name: "argument", Type: argumentTypeBytes, Value: "07"}}, intent.Arguments)
+	})
+
+	t.Run("delegation", func(t *testing.T) {
+		t.Parallel()
+
+		intent := parseAndCreateIntentOfPreparedTx(t, service, &data.Transaction{
+			Sender:   testscommon.TestAddressAlice,
+			Receiver: delegationManagerContractAddress,
+			Value:    "1250000000000000000000",
+			Data:     []byte("createNewDelegationContract@@"),
+		})
+
+		require.Equal(t, intentKindDelegation, intent.Kind)
+		require.Equal(t, "createNewDelegationContract", intent.Function)
+	})
+
+	t.Run("contract deployment", func(t *testing.T) {
+		t.Parallel()
+
+		intent := parseAndCreateIntentOfPreparedTx(t, service, &data.Transaction{
+			Sender:   testscommon.TestAddressAlice,
+			Receiver: systemContractDeployAddress,
+			Value:    "0",
+			Data:     []byte("0061736d@0500@0500@2a"),
+		})
+
+		require.Equal(t, intentKindContractDeploy, intent.Kind)
+		require.Equal(t, []*intentArgument{
+			{Name: "codeMetadata", Type: argumentTypeBytes, Value: "0500"},
+			{Name: "argument", Type: argumentTypeBytes, Value: "2a"},
+		}, intent.Arguments)
+	})
+}
+
+// parseAndCreateIntentOfPreparedTx parses the data field of a prepared transaction (expected to be well-formed), then creates its intent
+func parseAndCreateIntentOfPreparedTx(t *testing.T, service *constructionService, tx *data.Transaction) *transactionIntent {
+	parsed, err := service.parsePreparedTx(tx)
+	require.Nil(t, err)
+
+	return service.createIntentOfPreparedTx(tx, parsed)
+}
+
+// popIntentOfParsedMetadata removes the intent from the metadata returned by "/construction/parse", and returns it
+func popIntentOfParsedMetadata(t *testing.T, metadata map[string]interface{}) *transactionIntent {
+	intentAsObjectsMap, ok := metadata["intent"].(objectsMap)
+	require.True(t, ok)
+	delete(metadata, "intent")
+
+	intent := &transactionIntent{}
+	err := fromObjectsMap(intentAsObjectsMap, intent)
+	require.Nil(t, err)
+
+	return intent
+}
@@ -19,6 +19,18 @@ type constructionMetadata struct {
 	Data           []byte `json:"data"`
 	ChainID        string `json:"chainID"`
 	Version        int    `json:"version"`
+	Options        uint32 `json:"options,omitempty"`
+	Guardian       string `json:"guardian,omitempty"`
+	Relayer        string `json:"relayer,omitempty"`
+
+	// GasEstimationMethod reports how the gas limit has been decided (it does not affect the transaction).
+	GasEstimationMethod string `json:"gasEstimationMethod,omitempty"`
+
+	// ReceiverUsername is the username the receiver has been resolved from. It is set on the transaction, as well (so that the protocol checks the mapping).
+	ReceiverUsername string `json:"receiverUsername,omitempty"`
+
+	// Unchecked is set in offline mode: nothing (e.g. the nonce, the guardian) has been checked against the state of the chain (it does not affect the transaction).
+	Unchecked bool `json:"unchecked,omitempty"`
 }
 
 func newConstructionMetadata(obj objectsMap) (*constructionMetadata, error) {
@@ -61,6 +73,14 @@ func (metadata *constructionMetadata) toTransaction() (*data.Transaction, error)
 		Data:     metadata.Data,
 		ChainID:  metadata.ChainID,
 		Version:  uint32(metadata.Version),
+		Options:  metadata.Options,
+
+		GuardianAddr: metadata.Guardian,
+		RelayerAddr:  metadata.Relayer,
+	}
+
+	if len(metadata.ReceiverUsername) > 0 {
+		tx.ReceiverUsername = []byte(metadata.ReceiverUsername)
 	}
 
 	return tx, nil
@@ -79,12 +99,47 @@ func (metadata *constructionMetadata) validate() error {
 	if metadata.GasPrice == 0 {
 		return errors.New("missing metadata: 'gasPrice'")
 	}
-	if metadata.Version != 1 {
+	if metadata.Version != transactionVersion && metadata.Version != transactionVersionWithOptions {
 		return fmt.Errorf("bad metadata: unexpected 'version' %v", metadata.Version)
 	}
 	if len(metadata.ChainID) == 0 {
 		return errors.New("missing metadata: 'chainID'")
 	}
+	if metadata.Version == transactionVersion && metadata.Options != 0 {
+		return fmt.Errorf("bad metadata: 'options' require 'version' %d", transactionVersionWithOptions)
+	}
+	if metadata.Options&^(transactionOptionSignedWithHash|transactionOptionGuarded) != 0 {
+		return fmt.Errorf("bad metadata: unexpected 'options' %d", metadata.Options)
+	}
+	if metadata.isRelayed() && metadata.Version != transactionVersionWithOptions {
+		return fmt.Errorf("bad metadata: 'relayer' requires 'version' %d", transactionVersionWithOptions)
+	}
+	if metadata.hasGuardedOption() != metadata.isGuarded() {
+		return errors.New("bad metadata: 'guardian' must be set if and only if the guarded option is set")
+	}
 
 	return nil
 }
+
+func (metadata *constructionMetadata) setGuardian(guardian string) {
+	metadata.Version = transactionVersionWithOptions
+	metadata.Options |= transactionOptionGuarded
+	metadata.Guardian = guardian
+}
+
+func (metadata *constructionMetadata) setRelayer(relayer string) {
+	metadata.Version = transactionVersionWithOptions
+	metadata.Relayer = relayer
+}
+
+func (metadata *constructionMetadata) isRelayed() bool {
+	return len(metadata.Relayer) > 0
+}
+
+func (metadata *constructionMetadata) isGuarded() bool {
+	return len(metadata.Guardian) > 0
+}
+
+func (metadata *constructionMetadata) hasGuardedOption() bool {
+	return metadata.Options&transactionOptionGuarded != 0
+}
@@ -28,6 +28,29 @@ func TestConstructionMetadata_ToTransactionJson(t *testing.T) {
 	require.Equal(t, expectedJson, string(actualJson))
 }
 
+func TestConstructionMetadata_ToTransactionJson_WithGuardian(t *testing.T) {
+	t.Parallel()
+
+	options := &constructionMetadata{
+		Sender:         "alice",
+		Receiver:       "bob",
+		Nonce:          42,
+		Amount:         "1234",
+		CurrencySymbol: "XeGLD",
+		GasLimit:       100000,
+		GasPrice:       1000000000,
+		ChainID:        "T",
+		Version:        1,
+	}
+
+	options.setGuardian("carol")
+
+	expectedJson := `{"nonce":42,"value":"1234","receiver":"bob","sender":"alice","gasPrice":1000000000,"gasLimit":100000,"chainID":"T","version":2,"options":2,"guardian":"carol"}`
+	actualJson, err := options.toTransactionJson()
+	require.Nil(t, err)
+	require.Equal(t, expectedJson, string(actualJson))
+}
+
 func TestConstructionMetadata_Validate(t *testing.T) {
 	t.Parallel()
 
@@ -79,4 +102,65 @@ func TestConstructionMetadata_Validate(t *testing.T) {
 		Version:  1,
 		ChainID:  "T",
 	}).validate())
+
+	require.ErrorContains(t, (&constructionMetadata{
+		Sender:   "alice",
+		Receiver: "bob",
+		GasLimit: 50000,
+		GasPrice: 1000000000,
+		Version:  1,
+		ChainID:  "T",
+		Options:  2,
+	}).validate(), "bad metadata: 'options' require 'version' 2")
+
+	require.ErrorContains(t, (&constructionMetadata{
+		Sender:   "alice",
+		Receiver: "bob",
+		GasLimit: 50000,
+		GasPrice: 1000000000,
+		Version:  1,
+		ChainID:  "T",
+		Relayer:  "carol",
+	}).validate(), "bad metadata: 'relayer' requires 'version' 2")
+
+	require.ErrorContains(t, (&constructionMetadata{
+		Sender:   "alice",
+		Receiver: "bob",
+		GasLimit: 50000,
+		GasPrice: 1000000000,
+		Version:  2,
+		ChainID:  "T",
+		Options:  4,
+	}).validate(), "bad metadata: unexpected 'options' 4")
+
+	require.ErrorContains(t, (&constructionMetadata{
+		Sender:   "alice",
+		Receiver: "bob",
+		GasLimit: 50000,
+		GasPrice: 1000000000,
+		Version:  2,
+		ChainID:  "T",
+		Options:  2,
+	}).validate(), "bad metadata: 'guardian' must be set if and only if the guarded option is set")
+
+	require.ErrorContains(t, (&constructionMetadata{
+		Sender:   "alice",
+		Receiver: "bob",
+		GasLimit: 50000,
+		GasPrice: 1000000000,
+		Version:  2,
+		ChainID:  "T",
+		Guardian: "carol",
+	}).validate(), "bad metadata: 'guardian' must be set if and only if the guarded option is set")
+
+	require.Nil(t, (&constructionMetadata{
+		Sender:   "alice",
+		Receiver: "bob",
+		GasLimit: 50000,
+		GasPrice: 1000000000,
+		Version:  2,
+		ChainID:  "T",
+		Options:  2,
+		Guardian: "carol",
+	}).validate())
 }
@@ -0,0 +1,226 @@
+package services
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+)
+
+// nonceReservations keeps track of the nonces handed out (by the construction service) for each sender, until they expire.
+// This way, concurrent constructions for the same sender get consecutive nonces (instead of the same one).
+type nonceReservations struct {
+	mutex    sync.Mutex
+	ttl      time.Duration
+	bySender map[string]map[uint64]time.Time
+	getTime  func() time.Time
+
+	// lastSweep is the time of the last sweep of the expired reservations (of all senders)
+	lastSweep time.Time
+}
+
+// NewNonceReservations creates the nonce reservations (shared by the construction service and the nonce reservations controller)
+func NewNonceReservations(networkProvider NetworkProvider) *nonceReservations {
+	ttl := time.Duration(networkProvider.GetNetworkConfig().NonceReservationTTLSeconds) * time.Second
+	return newNonceReservations(ttl)
+}
+
+func newNonceReservations(ttl time.Duration) *nonceReservations {
+	return &nonceReservations{
+		ttl:      ttl,
+		bySender: make(map[string]map[uint64]time.Time),
+		getTime:  time.Now,
+	}
+}
+
+// reserve reserves (and returns) the lowest nonce (greater than or equal to the given one) that isn't already reserved for the sender.
+// Expired reservations, and reservations of nonces lower than the given one (already consumed), are dropped.
+// Once in a while (see sweepExpiredReservations), the expired reservations of the other senders are dropped, as well.
+func (reservations *nonceReservations) reserve(sender string, minNonce uint64) uint64 {
+	reservations.mutex.Lock()
+	defer reservations.mutex.Unlock()
+
+	now := reservations.getTime()
+	reservations.sweepExpiredReservations(now)
+	reservations.pruneReservationsOfSender(sender, now)
+
+	reserved, ok := reservations.bySender[sender]
+	if !ok {
+		reserved = make(map[uint64]time.Time)
+		reservations.bySender[sender] = reserved
+	}
+
+	for nonce := range reserved {
+		if nonce < minNonce {
+			delete(reserved, nonce)
+		}
+	}
+
+	nonce := minNonce
+	for {
+		_, isReserved := reserved[nonce]
+		if !isReserved {
+			break
+		}
+
+		nonce++
+	}
+
+	reserved[nonce] = now.Add(reservations.ttl)
+	return nonce
+}
+
+// release drops the reservation of a nonce (e.g. when the constructed transaction is abandoned)
+func (reservations *nonceReservations) release(sender string, nonce uint64) bool {
+	reservations.mutex.Lock()
+	defer reservations.mutex.Unlock()
+
+	reservations.pruneReservationsOfSender(sender, reservations.getTime())
+
+	reserved, ok := reservations.bySender[sender]
+	if !ok {
+		return false
+	}
+
+	_, isReserved := reserved[nonce]
+	delete(reserved, nonce)
+	reservations.forgetSenderIfNoReservations(sender)
+
+	return isReserved
+}
+
+// releaseAll drops all the reservations of the sender, and returns the number of dropped (non-expired) reservations
+func (reservations *nonceReservations) releaseAll(sender string) int {
+	reservations.mutex.Lock()
+	defer reservations.mutex.Unlock()
+
+	reservations.pruneReservationsOfSender(sender, reservations.getTime())
+	numReserved := len(reservations.bySender[sender])
+	delete(reservations.bySender, sender)
+
+	return numReserved
+}
+
+// getReservedNonces returns the (non-expired) reserved nonces of the sender, sorted
+func (reservations *nonceReservations) getReservedNonces(sender string) []uint64 {
+	reservations.mutex.Lock()
+	defer reservations.mutex.Unlock()
+
+	reservations.pruneReservationsOfSender(sender, reservations.getTime())
+	reserved := reservations.bySender[sender]
+
+	nonces := make([]uint64, 0, len(reserved))
+	for nonce := range reserved {
+		nonces = append(nonces, nonce)
+	}
+
+	sort.Slice(nonces, func(i, j int) bool {
+		return nonces[i] < nonces[j]
+	})
+
+	return nonces
+}
+
+// sweepExpiredReservations drops the expired reservations of all senders, at most once per TTL (thus, its cost is amortized over the reservations).
+// Otherwise, senders that aren't touched again would never be forgotten. Should be called under mutex.
+func (reservations *nonceReservations) sweepExpiredReservations(now time.Time) {
+	if now.Sub(reservations.lastSweep) < reservations.ttl {
+		return
+	}
+
+	for sender := range reservations.bySender {
+		reservations.pruneReservationsOfSender(sender, now)
+	}
+
+	reservations.lastSweep = now
+}
+
+// pruneReservationsOfSender drops the expired reservations of the sender (and forgets the sender, if no reservations are left).
+// Senders without reservations are not added. Should be called under mutex.
+func (reservations *nonceReservations) pruneReservationsOfSender(sender string, now time.Time) {
+	reserved, ok := reservations.bySender[sender]
+	if !ok {
+		return
+	}
+
+	for nonce, expiry := range reserved {
+		if !now.Before(expiry) {
+			delete(reserved, nonce)
+		}
+	}
+
+	reservations.forgetSenderIfNoReservations(sender)
+}
+
+// forgetSenderIfNoReservations should be called under mutex.
+func (reservations *nonceReservations) forgetSenderIfNoReservations(sender string) {
+	if len(reservations.bySender[sender]) == 0 {
+		delete(reservations.bySender, sender)
+	}
+}
+
+// getNonceOfSender returns the nonce provided by the caller (if any), or the network nonce of the sender, otherwise.
+// The account of the sender is returned, as well, if it had to be fetched (nil otherwise).
+func (service *constructionService) getNonceOfSender(options *constructionOptions) (uint64, *resources.Account, error) {
+	if options.Nonce != nil {
+		return *options.Nonce, nil, nil
+	}
+
+	account, err := service.provider.GetAccount(options.Sender)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	nonce, err := getNetworkNonceOfAccount(service.provider, &account.Account)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return nonce, &account.Account, nil
+}
+
+// getNetworkNonceOfSender returns the nonce of the sender's account (on the latest final block).
+// If configured so, the transactions of the sender in the mempool are considered, as well: the nonce is the highest one in the mempool, plus one.
+func getNetworkNonceOfSender(provider NetworkProvider, sender string) (uint64, error) {
+	account, err := provider.GetAccount(sender)
+	if err != nil {
+		return 0, err
+	}
+
+	return getNetworkNonceOfAccount(provider, &account.Account)
+}
+
+// getNetworkNonceOfAccount is the same as getNetworkNonceOfSender, given the (already fetched) account of the sender.
+func getNetworkNonceOfAccount(provider NetworkProvider, account *resources.Account) (uint64, error) {
+	sender := account.Address
+	nonce := account.Nonce
+
+	if !provider.GetNetworkConfig().ShouldUseMempoolNonce {
+		return nonce, nil
+	}
+
+	highestMempoolNonce, hasMempoolTransactions, err := provider.GetHighestMempoolNonceOfSender(sender)
+	if err != nil {
+		return 0, err
+	}
+
+	if hasMempoolTransactions && highestMempoolNonce+1 > nonce {
+		log.Debug("getNetworkNonceOfSender(): using mempool nonce", "sender", sender, "accountNonce", nonce, "highestMempoolNonce", highestMempoolNonce)
+		nonce = highestMempoolNonce + 1
+	}
+
+	return nonce, nil
+}
+
+// decideNonce reserves (if configured so) and returns the nonce of a transaction under construction, given the network nonce of the sender.
+func (service *constructionService) decideNonce(sender string, networkNonce uint64) uint64 {
+	if !service.provider.GetNetworkConfig().ShouldReserveNonces {
+		return networkNonce
+	}
+
+	nonce := service.nonces.reserve(sender, networkNonce)
+	log.Debug("constructionService.decideNonce(): reserved nonce", "sender", sender, "networkNonce", networkNonce, "nonce", nonce)
+
+	return nonce
+}
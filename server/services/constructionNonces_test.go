@@ -0,0 +1,226 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNonceReservations(t *testing.T) {
+	t.Parallel()
+
+	createReservations := func() (*nonceReservations, *time.Time) {
+		now := time.Unix(1700000000, 0)
+		reservations := newNonceReservations(time.Minute)
+		reservations.getTime = func() time.Time {
+			return now
+		}
+
+		return reservations, &now
+	}
+
+	t.Run("consecutive reservations", func(t *testing.T) {
+		t.Parallel()
+
+		reservations, _ := createReservations()
+
+		require.Equal(t, uint64(42), reservations.reserve("alice", 42))
+		require.Equal(t, uint64(43), reservations.reserve("alice", 42))
+		require.Equal(t, uint64(44), reservations.reserve("alice", 42))
+		require.Equal(t, uint64(7), reservations.reserve("bob", 7))
+		require.Equal(t, []uint64{42, 43, 44}, reservations.getReservedNonces("alice"))
+		require.Equal(t, []uint64{7}, reservations.getReservedNonces("bob"))
+	})
+
+	t.Run("gaps are filled, consumed nonces are dropped", func(t *testing.T) {
+		t.Parallel()
+
+		reservations, _ := createReservations()
+
+		require.Equal(t, uint64(42), reservations.reserve("alice", 42))
+		require.Equal(t, uint64(43), reservations.reserve("alice", 42))
+		require.Equal(t, uint64(44), reservations.reserve("alice", 42))
+		require.True(t, reservations.release("alice", 43))
+		require.False(t, reservations.release("alice", 43))
+		require.Equal(t, uint64(43), reservations.reserve("alice", 42))
+
+		// Nonces 42 and 43 have been consumed (the network nonce has advanced).
+		require.Equal(t, uint64(45), reservations.reserve("alice", 44))
+		require.Equal(t, []uint64{44, 45}, reservations.getReservedNonces("alice"))
+	})
+
+	t.Run("expired reservations are dropped", func(t *testing.T) {
+		t.Parallel()
+
+		reservations, now := createReservations()
+
+		require.Equal(t, uint64(42), reservations.reserve("alice", 42))
+		*now = now.Add(30 * time.Second)
+		require.Equal(t, uint64(43), reservations.reserve("alice", 42))
+		*now = now.Add(30 * time.Second)
+		require.Equal(t, []uint64{43}, reservations.getReservedNonces("alice"))
+		require.Equal(t, uint64(42), reservations.reserve("alice", 42))
+		*now = now.Add(time.Minute)
+		require.Empty(t, reservations.getReservedNonces("alice"))
+		require.Empty(t, reservations.bySender)
+	})
+
+	t.Run("release all", func(t *testing.T) {
+		t.Parallel()
+
+		reservations, _ := createReservations()
+
+		reservations.reserve("alice", 42)
+		reservations.reserve("alice", 42)
+		reservations.reserve("bob", 7)
+
+		require.Equal(t, 2, reservations.releaseAll("alice"))
+		require.Equal(t, 0, reservations.releaseAll("alice"))
+		require.Empty(t, reservations.getReservedNonces("alice"))
+		require.Equal(t, []uint64{7}, reservations.getReservedNonces("bob"))
+		require.Equal(t, uint64(42), reservations.reserve("alice", 42))
+	})
+
+	t.Run("reads and releases do not add senders", func(t *testing.T) {
+		t.Parallel()
+
+		reservations, now := createReservations()
+
+		require.Empty(t, reservations.getReservedNonces("alice"))
+		require.False(t, reservations.release("alice", 42))
+		require.Equal(t, 0, reservations.releaseAll("alice"))
+		require.Empty(t, reservations.bySender)
+
+		reservations.reserve("bob", 7)
+		require.True(t, reservations.release("bob", 7))
+		require.Empty(t, reservations.bySender)
+
+		reservations.reserve("carol", 7)
+		*now = now.Add(time.Minute)
+		require.False(t, reservations.release("carol", 8))
+		require.Empty(t, reservations.bySender)
+	})
+
+	t.Run("expired reservations of inactive senders are swept", func(t *testing.T) {
+		t.Parallel()
+
+		reservations, now := createReservations()
+
+		reservations.reserve("alice", 42)
+		reservations.reserve("bob", 7)
+		require.Contains(t, reservations.bySender, "alice")
+
+		// Only Bob is active, from now on.
+		*now = now.Add(30 * time.Second)
+		reservations.reserve("bob", 7)
+		require.Contains(t, reservations.bySender, "alice")
+
+		*now = now.Add(30 * time.Second)
+		reservations.reserve("bob", 7)
+		require.NotContains(t, reservations.bySender, "alice")
+		require.Equal(t, []uint64{7, 8}, reservations.getReservedNonces("bob"))
+	})
+}
+
+func TestGetNetworkNonceOfSender(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	networkProvider.MockAccountsByAddress[testscommon.TestAddressAlice] = &resources.Account{
+		Address: testscommon.TestAddressAlice,
+		Nonce:   42,
+	}
+	networkProvider.MockMempoolNoncesBySender[testscommon.TestAddressAlice] = 44
+
+	networkProvider.MockAccountsByAddress[testscommon.TestAddressBob] = &resources.Account{
+		Address: testscommon.TestAddressBob,
+		Nonce:   7,
+	}
+	networkProvider.MockMempoolNoncesBySender[testscommon.TestAddressBob] = 3
+
+	nonce, err := getNetworkNonceOfSender(networkProvider, testscommon.TestAddressAlice)
+	require.Nil(t, err)
+	require.Equal(t, uint64(42), nonce)
+
+	networkProvider.MockNetworkConfig.ShouldUseMempoolNonce = true
+
+	nonce, err = getNetworkNonceOfSender(networkProvider, testscommon.TestAddressAlice)
+	require.Nil(t, err)
+	require.Equal(t, uint64(45), nonce)
+
+	// Stale mempool transactions are ignored.
+	nonce, err = getNetworkNonceOfSender(networkProvider, testscommon.TestAddressBob)
+	require.Nil(t, err)
+	require.Equal(t, uint64(7), nonce)
+
+	networkProvider.MockNextError = errors.New("arbitrary error")
+	_, err = getNetworkNonceOfSender(networkProvider, testscommon.TestAddressAlice)
+	require.ErrorContains(t, err, "arbitrary error")
+}
+
+func TestConstructionService_ConstructionMetadata_WithNonceReservations(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	networkProvider.MockNetworkConfig.ShouldUseMempoolNonce = true
+	networkProvider.MockNetworkConfig.ShouldReserveNonces = true
+	networkProvider.MockNetworkConfig.NonceReservationTTLSeconds = 120
+	networkProvider.MockAccountsByAddress[testscommon.TestAddressAlice] = &resources.Account{
+		Address: testscommon.TestAddressAlice,
+		Nonce:   42,
+	}
+	networkProvider.MockMempoolNoncesBySender[testscommon.TestAddressAlice] = 42
+
+	service := createConstructionService(networkProvider)
+
+	getNonce := func() uint64 {
+		response, errTyped := service.ConstructionMetadata(context.Background(),
+			&types.ConstructionMetadataRequest{
+				Options: objectsMap{
+					"receiver":       testscommon.TestAddressBob,
+					"sender":         testscommon.TestAddressAlice,
+					"amount":         "1234",
+					"currencySymbol": "XeGLD",
+					"gasLimit":       50000,
+					"gasPrice":       1000000000,
+				},
+			},
+		)
+		require.Nil(t, errTyped)
+
+		metadata := &constructionMetadata{}
+		err := fromObjectsMap(response.Metadata, metadata)
+		require.NoError(t, err)
+
+		return metadata.Nonce
+	}
+
+	require.Equal(t, uint64(43), getNonce())
+	require.Equal(t, uint64(44), getNonce())
+	require.Equal(t, uint64(45), getNonce())
+
+	service.nonces.release(testscommon.TestAddressAlice, 44)
+	require.Equal(t, uint64(44), getNonce())
+
+	// Failed requests do not reserve nonces.
+	_, errTyped := service.ConstructionMetadata(context.Background(),
+		&types.ConstructionMetadataRequest{
+			Options: objectsMap{
+				"receiver":       testscommon.TestAddressBob,
+				"sender":         testscommon.TestAddressAlice,
+				"amount":         "1234",
+				"currencySymbol": "XeGLD",
+				"gasLimit":       40000,
+				"gasPrice":       1000000000,
+			},
+		},
+	)
+	require.NotNil(t, errTyped)
+	require.Equal(t, []uint64{43, 44, 45}, service.nonces.getReservedNonces(testscommon.TestAddressAlice))
+}
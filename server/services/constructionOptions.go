@@ -2,6 +2,9 @@ package services
 
 import (
 	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
 )
 
 type constructionOptions struct {
@@ -12,6 +15,27 @@ type constructionOptions struct {
 	GasLimit       uint64 `json:"gasLimit"`
 	GasPrice       uint64 `json:"gasPrice"`
 	Data           []byte `json:"data"`
+	Guardian       string `json:"guardian,omitempty"`
+	Relayer        string `json:"relayer,omitempty"`
+	Version        int    `json:"version,omitempty"`
+	Options        uint32 `json:"options,omitempty"`
+
+	Transfers []*constructionTransfer `json:"transfers,omitempty"`
+
+	ContractCall   *constructionContractCall   `json:"contractCall,omitempty"`
+	ContractDeploy *constructionContractDeploy `json:"contractDeploy,omitempty"`
+
+	Delegation *constructionDelegation `json:"delegation,omitempty"`
+
+	// ReceiverUsername is the username (if any) the receiver has been resolved from.
+	ReceiverUsername string `json:"receiverUsername,omitempty"`
+
+	// MaxFee and FeeMultiplier are taken from "max_fee" and "suggested_fee_multiplier" (of the preprocess request).
+	MaxFee        string  `json:"maxFee,omitempty"`
+	FeeMultiplier float64 `json:"feeMultiplier,omitempty"`
+
+	// Nonce is the nonce provided by the caller (if any). It is used as it is (neither fetched from the network, nor reserved).
+	Nonce *uint64 `json:"nonce,omitempty"`
 }
 
 func newConstructionOptions(obj objectsMap) (*constructionOptions, error) {
@@ -40,13 +64,64 @@ func (options *constructionOptions) coalesceGasPrice(minGasPrice uint64) uint64
 	return options.GasPrice
 }
 
+// applyFeeMultiplier scales the gas price by the fee multiplier (if any), rounding up.
+// The multiplier is handled as the decimal number it's written as (e.g. 1.1), not as its binary approximation, so that the result is exact.
+func (options *constructionOptions) applyFeeMultiplier(gasPrice uint64) uint64 {
+	if options.FeeMultiplier <= 0 {
+		return gasPrice
+	}
+
+	multiplier, ok := big.NewRat(0, 1).SetString(strconv.FormatFloat(options.FeeMultiplier, 'f', -1, 64))
+	if !ok {
+		return gasPrice
+	}
+
+	scaled := big.NewRat(0, 1).Mul(big.NewRat(0, 1).SetUint64(gasPrice), multiplier)
+	quotient, remainder := big.NewInt(0).QuoRem(scaled.Num(), scaled.Denom(), big.NewInt(0))
+	if remainder.Sign() > 0 {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+
+	return quotient.Uint64()
+}
+
+func (options *constructionOptions) coalesceVersion(defaultVersion int) int {
+	if options.Version == 0 {
+		return defaultVersion
+	}
+
+	return options.Version
+}
+
 func (options *constructionOptions) validate(nativeCurrencySymbol string) error {
 	if len(options.Sender) == 0 {
 		return errors.New("missing option: 'sender'")
 	}
-	if len(options.Receiver) == 0 {
+	if len(options.Receiver) == 0 && !options.isContractDeploy() {
 		return errors.New("missing option: 'receiver'")
 	}
+	if len(options.Relayer) > 0 && options.Relayer == options.Sender {
+		return errors.New("option 'relayer' must differ from 'sender'")
+	}
+	if options.Version != 0 && options.Version != transactionVersion && options.Version != transactionVersionWithOptions {
+		return fmt.Errorf("unexpected option 'version': %d", options.Version)
+	}
+	if options.Options&^transactionOptionSignedWithHash != 0 {
+		// The guarded option is set automatically (see "guardian").
+		return fmt.Errorf("unexpected option 'options': %d (only the hash-signing flag can be set)", options.Options)
+	}
+	if options.Options != 0 && options.Version != transactionVersionWithOptions {
+		return fmt.Errorf("option 'options' requires 'version' %d", transactionVersionWithOptions)
+	}
+	if options.isDelegation() {
+		return options.validateDelegation()
+	}
+	if options.isContractInteraction() {
+		return options.validateContractInteraction(nativeCurrencySymbol)
+	}
+	if options.isMultiTransfer() {
+		return options.validateMultiTransfer()
+	}
 	if isZeroAmount(options.Amount) {
 		return errors.New("missing option: 'amount'")
 	}
@@ -59,3 +134,106 @@ func (options *constructionOptions) validate(nativeCurrencySymbol string) error
 
 	return nil
 }
+
+func (options *constructionOptions) isContractCall() bool {
+	return options.ContractCall != nil
+}
+
+func (options *constructionOptions) isContractDeploy() bool {
+	return options.ContractDeploy != nil
+}
+
+func (options *constructionOptions) isContractInteraction() bool {
+	return options.isContractCall() || options.isContractDeploy()
+}
+
+// validateContractInteraction validates the options of a contract call or deployment.
+// Transferring value (native currency or tokens) along with a contract call is optional. Deployments can only receive native currency.
+func (options *constructionOptions) validateContractInteraction(nativeCurrencySymbol string) error {
+	if options.isContractCall() && options.isContractDeploy() {
+		return errors.New("options 'contractCall' and 'contractDeploy' are mutually exclusive")
+	}
+	if len(options.Data) > 0 {
+		return errors.New("for contract interactions, option 'data' must be empty (it is computed)")
+	}
+
+	if options.isContractDeploy() {
+		if len(options.Receiver) > 0 {
+			return errors.New("for contract deployments, option 'receiver' must be empty")
+		}
+		if options.isMultiTransfer() || (len(options.CurrencySymbol) > 0 && options.CurrencySymbol != nativeCurrencySymbol) {
+			return errors.New("for contract deployments, only the native currency can be transferred")
+		}
+
+		return options.ContractDeploy.validate()
+	}
+
+	err := options.ContractCall.validate()
+	if err != nil {
+		return err
+	}
+
+	if options.isMultiTransfer() {
+		return options.validateMultiTransfer()
+	}
+	if isNonZeroAmount(options.Amount) && len(options.CurrencySymbol) == 0 {
+		return errors.New("missing option: 'currencySymbol'")
+	}
+	if len(options.CurrencySymbol) > 0 && options.CurrencySymbol != nativeCurrencySymbol && isZeroAmount(options.Amount) {
+		return errors.New("missing option: 'amount'")
+	}
+
+	return nil
+}
+
+func (options *constructionOptions) isDelegation() bool {
+	return options.Delegation != nil
+}
+
+// validateDelegation validates the options of a delegation transaction.
+// The value to be transferred (if any) is given by the delegation itself (see "delegation.amount").
+func (options *constructionOptions) validateDelegation() error {
+	if options.isContractInteraction() || options.isMultiTransfer() {
+		return errors.New("option 'delegation' cannot be combined with contract interactions or multi-token transfers")
+	}
+	if len(options.Amount) > 0 || len(options.CurrencySymbol) > 0 {
+		return errors.New("for delegation, options 'amount' and 'currencySymbol' must be empty (see 'delegation.amount')")
+	}
+	if len(options.Data) > 0 {
+		return errors.New("for delegation, option 'data' must be empty (it is computed)")
+	}
+
+	isReceiverDelegationManager := options.Receiver == delegationManagerContractAddress
+	if options.Delegation.isCreation() && !isReceiverDelegationManager {
+		return errors.New("for creating a delegation contract, option 'receiver' must be the delegation manager")
+	}
+	if !options.Delegation.isCreation() && isReceiverDelegationManager {
+		return errors.New("for delegation, option 'receiver' must be a delegation contract")
+	}
+
+	return options.Delegation.validate()
+}
+
+func (options *constructionOptions) isMultiTransfer() bool {
+	return len(options.Transfers) > 0
+}
+
+func (options *constructionOptions) validateMultiTransfer() error {
+	if len(options.Amount) > 0 || len(options.CurrencySymbol) > 0 {
+		return errors.New("for multi-token transfers, options 'amount' and 'currencySymbol' must be empty (see 'transfers')")
+	}
+	if len(options.Data) > 0 {
+		return errors.New("for multi-token transfers, option 'data' must be empty")
+	}
+
+	for i, transfer := range options.Transfers {
+		if isZeroAmount(transfer.Amount) {
+			return fmt.Errorf("missing option: 'transfers[%d].amount'", i)
+		}
+		if len(transfer.CurrencySymbol) == 0 {
+			return fmt.Errorf("missing option: 'transfers[%d].currencySymbol'", i)
+		}
+	}
+
+	return nil
+}
@@ -30,6 +30,17 @@ func TestConstructionOptions_CoalesceGasPrice(t *testing.T) {
 	require.Equal(t, uint64(1000000000), options.coalesceGasLimit(1000000000))
 }
 
+func TestConstructionOptions_ApplyFeeMultiplier(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, uint64(1000000000), (&constructionOptions{}).applyFeeMultiplier(1000000000))
+	require.Equal(t, uint64(1100000000), (&constructionOptions{FeeMultiplier: 1.1}).applyFeeMultiplier(1000000000))
+	require.Equal(t, uint64(1500000000), (&constructionOptions{FeeMultiplier: 1.5}).applyFeeMultiplier(1000000000))
+	require.Equal(t, uint64(1000000001), (&constructionOptions{FeeMultiplier: 1.0000000001}).applyFeeMultiplier(1000000000))
+	require.Equal(t, uint64(2), (&constructionOptions{FeeMultiplier: 0.5}).applyFeeMultiplier(3))
+	require.Equal(t, uint64(3300000000), (&constructionOptions{FeeMultiplier: 3.3}).applyFeeMultiplier(1000000000))
+}
+
 func TestConstructionOptions_Validate(t *testing.T) {
 	t.Parallel()
 
@@ -39,6 +50,31 @@ func TestConstructionOptions_Validate(t *testing.T) {
 		Sender: "alice",
 	}).validate("XeGLD"), "missing option: 'receiver'")
 
+	require.ErrorContains(t, (&constructionOptions{
+		Sender:   "alice",
+		Receiver: "bob",
+		Relayer:  "alice",
+	}).validate("XeGLD"), "option 'relayer' must differ from 'sender'")
+
+	require.ErrorContains(t, (&constructionOptions{
+		Sender:   "alice",
+		Receiver: "bob",
+		Version:  3,
+	}).validate("XeGLD"), "unexpected option 'version': 3")
+
+	require.ErrorContains(t, (&constructionOptions{
+		Sender:   "alice",
+		Receiver: "bob",
+		Version:  2,
+		Options:  2,
+	}).validate("XeGLD"), "unexpected option 'options': 2 (only the hash-signing flag can be set)")
+
+	require.ErrorContains(t, (&constructionOptions{
+		Sender:   "alice",
+		Receiver: "bob",
+		Options:  1,
+	}).validate("XeGLD"), "option 'options' requires 'version' 2")
+
 	require.ErrorContains(t, (&constructionOptions{
 		Sender:   "alice",
 		Receiver: "bob",
@@ -64,4 +100,130 @@ func TestConstructionOptions_Validate(t *testing.T) {
 		Amount:         "1234",
 		CurrencySymbol: "XeGLD",
 	}).validate("XeGLD"))
+
+	require.ErrorContains(t, (&constructionOptions{
+		Sender:         "alice",
+		Receiver:       "bob",
+		Amount:         "1234",
+		CurrencySymbol: "XeGLD",
+		Transfers:      []*constructionTransfer{{Amount: "1", CurrencySymbol: "TEST-abcdef"}},
+	}).validate("XeGLD"), "for multi-token transfers, options 'amount' and 'currencySymbol' must be empty")
+
+	require.ErrorContains(t, (&constructionOptions{
+		Sender:    "alice",
+		Receiver:  "bob",
+		Transfers: []*constructionTransfer{{Amount: "1", CurrencySymbol: "TEST-abcdef"}, {Amount: "0", CurrencySymbol: "XeGLD"}},
+	}).validate("XeGLD"), "missing option: 'transfers[1].amount'")
+
+	require.Nil(t, (&constructionOptions{
+		Sender:    "alice",
+		Receiver:  "bob",
+		Transfers: []*constructionTransfer{{Amount: "1", CurrencySymbol: "TEST-abcdef"}, {Amount: "7", CurrencySymbol: "XeGLD"}},
+	}).validate("XeGLD"))
+
+	require.ErrorContains(t, (&constructionOptions{
+		Sender:         "alice",
+		Receiver:       "contract",
+		ContractCall:   &constructionContractCall{Function: "add"},
+		ContractDeploy: &constructionContractDeploy{Code: "0061736d"},
+	}).validate("XeGLD"), "options 'contractCall' and 'contractDeploy' are mutually exclusive")
+
+	require.ErrorContains(t, (&constructionOptions{
+		Sender:       "alice",
+		Receiver:     "contract",
+		Data:         []byte("add@07"),
+		ContractCall: &constructionContractCall{Function: "add"},
+	}).validate("XeGLD"), "for contract interactions, option 'data' must be empty")
+
+	require.ErrorContains(t, (&constructionOptions{
+		Sender:       "alice",
+		ContractCall: &constructionContractCall{Function: "add"},
+	}).validate("XeGLD"), "missing option: 'receiver'")
+
+	require.ErrorContains(t, (&constructionOptions{
+		Sender:       "alice",
+		Receiver:     "contract",
+		ContractCall: &constructionContractCall{},
+	}).validate("XeGLD"), "missing option: 'contractCall.function'")
+
+	require.ErrorContains(t, (&constructionOptions{
+		Sender:         "alice",
+		Receiver:       "contract",
+		CurrencySymbol: "TEST-abcdef",
+		ContractCall:   &constructionContractCall{Function: "add"},
+	}).validate("XeGLD"), "missing option: 'amount'")
+
+	require.Nil(t, (&constructionOptions{
+		Sender:       "alice",
+		Receiver:     "contract",
+		ContractCall: &constructionContractCall{Function: "add"},
+	}).validate("XeGLD"))
+
+	require.Nil(t, (&constructionOptions{
+		Sender:         "alice",
+		Receiver:       "contract",
+		Amount:         "100",
+		CurrencySymbol: "TEST-abcdef",
+		ContractCall:   &constructionContractCall{Function: "add", Arguments: []string{"07"}},
+	}).validate("XeGLD"))
+
+	require.ErrorContains(t, (&constructionOptions{
+		Sender:         "alice",
+		Receiver:       "bob",
+		ContractDeploy: &constructionContractDeploy{Code: "0061736d"},
+	}).validate("XeGLD"), "for contract deployments, option 'receiver' must be empty")
+
+	require.ErrorContains(t, (&constructionOptions{
+		Sender:         "alice",
+		Amount:         "100",
+		CurrencySymbol: "TEST-abcdef",
+		ContractDeploy: &constructionContractDeploy{Code: "0061736d"},
+	}).validate("XeGLD"), "for contract deployments, only the native currency can be transferred")
+
+	require.Nil(t, (&constructionOptions{
+		Sender:         "alice",
+		Amount:         "1234",
+		CurrencySymbol: "XeGLD",
+		ContractDeploy: &constructionContractDeploy{Code: "0061736d"},
+	}).validate("XeGLD"))
+
+	require.ErrorContains(t, (&constructionOptions{
+		Sender:       "alice",
+		Receiver:     "contract",
+		ContractCall: &constructionContractCall{Function: "add"},
+		Delegation:   &constructionDelegation{Function: "claimRewards"},
+	}).validate("XeGLD"), "option 'delegation' cannot be combined with contract interactions or multi-token transfers")
+
+	require.ErrorContains(t, (&constructionOptions{
+		Sender:         "alice",
+		Receiver:       "contract",
+		Amount:         "1000",
+		CurrencySymbol: "XeGLD",
+		Delegation:     &constructionDelegation{Function: "delegate", Amount: "1000"},
+	}).validate("XeGLD"), "for delegation, options 'amount' and 'currencySymbol' must be empty (see 'delegation.amount')")
+
+	require.ErrorContains(t, (&constructionOptions{
+		Sender:     "alice",
+		Receiver:   "contract",
+		Data:       []byte("hello"),
+		Delegation: &constructionDelegation{Function: "claimRewards"},
+	}).validate("XeGLD"), "for delegation, option 'data' must be empty (it is computed)")
+
+	require.ErrorContains(t, (&constructionOptions{
+		Sender:     "alice",
+		Receiver:   "contract",
+		Delegation: &constructionDelegation{Function: "createNewDelegationContract", Amount: "1000", TotalDelegationCap: "0", ServiceFee: "0"},
+	}).validate("XeGLD"), "for creating a delegation contract, option 'receiver' must be the delegation manager")
+
+	require.ErrorContains(t, (&constructionOptions{
+		Sender:     "alice",
+		Receiver:   delegationManagerContractAddress,
+		Delegation: &constructionDelegation{Function: "claimRewards"},
+	}).validate("XeGLD"), "for delegation, option 'receiver' must be a delegation contract")
+
+	require.Nil(t, (&constructionOptions{
+		Sender:     "alice",
+		Receiver:   "contract",
+		Delegation: &constructionDelegation{Function: "delegate", Amount: "1000"},
+	}).validate("XeGLD"))
 }
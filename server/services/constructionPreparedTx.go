@@ -0,0 +1,192 @@
+package services
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-proxy-go/data"
+)
+
+// preparedTxData is the data field of a prepared transaction, as parsed (once) by "/construction/parse".
+// Both the operations and the intent of the transaction are derived from it.
+type preparedTxData struct {
+	kind              string
+	function          string
+	isBuiltInFunction bool
+	arguments         []string
+
+	// receiver is the actual receiver (e.g. the receiver of a token transfer sent to self)
+	receiver  string
+	transfers []*parsedTransfer
+
+	// contractCall is either the whole data field (for calls towards contracts), or the contract call that follows a token transfer
+	contractCall   *constructionContractCall
+	contractDeploy *constructionContractDeploy
+	delegation     *constructionDelegation
+}
+
+func (parsed *preparedTxData) isTokenTransfer() bool {
+	return parsed.kind == intentKindCustomTransfer || parsed.kind == intentKindNonFungibleTransfer || parsed.kind == intentKindMultiTransfer
+}
+
+// parsePreparedTx parses the data field of a prepared transaction: the contract deployment, the delegation,
+// the built-in function (e.g. a token transfer, possibly followed by a contract call) or the contract call it holds (if any).
+func (service *constructionService) parsePreparedTx(tx *data.Transaction) (*preparedTxData, error) {
+	txData := string(tx.Data)
+	parsed := &preparedTxData{
+		kind:     intentKindNativeTransfer,
+		receiver: tx.Receiver,
+	}
+
+	if tx.Receiver == systemContractDeployAddress {
+		deploy, err := parseContractDeploy(txData)
+		if err != nil {
+			return nil, err
+		}
+
+		parsed.kind = intentKindContractDeploy
+		parsed.contractDeploy = deploy
+		return parsed, nil
+	}
+
+	parts := strings.Split(txData, argumentsSeparator)
+
+	delegation, err := service.parseDelegationOfPreparedTx(txData, tx.Value, tx.Receiver)
+	if err != nil {
+		return nil, err
+	}
+	if delegation != nil {
+		parsed.kind = intentKindDelegation
+		parsed.function = parts[0]
+		parsed.arguments = parts[1:]
+		parsed.delegation = delegation
+		return parsed, nil
+	}
+
+	if len(txData) == 0 {
+		return parsed, nil
+	}
+
+	function := parts[0]
+	isReceiverContract := service.isContractAddress(tx.Receiver)
+
+	_, isBuiltInFunction := builtInFunctionsSchemas[function]
+	if !isBuiltInFunction {
+		if !isReceiverContract {
+			// Arbitrary data (e.g. a note) sent along with a native transfer.
+			return parsed, nil
+		}
+
+		parsed.kind = intentKindContractCall
+		if service.isDelegationContractAddress(tx.Receiver) {
+			parsed.kind = intentKindDelegation
+		}
+
+		parsed.function = function
+		parsed.arguments = parts[1:]
+		parsed.contractCall = &constructionContractCall{Function: function, Arguments: parts[1:]}
+		return parsed, nil
+	}
+
+	parsed.kind = decideIntentKindOfBuiltInFunction(function)
+	parsed.function = function
+	parsed.isBuiltInFunction = true
+	parsed.arguments = parts[1:]
+
+	if !parsed.isTokenTransfer() {
+		// Built-in functions other than token transfers can be called on contracts, as well (e.g. "ClaimDeveloperRewards").
+		if isReceiverContract {
+			parsed.contractCall = &constructionContractCall{Function: function, Arguments: parts[1:]}
+		}
+
+		return parsed, nil
+	}
+
+	numPartsOfTransfer, err := computeNumPartsOfTransfer(function, parts)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed.arguments = parts[1:numPartsOfTransfer]
+
+	err = service.parseTransfersOfPreparedTx(parsed, tx, strings.Join(parts[:numPartsOfTransfer], argumentsSeparator))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(parts) > numPartsOfTransfer {
+		// The arguments that follow the transfer form a contract call.
+		contractFunction, err := hex.DecodeString(parts[numPartsOfTransfer])
+		if err != nil {
+			return nil, errors.New("cannot decode function of contract call")
+		}
+
+		parsed.contractCall = &constructionContractCall{
+			Function:  string(contractFunction),
+			Arguments: parts[numPartsOfTransfer+1:],
+		}
+	}
+
+	return parsed, nil
+}
+
+// computeNumPartsOfTransfer returns the number of parts (of the data field) that describe a token transfer (function included).
+// The parts that follow (if any) form a contract call.
+func computeNumPartsOfTransfer(function string, parts []string) (int, error) {
+	numParts := 0
+
+	switch function {
+	case core.BuiltInFunctionESDTTransfer:
+		numParts = 3
+	case core.BuiltInFunctionESDTNFTTransfer:
+		numParts = 5
+	case core.BuiltInFunctionMultiESDTNFTTransfer:
+		numTransfers, err := parseNumTransfersOfMultiTransfer(parts)
+		if err != nil {
+			return 0, err
+		}
+
+		numParts = numPartsOfMultiTransferHeader + numTransfers*numPartsPerTransferOfMultiTransfer
+	}
+
+	// Missing parts are reported by the parsers of the transfers.
+	if numParts > len(parts) {
+		return len(parts), nil
+	}
+
+	return numParts, nil
+}
+
+// parseTransfersOfPreparedTx parses the token transfers (and the actual receiver), given the data of the transfer (contract call excluded).
+func (service *constructionService) parseTransfersOfPreparedTx(parsed *preparedTxData, tx *data.Transaction, transferData string) error {
+	switch parsed.function {
+	case core.BuiltInFunctionMultiESDTNFTTransfer:
+		receiverPubKey, transfers, err := parseMultiTransfer(transferData)
+		if err != nil {
+			return err
+		}
+
+		parsed.receiver = service.provider.ConvertPubKeyToAddress(receiverPubKey)
+		parsed.transfers = transfers
+	case core.BuiltInFunctionESDTNFTTransfer:
+		tokenIdentifier, amount, receiverPubKey, err := parseNonFungibleCustomCurrencyTransfer(transferData)
+		if err != nil {
+			return err
+		}
+
+		parsed.receiver = service.provider.ConvertPubKeyToAddress(receiverPubKey)
+		parsed.transfers = []*parsedTransfer{{tokenIdentifier: tokenIdentifier, amount: amount}}
+	default:
+		tokenIdentifier, amount, err := parseCustomCurrencyTransfer(transferData)
+		if err != nil {
+			return err
+		}
+
+		parsed.receiver = tx.Receiver
+		parsed.transfers = []*parsedTransfer{{tokenIdentifier: tokenIdentifier, amount: amount}}
+	}
+
+	return nil
+}
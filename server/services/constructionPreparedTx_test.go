@@ -0,0 +1,149 @@
+package services
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/multiversx/mx-chain-proxy-go/data"
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConstructionService_ParsePreparedTx(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	service := createConstructionService(networkProvider)
+
+	contract := testscommon.TestContractFooShard0
+	contractPubKeyHex := hex.EncodeToString(contract.PubKey)
+
+	t.Run("native currency, receiver is user", func(t *testing.T) {
+		parsed, err := service.parsePreparedTx(&data.Transaction{
+			Receiver: testscommon.TestAddressBob,
+			Value:    "1",
+			Data:     []byte("hello"),
+		})
+
+		require.Nil(t, err)
+		require.Equal(t, intentKindNativeTransfer, parsed.kind)
+		require.Equal(t, testscommon.TestAddressBob, parsed.receiver)
+		require.Nil(t, parsed.transfers)
+		require.Nil(t, parsed.contractCall)
+	})
+
+	t.Run("native currency, receiver is contract, without data", func(t *testing.T) {
+		parsed, err := service.parsePreparedTx(&data.Transaction{
+			Receiver: contract.Address,
+			Value:    "1",
+		})
+
+		require.Nil(t, err)
+		require.Equal(t, intentKindNativeTransfer, parsed.kind)
+		require.Nil(t, parsed.contractCall)
+	})
+
+	t.Run("native currency, receiver is contract", func(t *testing.T) {
+		parsed, err := service.parsePreparedTx(&data.Transaction{
+			Receiver: contract.Address,
+			Value:    "1",
+			Data:     []byte("add@07@2a"),
+		})
+
+		require.Nil(t, err)
+		require.Equal(t, intentKindContractCall, parsed.kind)
+		require.Equal(t, contract.Address, parsed.receiver)
+		require.Nil(t, parsed.transfers)
+		require.Equal(t, &constructionContractCall{Function: "add", Arguments: []string{"07", "2a"}}, parsed.contractCall)
+	})
+
+	t.Run("ESDTTransfer, without call", func(t *testing.T) {
+		parsed, err := service.parsePreparedTx(&data.Transaction{
+			Receiver: contract.Address,
+			Value:    "0",
+			Data:     []byte("ESDTTransfer@544553542d616263646566@64"),
+		})
+
+		require.Nil(t, err)
+		require.Equal(t, intentKindCustomTransfer, parsed.kind)
+		require.Equal(t, contract.Address, parsed.receiver)
+		require.Equal(t, []*parsedTransfer{{tokenIdentifier: "TEST-abcdef", amount: "100"}}, parsed.transfers)
+		require.Nil(t, parsed.contractCall)
+	})
+
+	t.Run("ESDTTransfer, with call", func(t *testing.T) {
+		parsed, err := service.parsePreparedTx(&data.Transaction{
+			Receiver: contract.Address,
+			Value:    "0",
+			Data:     []byte("ESDTTransfer@544553542d616263646566@64@616464@07"),
+		})
+
+		require.Nil(t, err)
+		require.Equal(t, intentKindCustomTransfer, parsed.kind)
+		require.Equal(t, []string{"544553542d616263646566", "64"}, parsed.arguments)
+		require.Equal(t, []*parsedTransfer{{tokenIdentifier: "TEST-abcdef", amount: "100"}}, parsed.transfers)
+		require.Equal(t, &constructionContractCall{Function: "add", Arguments: []string{"07"}}, parsed.contractCall)
+	})
+
+	t.Run("ESDTNFTTransfer, with call", func(t *testing.T) {
+		parsed, err := service.parsePreparedTx(&data.Transaction{
+			Sender:   testscommon.TestAddressAlice,
+			Receiver: testscommon.TestAddressAlice,
+			Value:    "0",
+			Data:     []byte(fmt.Sprintf("ESDTNFTTransfer@4e46542d616263646566@0a@01@%s@616464", contractPubKeyHex)),
+		})
+
+		require.Nil(t, err)
+		require.Equal(t, intentKindNonFungibleTransfer, parsed.kind)
+		require.Equal(t, contract.Address, parsed.receiver)
+		require.Equal(t, []*parsedTransfer{{tokenIdentifier: "NFT-abcdef-0a", amount: "1"}}, parsed.transfers)
+		require.Equal(t, &constructionContractCall{Function: "add", Arguments: []string{}}, parsed.contractCall)
+	})
+
+	t.Run("MultiESDTNFTTransfer, with call", func(t *testing.T) {
+		parsed, err := service.parsePreparedTx(&data.Transaction{
+			Sender:   testscommon.TestAddressAlice,
+			Receiver: testscommon.TestAddressAlice,
+			Value:    "0",
+			Data:     []byte(fmt.Sprintf("MultiESDTNFTTransfer@%s@02@544553542d616263646566@@64@45474c442d303030303030@@0a@616464@07", contractPubKeyHex)),
+		})
+
+		require.Nil(t, err)
+		require.Equal(t, intentKindMultiTransfer, parsed.kind)
+		require.Equal(t, contract.Address, parsed.receiver)
+		require.Equal(t, []*parsedTransfer{
+			{tokenIdentifier: "TEST-abcdef", amount: "100"},
+			{tokenIdentifier: "EGLD-000000", amount: "10", isNative: true},
+		}, parsed.transfers)
+		require.Equal(t, &constructionContractCall{Function: "add", Arguments: []string{"07"}}, parsed.contractCall)
+	})
+
+	t.Run("with bad function", func(t *testing.T) {
+		_, err := service.parsePreparedTx(&data.Transaction{
+			Receiver: contract.Address,
+			Value:    "0",
+			Data:     []byte("ESDTTransfer@544553542d616263646566@64@add"),
+		})
+
+		require.ErrorContains(t, err, "cannot decode function of contract call")
+	})
+
+	t.Run("MultiESDTNFTTransfer, with number of transfers that overflows", func(t *testing.T) {
+		_, err := service.parsePreparedTx(&data.Transaction{
+			Receiver: testscommon.TestAddressAlice,
+			Value:    "0",
+			Data:     []byte("MultiESDTNFTTransfer@010203@5555555555555556@aa@bb"),
+		})
+
+		require.ErrorContains(t, err, "bad number of arguments for multi-token transfer")
+
+		_, err = service.parsePreparedTx(&data.Transaction{
+			Receiver: testscommon.TestAddressAlice,
+			Value:    "0",
+			Data:     []byte("MultiESDTNFTTransfer@010203@2aaaaaaaaaaaaaab@aa@bb@cc@dd"),
+		})
+
+		require.ErrorContains(t, err, "bad number of arguments for multi-token transfer")
+	})
+}
@@ -8,6 +8,20 @@ type constructionPreprocessMetadata struct {
 	GasLimit       uint64 `json:"gasLimit"`
 	GasPrice       uint64 `json:"gasPrice"`
 	Data           []byte `json:"data"`
+	Guardian       string `json:"guardian"`
+	Relayer        string `json:"relayer"`
+	Version        int    `json:"version"`
+	Options        uint32 `json:"options"`
+
+	Transfers []*constructionTransfer `json:"transfers"`
+
+	ContractCall   *constructionContractCall   `json:"contractCall"`
+	ContractDeploy *constructionContractDeploy `json:"contractDeploy"`
+
+	Delegation *constructionDelegation `json:"delegation"`
+
+	// Nonce is optional (online, it is fetched from the network). In offline mode, it is required.
+	Nonce *uint64 `json:"nonce"`
 }
 
 func newConstructionPreprocessMetadata(obj objectsMap) (*constructionPreprocessMetadata, error) {
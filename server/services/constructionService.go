@@ -6,27 +6,37 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"strings"
 
 	"github.com/coinbase/rosetta-sdk-go/server"
 	"github.com/coinbase/rosetta-sdk-go/types"
 	"github.com/multiversx/mx-chain-proxy-go/data"
+	"github.com/multiversx/mx-chain-rosetta/server/provider"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
 )
 
 type constructionService struct {
 	provider   NetworkProvider
 	extension  *networkProviderExtension
 	errFactory *errFactory
+	nonces     *nonceReservations
+	submitter  *transactionsSubmitter
 }
 
-// NewConstructionService creates a new instance of an constructionService
+// NewConstructionService creates a new instance of an constructionService.
+// The nonce reservations and the transactions submitter are shared with the extension controllers (non-Rosetta routes).
 func NewConstructionService(
 	networkProvider NetworkProvider,
+	nonces *nonceReservations,
+	submitter *transactionsSubmitter,
 ) server.ConstructionAPIServicer {
 	return &constructionService{
 		provider:   networkProvider,
 		extension:  newNetworkProviderExtension(networkProvider),
 		errFactory: newErrFactory(),
+		nonces:     nonces,
+		submitter:  submitter,
 	}
 }
 
@@ -37,9 +47,6 @@ func (service *constructionService) ConstructionPreprocess(
 ) (*types.ConstructionPreprocessResponse, *types.Error) {
 	log.Debug("constructionService.ConstructionPreprocess()", "metadata", request.Metadata)
 
-	noOperationProvided := len(request.Operations) == 0
-	lessThanTwoOperationsProvided := len(request.Operations) < 2
-
 	requestMetadata, err := newConstructionPreprocessMetadata(request.Metadata)
 	if err != nil {
 		return nil, service.errFactory.newErrWithOriginal(ErrConstruction, err)
@@ -47,14 +54,162 @@ func (service *constructionService) ConstructionPreprocess(
 
 	responseOptions := &constructionOptions{}
 
+	// "Fee" operations (if any) do not describe transfers; they only designate the fee payer.
+	transferOperations, feePayer := separateFeeOperations(request.Operations)
+
+	// "SmartContractCall" and "SmartContractDeploy" operations do not describe transfers, either (transfers are optional for contract interactions).
+	transferOperations, contractOperation, err := separateContractOperation(transferOperations)
+	if err != nil {
+		return nil, service.errFactory.newErrWithOriginal(ErrConstruction, err)
+	}
+
+	contract, err := prepareContractInteraction(contractOperation, requestMetadata, responseOptions)
+	if err != nil {
+		return nil, service.errFactory.newErrWithOriginal(ErrConstruction, err)
+	}
+
+	// Delegation operations (e.g. "Delegate", "ClaimRewards") do not describe transfers, either.
+	transferOperations, delegationOperation, err := separateDelegationOperation(transferOperations)
+	if err != nil {
+		return nil, service.errFactory.newErrWithOriginal(ErrConstruction, err)
+	}
+
+	delegationContract, err := prepareDelegation(delegationOperation, requestMetadata, responseOptions)
+	if err != nil {
+		return nil, service.errFactory.newErrWithOriginal(ErrConstruction, err)
+	}
+
+	isMultiTransfer := len(requestMetadata.Transfers) > 0 || countDebitOperations(transferOperations) > 1
+	if responseOptions.isDelegation() {
+		err = prepareOptionsOfDelegation(transferOperations, delegationOperation, delegationContract, requestMetadata, responseOptions)
+	} else if isMultiTransfer {
+		err = prepareOptionsOfMultiTransfer(transferOperations, requestMetadata, responseOptions)
+	} else if responseOptions.isContractInteraction() {
+		err = prepareOptionsOfContractInteraction(transferOperations, contractOperation, contract, requestMetadata, responseOptions)
+	} else {
+		err = prepareOptionsOfTransfer(transferOperations, requestMetadata, responseOptions)
+	}
+	if err != nil {
+		return nil, service.errFactory.newErrWithOriginal(ErrConstruction, err)
+	}
+
+	if requestMetadata.GasLimit > 0 {
+		responseOptions.GasLimit = requestMetadata.GasLimit
+	}
+	if requestMetadata.GasPrice > 0 {
+		responseOptions.GasPrice = requestMetadata.GasPrice
+	}
+	if len(requestMetadata.Data) > 0 {
+		responseOptions.Data = requestMetadata.Data
+	}
+	if len(requestMetadata.Guardian) > 0 {
+		responseOptions.Guardian = requestMetadata.Guardian
+	}
+	if requestMetadata.Version > 0 {
+		responseOptions.Version = requestMetadata.Version
+	}
+	if requestMetadata.Options > 0 {
+		responseOptions.Options = requestMetadata.Options
+	}
+	if requestMetadata.Nonce != nil {
+		responseOptions.Nonce = requestMetadata.Nonce
+	}
+	if len(requestMetadata.Relayer) > 0 {
+		responseOptions.Relayer = requestMetadata.Relayer
+	} else if len(feePayer) > 0 && feePayer != responseOptions.Sender {
+		// Fallback: a fee payer other than the sender is the relayer
+		responseOptions.Relayer = feePayer
+	}
+
+	err = service.prepareFeeOptions(request.MaxFee, request.SuggestedFeeMultiplier, responseOptions)
+	if err != nil {
+		return nil, service.errFactory.newErrWithOriginal(ErrConstruction, err)
+	}
+
+	errTyped := service.resolveReceiverUsername(responseOptions)
+	if errTyped != nil {
+		return nil, errTyped
+	}
+
+	err = responseOptions.validate(
+		service.extension.getNativeCurrencySymbol(),
+	)
+	if err != nil {
+		return nil, service.errFactory.newErrWithOriginal(ErrConstruction, err)
+	}
+
+	errTyped = service.validateStrictly(responseOptions)
+	if errTyped != nil {
+		return nil, errTyped
+	}
+
+	optionsAsObjectsMap, err := toObjectsMap(responseOptions)
+	if err != nil {
+		return nil, service.errFactory.newErrWithOriginal(ErrConstruction, err)
+	}
+
+	return &types.ConstructionPreprocessResponse{
+		Options: optionsAsObjectsMap,
+	}, nil
+}
+
+// prepareFeeOptions handles "max_fee" (a single amount, in the native currency) and "suggested_fee_multiplier" (applied on the gas price).
+func (service *constructionService) prepareFeeOptions(maxFee []*types.Amount, feeMultiplier *float64, responseOptions *constructionOptions) error {
+	if len(maxFee) > 1 {
+		return errors.New("'max_fee' must contain a single amount (in the native currency)")
+	}
+	if len(maxFee) == 1 {
+		amount := maxFee[0]
+		if amount.Currency == nil || !service.extension.isNativeCurrencySymbol(amount.Currency.Symbol) {
+			return errors.New("'max_fee' must be expressed in the native currency")
+		}
+		if !isCanonicalPositiveInteger(amount.Value) {
+			return fmt.Errorf("'max_fee' must be a positive integer, not %s", amount.Value)
+		}
+
+		responseOptions.MaxFee = amount.Value
+	}
+
+	if feeMultiplier != nil {
+		if *feeMultiplier <= 0 || math.IsInf(*feeMultiplier, 0) || math.IsNaN(*feeMultiplier) {
+			return fmt.Errorf("'suggested_fee_multiplier' must be a positive number, not %v", *feeMultiplier)
+		}
+
+		responseOptions.FeeMultiplier = *feeMultiplier
+	}
+
+	return nil
+}
+
+// separateFeeOperations returns the operations other than "Fee", and the account that pays the fee (if any "Fee" operation is provided).
+func separateFeeOperations(operations []*types.Operation) ([]*types.Operation, string) {
+	transferOperations := make([]*types.Operation, 0, len(operations))
+	feePayer := ""
+
+	for _, operation := range operations {
+		if operation.Type == opFee {
+			feePayer = operation.Account.Address
+			continue
+		}
+
+		transferOperations = append(transferOperations, operation)
+	}
+
+	return transferOperations, feePayer
+}
+
+func prepareOptionsOfTransfer(operations []*types.Operation, requestMetadata *constructionPreprocessMetadata, responseOptions *constructionOptions) error {
+	noOperationProvided := len(operations) == 0
+	lessThanTwoOperationsProvided := len(operations) < 2
+
 	if len(requestMetadata.Sender) > 0 {
 		responseOptions.Sender = requestMetadata.Sender
 	} else {
 		// Fallback: get "sender" from the first operation
 		if noOperationProvided {
-			return nil, service.errFactory.newErrWithOriginal(ErrConstruction, errors.New("cannot prepare sender"))
+			return errors.New("cannot prepare sender")
 		}
-		responseOptions.Sender = request.Operations[0].Account.Address
+		responseOptions.Sender = operations[0].Account.Address
 	}
 
 	if len(requestMetadata.Receiver) > 0 {
@@ -62,9 +217,9 @@ func (service *constructionService) ConstructionPreprocess(
 	} else {
 		// Fallback: get "receiver" from the second operation
 		if lessThanTwoOperationsProvided {
-			return nil, service.errFactory.newErrWithOriginal(ErrConstruction, errors.New("cannot prepare receiver"))
+			return errors.New("cannot prepare receiver")
 		}
-		responseOptions.Receiver = request.Operations[1].Account.Address
+		responseOptions.Receiver = operations[1].Account.Address
 	}
 
 	if len(requestMetadata.Amount) > 0 {
@@ -72,9 +227,9 @@ func (service *constructionService) ConstructionPreprocess(
 	} else {
 		// Fallback: get "amount" from the first operation
 		if noOperationProvided {
-			return nil, service.errFactory.newErrWithOriginal(ErrConstruction, errors.New("cannot prepare amount"))
+			return errors.New("cannot prepare amount")
 		}
-		responseOptions.Amount = getMagnitudeOfAmount(request.Operations[0].Amount.Value)
+		responseOptions.Amount = getMagnitudeOfAmount(operations[0].Amount.Value)
 	}
 
 	if len(requestMetadata.CurrencySymbol) > 0 {
@@ -82,36 +237,45 @@ func (service *constructionService) ConstructionPreprocess(
 	} else {
 		// Fallback: get "currencySymbol" from the first operation
 		if noOperationProvided {
-			return nil, service.errFactory.newErrWithOriginal(ErrConstruction, errors.New("cannot prepare currency"))
+			return errors.New("cannot prepare currency")
 		}
-		responseOptions.CurrencySymbol = request.Operations[0].Amount.Currency.Symbol
+		responseOptions.CurrencySymbol = operations[0].Amount.Currency.Symbol
 	}
 
-	if requestMetadata.GasLimit > 0 {
-		responseOptions.GasLimit = requestMetadata.GasLimit
-	}
-	if requestMetadata.GasPrice > 0 {
-		responseOptions.GasPrice = requestMetadata.GasPrice
-	}
-	if len(requestMetadata.Data) > 0 {
-		responseOptions.Data = requestMetadata.Data
-	}
+	return nil
+}
 
-	err = responseOptions.validate(
-		service.extension.getNativeCurrencySymbol(),
-	)
-	if err != nil {
-		return nil, service.errFactory.newErrWithOriginal(ErrConstruction, err)
+func prepareOptionsOfMultiTransfer(operations []*types.Operation, requestMetadata *constructionPreprocessMetadata, responseOptions *constructionOptions) error {
+	sender, receiver, transfers := requestMetadata.Sender, requestMetadata.Receiver, requestMetadata.Transfers
+
+	if len(operations) > 0 {
+		senderOfOperations, receiverOfOperations, transfersOfOperations, err := extractMultiTransferFromOperations(operations)
+		if err != nil {
+			return err
+		}
+
+		if len(sender) == 0 {
+			sender = senderOfOperations
+		}
+		if len(receiver) == 0 {
+			receiver = receiverOfOperations
+		}
+		if len(transfers) == 0 {
+			transfers = transfersOfOperations
+		}
 	}
 
-	optionsAsObjectsMap, err := toObjectsMap(responseOptions)
-	if err != nil {
-		return nil, service.errFactory.newErrWithOriginal(ErrConstruction, err)
+	if len(sender) == 0 {
+		return errors.New("cannot prepare sender")
+	}
+	if len(receiver) == 0 {
+		return errors.New("cannot prepare receiver")
 	}
 
-	return &types.ConstructionPreprocessResponse{
-		Options: optionsAsObjectsMap,
-	}, nil
+	responseOptions.Sender = sender
+	responseOptions.Receiver = receiver
+	responseOptions.Transfers = transfers
+	return nil
 }
 
 // ConstructionMetadata gets any information required to construct a transaction for a specific network (e.g. the account nonce)
@@ -121,50 +285,121 @@ func (service *constructionService) ConstructionMetadata(
 ) (*types.ConstructionMetadataResponse, *types.Error) {
 	log.Debug("constructionService.ConstructionMetadata()", "options", request.Options)
 
-	if service.provider.IsOffline() {
-		return nil, service.errFactory.newErr(ErrOfflineMode)
-	}
-
 	requestOptions, err := newConstructionOptions(request.Options)
 	if err != nil {
 		return nil, service.errFactory.newErrWithOriginal(ErrConstruction, err)
 	}
 
-	account, err := service.provider.GetAccount(requestOptions.Sender)
+	// In offline mode, the nonce must be provided by the caller (and nothing is checked against the state of the chain).
+	isOffline := service.provider.IsOffline()
+	if isOffline && requestOptions.Nonce == nil {
+		err = errors.New("option 'nonce' is required in offline mode")
+		return nil, service.errFactory.newErrWithOriginal(ErrOfflineMode, err)
+	}
+
+	errTyped := service.resolveReceiverUsername(requestOptions)
+	if errTyped != nil {
+		return nil, errTyped
+	}
+
+	errTyped = service.validateStrictly(requestOptions)
+	if errTyped != nil {
+		return nil, errTyped
+	}
+
+	nonce, senderAccount, err := service.getNonceOfSender(requestOptions)
 	if err != nil {
 		return nil, service.errFactory.newErrWithOriginal(ErrUnableToGetAccount, err)
 	}
 
 	metadata := &constructionMetadata{
-		Nonce:          account.Account.Nonce,
+		Nonce:          nonce,
 		Sender:         requestOptions.Sender,
 		Receiver:       requestOptions.Receiver,
 		CurrencySymbol: requestOptions.CurrencySymbol,
 		ChainID:        service.provider.GetNetworkConfig().NetworkID,
-		Version:        transactionVersion,
+		Version:        requestOptions.coalesceVersion(transactionVersion),
+		Options:        requestOptions.Options,
+		Unchecked:      isOffline,
 	}
 
-	if service.extension.isNativeCurrencySymbol(requestOptions.CurrencySymbol) {
-		metadata.Amount = requestOptions.Amount
+	if requestOptions.isDelegation() {
+		if !service.isDelegationContractAddress(requestOptions.Receiver) {
+			err = errors.New("the receiver of a delegation transaction must be a delegation contract (or the delegation manager)")
+			return nil, service.errFactory.newErrWithOriginal(ErrConstruction, err)
+		}
+
+		metadata.Amount = requestOptions.Delegation.getValue()
+		metadata.Data = computeDataForDelegation(requestOptions.Delegation)
+	} else if requestOptions.isContractDeploy() {
+		metadata.Amount = coalesceAmount(requestOptions.Amount)
+		metadata.Receiver = systemContractDeployAddress
+		metadata.Data = computeDataForContractDeploy(requestOptions.ContractDeploy)
+	} else if requestOptions.isMultiTransfer() {
+		metadata.Amount = amountZero
+		metadata.Receiver = requestOptions.Sender
+		metadata.Data, err = service.computeDataForMultiTransfer(requestOptions.Receiver, requestOptions.Transfers)
+		if err != nil {
+			return nil, service.errFactory.newErrWithOriginal(ErrConstruction, err)
+		}
+	} else if service.isNativeCurrencyOfOptions(requestOptions) {
+		metadata.Amount = coalesceAmount(requestOptions.Amount)
 		metadata.Data = requestOptions.Data
 	} else {
 		metadata.Amount = amountZero
-		metadata.Data = service.computeDataForCustomCurrencyTransfer(requestOptions.CurrencySymbol, requestOptions.Amount)
+		metadata.Receiver, metadata.Data, err = service.computeCustomCurrencyTransfer(requestOptions)
+		if err != nil {
+			return nil, service.errFactory.newErrWithOriginal(ErrConstruction, err)
+		}
+	}
+
+	if requestOptions.isContractCall() {
+		metadata.Data = computeDataForContractCall(metadata.Data, requestOptions.ContractCall)
+	}
+
+	// The username is set on the transaction only if its receiver is the resolved account (e.g. not for multi-token transfers).
+	if len(requestOptions.ReceiverUsername) > 0 && metadata.Receiver == requestOptions.Receiver {
+		metadata.ReceiverUsername = requestOptions.ReceiverUsername
+	}
+
+	requestOptions.Guardian, err = service.decideGuardian(requestOptions, senderAccount)
+	if err != nil {
+		return nil, service.errFactory.newErrWithOriginal(ErrUnableToGetAccount, err)
+	}
+	if len(requestOptions.Guardian) > 0 {
+		metadata.setGuardian(requestOptions.Guardian)
+	}
+
+	if len(requestOptions.Relayer) > 0 {
+		err = service.checkRelayer(requestOptions.Sender, requestOptions.Relayer)
+		if err != nil {
+			return nil, service.errFactory.newErrWithOriginal(ErrConstruction, err)
+		}
+
+		metadata.setRelayer(requestOptions.Relayer)
 	}
 
-	fee, gasLimit, gasPrice, errTyped := service.computeFeeComponents(requestOptions, metadata.Data)
+	fee, gasLimit, gasPrice, gasEstimationMethod, errTyped := service.computeFeeComponentsOfTransaction(requestOptions, metadata)
 	if errTyped != nil {
 		return nil, errTyped
 	}
 
 	metadata.GasLimit = gasLimit
 	metadata.GasPrice = gasPrice
+	metadata.GasEstimationMethod = gasEstimationMethod
+
+	// The nonce is reserved (if configured so) only after all checks have passed. A nonce provided by the caller is not reserved.
+	if requestOptions.Nonce == nil {
+		metadata.Nonce = service.decideNonce(metadata.Sender, nonce)
+	}
 
 	metadataAsObjectsMap, err := toObjectsMap(metadata)
 	if err != nil {
 		return nil, service.errFactory.newErrWithOriginal(ErrConstruction, err)
 	}
 
+	service.addShardsToMetadata(metadata, metadataAsObjectsMap)
+
 	return &types.ConstructionMetadataResponse{
 		Metadata: metadataAsObjectsMap,
 		SuggestedFee: []*types.Amount{
@@ -173,12 +408,113 @@ func (service *constructionService) ConstructionMetadata(
 	}, nil
 }
 
+// isNativeCurrencyOfOptions returns true for transfers of native currency (contract interactions without a currency are considered as such).
+func (service *constructionService) isNativeCurrencyOfOptions(options *constructionOptions) bool {
+	if options.isContractInteraction() && len(options.CurrencySymbol) == 0 {
+		return true
+	}
+
+	return service.extension.isNativeCurrencySymbol(options.CurrencySymbol)
+}
+
+func coalesceAmount(amount string) string {
+	if len(amount) == 0 {
+		return amountZero
+	}
+
+	return amount
+}
+
+// decideGuardian returns the guardian explicitly provided by the caller, if any.
+// Otherwise, it returns the active guardian of the sender, if the sender is guarded (or an empty string, if not guarded).
+// The account of the sender (if already fetched, e.g. for its nonce) tells whether the sender is guarded, thus the guardian data is only fetched when necessary.
+func (service *constructionService) decideGuardian(options *constructionOptions, senderAccount *resources.Account) (string, error) {
+	if len(options.Guardian) > 0 {
+		return options.Guardian, nil
+	}
+
+	// In offline mode, the guardian cannot be detected (it must be explicitly provided, if any).
+	if service.provider.IsOffline() {
+		return "", nil
+	}
+
+	if senderAccount != nil && !senderAccount.IsGuarded {
+		return "", nil
+	}
+
+	guardianData, err := service.provider.GetAccountGuardianData(options.Sender)
+	if err != nil {
+		return "", err
+	}
+
+	activeGuardian := guardianData.GuardianData.ActiveGuardian
+	if !guardianData.GuardianData.Guarded || activeGuardian == nil {
+		return "", nil
+	}
+
+	return activeGuardian.Address, nil
+}
+
+// checkRelayer makes sure the relayer (of a relayed V3 transaction) is in the same shard as the sender.
+func (service *constructionService) checkRelayer(sender string, relayer string) error {
+	senderPubKey, err := service.provider.ConvertAddressToPubKey(sender)
+	if err != nil {
+		return err
+	}
+
+	relayerPubKey, err := service.provider.ConvertAddressToPubKey(relayer)
+	if err != nil {
+		return err
+	}
+
+	senderShard := service.provider.ComputeShardIdOfPubKey(senderPubKey)
+	relayerShard := service.provider.ComputeShardIdOfPubKey(relayerPubKey)
+	if senderShard != relayerShard {
+		return fmt.Errorf("relayer must be in the same shard as the sender: %d, actual: %d", senderShard, relayerShard)
+	}
+
+	return nil
+}
+
+// computeCustomCurrencyTransfer returns the (actual) receiver and the data of a custom currency transfer.
+// Fungible tokens are transferred using "ESDTTransfer", while SFTs, NFTs and MetaESDTs (tokens with a nonce) are transferred using "ESDTNFTTransfer" (sent to self).
+func (service *constructionService) computeCustomCurrencyTransfer(options *constructionOptions) (string, []byte, error) {
+	baseIdentifier, nonce, err := provider.ParseTokenIdentifier(options.CurrencySymbol)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if nonce == 0 {
+		data := service.computeDataForCustomCurrencyTransfer(options.CurrencySymbol, options.Amount)
+		return options.Receiver, data, nil
+	}
+
+	receiverPubKey, err := service.provider.ConvertAddressToPubKey(options.Receiver)
+	if err != nil {
+		return "", nil, err
+	}
+
+	data := service.computeDataForNonFungibleCustomCurrencyTransfer(baseIdentifier, nonce, options.Amount, receiverPubKey)
+	return options.Sender, data, nil
+}
+
 func (service *constructionService) computeDataForCustomCurrencyTransfer(tokenIdentifier string, amount string) []byte {
-	// Only fungible tokens are supported (at least, for now).
 	data := fmt.Sprintf("%s@%s@%s", builtInFunctionESDTTransfer, stringToHex(tokenIdentifier), amountToHex(amount))
 	return []byte(data)
 }
 
+func (service *constructionService) computeDataForNonFungibleCustomCurrencyTransfer(baseIdentifier string, nonce uint64, amount string, receiverPubKey []byte) []byte {
+	data := fmt.Sprintf("%s@%s@%s@%s@%s",
+		builtInFunctionESDTNFTTransfer,
+		stringToHex(baseIdentifier),
+		nonceToHex(nonce),
+		amountToHex(amount),
+		hex.EncodeToString(receiverPubKey),
+	)
+
+	return []byte(data)
+}
+
 // ConstructionPayloads returns an unsigned transaction blob and a collection of payloads that must be signed
 func (service *constructionService) ConstructionPayloads(
 	_ context.Context,
@@ -191,20 +527,34 @@ func (service *constructionService) ConstructionPayloads(
 		return nil, service.errFactory.newErrWithOriginal(ErrConstruction, err)
 	}
 
-	txJson, err := metadata.toTransactionJson()
+	tx, err := metadata.toTransaction()
 	if err != nil {
 		return nil, service.errFactory.newErrWithOriginal(ErrConstruction, err)
 	}
 
+	txJson, err := json.Marshal(tx)
+	if err != nil {
+		return nil, service.errFactory.newErrWithOriginal(ErrConstruction, err)
+	}
+
+	signingBytes, err := computeSigningBytes(tx)
+	if err != nil {
+		return nil, service.errFactory.newErrWithOriginal(ErrConstruction, err)
+	}
+
+	// The sender, the guardian (if any) and the relayer (if any) sign the very same bytes.
+	payloads := make([]*types.SigningPayload, 0)
+	for _, signer := range getSignersOfTransaction(tx) {
+		payloads = append(payloads, &types.SigningPayload{
+			AccountIdentifier: addressToAccountIdentifier(signer),
+			SignatureType:     types.Ed25519,
+			Bytes:             signingBytes,
+		})
+	}
+
 	return &types.ConstructionPayloadsResponse{
 		UnsignedTransaction: string(txJson),
-		Payloads: []*types.SigningPayload{
-			{
-				AccountIdentifier: addressToAccountIdentifier(metadata.Sender),
-				SignatureType:     types.Ed25519,
-				Bytes:             txJson,
-			},
-		},
+		Payloads:            payloads,
 	}, nil
 }
 
@@ -221,14 +571,23 @@ func (service *constructionService) ConstructionParse(
 
 	var signers []*types.AccountIdentifier
 	if request.Signed {
-		signers = []*types.AccountIdentifier{
-			{
-				Address: tx.Sender,
-			},
+		for _, signer := range getSignersOfTransaction(tx) {
+			signers = append(signers, addressToAccountIdentifier(signer))
 		}
 	}
 
-	operations, err := service.createOperationsFromPreparedTx(tx)
+	parsed, err := service.parsePreparedTx(tx)
+	if err != nil {
+		return nil, service.errFactory.newErrWithOriginal(ErrConstruction, err)
+	}
+
+	operations, metadata, err := service.createOperationsFromPreparedTx(tx, parsed)
+	if err != nil {
+		return nil, service.errFactory.newErrWithOriginal(ErrConstruction, err)
+	}
+
+	metadata = addUsernameMappingToParsedMetadata(tx, metadata)
+	metadata, err = service.addIntentToParsedMetadata(tx, parsed, metadata)
 	if err != nil {
 		return nil, service.errFactory.newErrWithOriginal(ErrConstruction, err)
 	}
@@ -236,55 +595,119 @@ func (service *constructionService) ConstructionParse(
 	return &types.ConstructionParseResponse{
 		Operations:               operations,
 		AccountIdentifierSigners: signers,
+		Metadata:                 metadata,
 	}, nil
 }
 
-func (service *constructionService) createOperationsFromPreparedTx(tx *data.Transaction) ([]*types.Operation, error) {
-	var operations []*types.Operation
+// createOperationsFromPreparedTx recovers the operations of a prepared transaction, given its (parsed) data field.
+// For contract interactions, it also returns (as metadata) the function and the arguments of the call, or the code metadata and the arguments of the deployment.
+// For delegation transactions, it returns (as metadata) the delegation function, its arguments, and the delegation contract.
+func (service *constructionService) createOperationsFromPreparedTx(tx *data.Transaction, parsed *preparedTxData) ([]*types.Operation, objectsMap, error) {
+	if parsed.contractDeploy != nil {
+		return service.createOperationsFromPreparedContractDeploy(tx, parsed.contractDeploy)
+	}
+	if parsed.delegation != nil {
+		return service.createOperationsFromPreparedDelegation(tx, parsed.delegation)
+	}
 
-	isCustomCurrencyTransfer := isCustomCurrencyTransfer(string(tx.Data))
+	var operations []*types.Operation
+	if len(parsed.transfers) > 0 {
+		operations = service.createOperationsFromParsedTransfers(tx.Sender, parsed.receiver, parsed.transfers)
+	} else if isNonZeroAmount(tx.Value) || (parsed.contractCall == nil && !parsed.isBuiltInFunction) {
+		// Native currency transfer (optional for contract calls and for calls of built-in functions, e.g. ESDTLocalMint)
+		operations = service.createNativeTransferOperations(tx.Sender, tx.Receiver, tx.Value)
+	}
 
-	if isCustomCurrencyTransfer {
-		tokenIdentifier, amount, err := parseCustomCurrencyTransfer(string(tx.Data))
+	var metadata objectsMap
+	if parsed.contractCall != nil {
+		contractOperation, err := service.createContractCallOperation(tx.Sender, parsed.receiver, parsed.contractCall)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
-		operations = []*types.Operation{
-			{
-				Type:    opCustomTransfer,
-				Account: addressToAccountIdentifier(tx.Sender),
-				Amount:  service.extension.valueToCustomAmount("-"+amount, tokenIdentifier),
-			},
-			{
-				Type:    opCustomTransfer,
-				Account: addressToAccountIdentifier(tx.Receiver),
-				Amount:  service.extension.valueToCustomAmount(amount, tokenIdentifier),
-			},
-		}
-	} else {
-		// Native currency transfer
-		operations = []*types.Operation{
-			{
-				Type:    opTransfer,
-				Account: addressToAccountIdentifier(tx.Sender),
-				Amount:  service.extension.valueToNativeAmount("-" + tx.Value),
-			},
-			{
-				Type:    opTransfer,
-				Account: addressToAccountIdentifier(tx.Receiver),
-				Amount:  service.extension.valueToNativeAmount(tx.Value),
-			},
-		}
+		operations = append(operations, contractOperation)
+		metadata = contractOperation.Metadata
 	}
 
+	operations = service.appendRelayerFeeOperation(tx, operations)
 	indexOperations(operations)
 
-	return operations, nil
+	return operations, metadata, nil
 }
 
-func isCustomCurrencyTransfer(txData string) bool {
-	return strings.HasPrefix(txData, builtInFunctionESDTTransfer)
+func (service *constructionService) createOperationsFromPreparedContractDeploy(tx *data.Transaction, deploy *constructionContractDeploy) ([]*types.Operation, objectsMap, error) {
+	operations := make([]*types.Operation, 0)
+	if isNonZeroAmount(tx.Value) {
+		operations = append(operations, service.createNativeTransferOperations(tx.Sender, tx.Receiver, tx.Value)...)
+	}
+
+	contractOperation, err := service.createContractDeployOperation(tx.Sender, deploy)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	operations = append(operations, contractOperation)
+	operations = service.appendRelayerFeeOperation(tx, operations)
+	indexOperations(operations)
+
+	return operations, contractOperation.Metadata, nil
+}
+
+func (service *constructionService) createOperationsFromPreparedDelegation(tx *data.Transaction, delegation *constructionDelegation) ([]*types.Operation, objectsMap, error) {
+	operations := make([]*types.Operation, 0)
+	if isNonZeroAmount(tx.Value) {
+		operations = append(operations, service.createNativeTransferOperations(tx.Sender, tx.Receiver, tx.Value)...)
+	}
+
+	delegationOperation, err := service.createDelegationOperation(tx.Sender, tx.Receiver, delegation)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	operations = append(operations, delegationOperation)
+	operations = service.appendRelayerFeeOperation(tx, operations)
+	indexOperations(operations)
+
+	return operations, delegationOperation.Metadata, nil
+}
+
+func (service *constructionService) isContractAddress(address string) bool {
+	pubKey, err := service.provider.ConvertAddressToPubKey(address)
+	if err != nil {
+		return false
+	}
+
+	return !service.extension.isUserPubKey(pubKey)
+}
+
+func (service *constructionService) createNativeTransferOperations(sender string, receiver string, value string) []*types.Operation {
+	return []*types.Operation{
+		{
+			Type:    opTransfer,
+			Account: addressToAccountIdentifier(sender),
+			Amount:  service.extension.valueToNativeAmount("-" + value),
+		},
+		{
+			Type:    opTransfer,
+			Account: addressToAccountIdentifier(receiver),
+			Amount:  service.extension.valueToNativeAmount(value),
+		},
+	}
+}
+
+func (service *constructionService) appendRelayerFeeOperation(tx *data.Transaction, operations []*types.Operation) []*types.Operation {
+	if len(tx.RelayerAddr) > 0 {
+		// For relayed V3 transactions, the fee is paid by the relayer.
+		fee := service.computeFeeOfPreparedTx(tx)
+
+		operations = append(operations, &types.Operation{
+			Type:    opFee,
+			Account: addressToAccountIdentifier(tx.RelayerAddr),
+			Amount:  service.extension.valueToNativeAmount("-" + fee.String()),
+		})
+	}
+
+	return operations
 }
 
 // parseCustomCurrencyTransfer parses a single ESDT transfer.
@@ -309,6 +732,39 @@ func parseCustomCurrencyTransfer(txData string) (string, string, error) {
 	return string(tokenIdentifierBytes), amount, nil
 }
 
+// parseNonFungibleCustomCurrencyTransfer parses a single ESDTNFTTransfer (SFT, NFT or MetaESDT transfer).
+// It returns the extended token identifier (which includes the nonce), the amount and the public key of the actual receiver.
+func parseNonFungibleCustomCurrencyTransfer(txData string) (string, string, []byte, error) {
+	parts := strings.Split(txData, argumentsSeparator)
+
+	if len(parts) != 5 {
+		return "", "", nil, errors.New("cannot parse data of non-fungible custom currency transfer")
+	}
+
+	baseIdentifierBytes, err := hex.DecodeString(parts[1])
+	if err != nil {
+		return "", "", nil, errors.New("cannot decode custom token identifier")
+	}
+
+	nonce, err := hexToNonce(parts[2])
+	if err != nil || nonce == 0 {
+		return "", "", nil, errors.New("cannot decode custom token nonce")
+	}
+
+	amount, err := hexToAmount(parts[3])
+	if err != nil {
+		return "", "", nil, errors.New("cannot decode custom token amount")
+	}
+
+	receiverPubKey, err := hex.DecodeString(parts[4])
+	if err != nil {
+		return "", "", nil, errors.New("cannot decode receiver of custom token")
+	}
+
+	tokenIdentifier := fmt.Sprintf("%s-%s", string(baseIdentifierBytes), nonceToHex(nonce))
+	return tokenIdentifier, amount, receiverPubKey, nil
+}
+
 func getTxFromRequest(txString string) (*data.Transaction, error) {
 	txBytes := []byte(txString)
 
@@ -331,11 +787,20 @@ func (service *constructionService) ConstructionCombine(
 		return nil, service.errFactory.newErrWithOriginal(ErrMalformedValue, err)
 	}
 
-	if len(request.Signatures) != 1 {
-		return nil, service.errFactory.newErr(ErrInvalidInputParam)
+	signingBytes, err := computeSigningBytes(tx)
+	if err != nil {
+		return nil, service.errFactory.newErrWithOriginal(ErrMalformedValue, err)
 	}
 
-	tx.Signature = hex.EncodeToString(request.Signatures[0].Bytes)
+	err = applySignaturesOnTransaction(tx, request.Signatures)
+	if err != nil {
+		return nil, service.newErrOfSignatures(ErrInvalidInputParam, err)
+	}
+
+	err = service.verifySignatures(tx, signingBytes, request.Signatures)
+	if err != nil {
+		return nil, service.newErrOfSignatures(ErrConstruction, err)
+	}
 
 	signedTxBytes, err := json.Marshal(tx)
 	if err != nil {
@@ -394,23 +859,13 @@ func (service *constructionService) ConstructionSubmit(
 ) (*types.TransactionIdentifierResponse, *types.Error) {
 	log.Debug("constructionService.ConstructionSubmit()", "transaction", request.SignedTransaction)
 
-	if service.provider.IsOffline() {
-		return nil, service.errFactory.newErr(ErrOfflineMode)
-	}
-
-	tx, err := getTxFromRequest(request.SignedTransaction)
-	if err != nil {
-		return nil, service.errFactory.newErrWithOriginal(ErrMalformedValue, err)
-	}
-
-	txHash, err := service.provider.SendTransaction(tx)
-	if err != nil {
-		return nil, service.errFactory.newErrWithOriginal(ErrUnableToSubmitTransaction, err)
-	}
+	return service.submitter.submit(request.SignedTransaction)
+}
 
+func newTransactionIdentifierResponse(hash string) *types.TransactionIdentifierResponse {
 	return &types.TransactionIdentifierResponse{
 		TransactionIdentifier: &types.TransactionIdentifier{
-			Hash: txHash,
+			Hash: hash,
 		},
-	}, nil
+	}
 }
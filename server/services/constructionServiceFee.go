@@ -1,29 +1,167 @@
 package services
 
 import (
+	"errors"
+	"fmt"
+	"math"
 	"math/big"
 
 	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-proxy-go/data"
+	"github.com/multiversx/mx-chain-rosetta/server/provider"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
 )
 
 func (service *constructionService) computeFeeComponents(options *constructionOptions, computedData []byte) (*big.Int, uint64, uint64, *types.Error) {
-	networkConfig := service.provider.GetNetworkConfig()
-	minGasPrice := networkConfig.MinGasPrice
-	gasPriceModifier := networkConfig.GasPriceModifier
+	isGuarded := len(options.Guardian) > 0
+	isRelayed := len(options.Relayer) > 0
+	movementGasLimit := service.computeMovementGasLimit(computedData, isGuarded, isRelayed)
+	executionGasLimit := service.estimateExecutionGasLimit(options)
 
-	isForNativeCurrency := service.extension.isNativeCurrencySymbol(options.CurrencySymbol)
-	isForCustomCurrency := !isForNativeCurrency
+	return service.computeFeeComponentsGivenEstimation(options, movementGasLimit, executionGasLimit)
+}
+
+// computeFeeComponentsOfTransaction computes the fee components of a transaction, and reports the method used for estimating the gas limit:
+//   - "provided": the gas limit is provided by the caller (it is only checked against the static estimation, or against the movement gas, for contract interactions)
+//   - "observer": the gas limit is estimated by the observer (by simulating the transaction), then increased by a safety margin
+//   - "static": the gas limit is estimated by a static formula (movement gas, plus a flat execution gas for custom transfers)
+//   - "configuration": the gas limit is taken from configuration (for delegation transactions, per delegation function)
+//
+// Contract interactions are always estimated through the observer (unless the caller provides a gas limit).
+// Otherwise, the observer is used only if configured so, and only when online.
+func (service *constructionService) computeFeeComponentsOfTransaction(options *constructionOptions, metadata *constructionMetadata) (*big.Int, uint64, uint64, string, *types.Error) {
+	if options.GasLimit > 0 {
+		fee, gasLimit, gasPrice, errTyped := service.computeFeeComponentsGivenGasLimit(options, metadata.Data)
+		return fee, gasLimit, gasPrice, gasEstimationMethodProvided, errTyped
+	}
+
+	if options.isDelegation() {
+		gasLimit := service.getGasLimitOfDelegation(options.Delegation.Function)
+		fee, gasLimit, gasPrice, errTyped := service.computeFeeComponentsGivenTotalGasLimit(options, metadata.Data, gasLimit)
+		return fee, gasLimit, gasPrice, gasEstimationMethodConfiguration, errTyped
+	}
+
+	if service.shouldEstimateGasWithObserver(options) {
+		fee, gasLimit, gasPrice, errTyped := service.computeFeeComponentsThroughObserver(options, metadata)
+		return fee, gasLimit, gasPrice, gasEstimationMethodObserver, errTyped
+	}
+
+	if options.isContractInteraction() {
+		err := errors.New("the gas limit of contract interactions cannot be estimated offline (option 'gasLimit' is required)")
+		return nil, 0, 0, "", service.errFactory.newErrWithOriginal(ErrUnableToEstimateGasLimit, err)
+	}
+
+	fee, gasLimit, gasPrice, errTyped := service.computeFeeComponents(options, metadata.Data)
+	return fee, gasLimit, gasPrice, gasEstimationMethodStatic, errTyped
+}
+
+func (service *constructionService) shouldEstimateGasWithObserver(options *constructionOptions) bool {
+	if service.provider.IsOffline() {
+		return false
+	}
+
+	return options.isContractInteraction() || service.provider.GetNetworkConfig().ShouldEstimateGasWithObserver
+}
+
+// computeFeeComponentsGivenGasLimit computes the fee components, given a gas limit provided by the caller.
+// For contract interactions (and delegation transactions), the whole gas limit (except for the movement gas) is considered to be consumed by the execution.
+func (service *constructionService) computeFeeComponentsGivenGasLimit(options *constructionOptions, computedData []byte) (*big.Int, uint64, uint64, *types.Error) {
+	if !options.isContractInteraction() && !options.isDelegation() {
+		return service.computeFeeComponents(options, computedData)
+	}
+
+	isGuarded := len(options.Guardian) > 0
+	isRelayed := len(options.Relayer) > 0
+	movementGasLimit := service.computeMovementGasLimit(computedData, isGuarded, isRelayed)
+
+	if options.GasLimit < movementGasLimit {
+		return nil, 0, 0, service.errFactory.newErr(ErrInsufficientGasLimit)
+	}
+
+	return service.computeFeeComponentsGivenEstimation(options, movementGasLimit, options.GasLimit-movementGasLimit)
+}
+
+// computeFeeComponentsThroughObserver computes the fee components given the gas limit estimated by the observer (increased by the safety margin).
+func (service *constructionService) computeFeeComponentsThroughObserver(options *constructionOptions, metadata *constructionMetadata) (*big.Int, uint64, uint64, *types.Error) {
+	simulatedGasLimit, err := service.simulateGasLimit(metadata)
+	if err != nil {
+		return nil, 0, 0, service.errFactory.newErrWithOriginal(ErrUnableToEstimateGasLimit, err)
+	}
+
+	simulatedGasLimit = service.applyGasEstimationSafetyMargin(simulatedGasLimit)
+
+	// The simulated transaction is guarded and / or relayed (same as the actual one), thus the extra gas is already accounted for.
+	isGuarded := len(options.Guardian) > 0
+	isRelayed := len(options.Relayer) > 0
+	movementGasLimit := service.computeMovementGasLimit(metadata.Data, isGuarded, isRelayed)
 
-	movementGasLimit := networkConfig.MinGasLimit + networkConfig.GasPerDataByte*uint64(len(computedData))
 	executionGasLimit := uint64(0)
-	if isForCustomCurrency {
-		executionGasLimit = networkConfig.GasLimitCustomTransfer
+	if simulatedGasLimit > movementGasLimit {
+		executionGasLimit = simulatedGasLimit - movementGasLimit
 	}
 
+	return service.computeFeeComponentsGivenEstimation(options, movementGasLimit, executionGasLimit)
+}
+
+// computeFeeComponentsGivenTotalGasLimit computes the fee components, given the total gas limit of a transaction that is neither guarded, nor relayed
+// (e.g. as configured). The extra gas for guarded or relayed transactions (if any) is added on top of it, as part of the movement gas.
+func (service *constructionService) computeFeeComponentsGivenTotalGasLimit(options *constructionOptions, computedData []byte, totalGasLimit uint64) (*big.Int, uint64, uint64, *types.Error) {
+	isGuarded := len(options.Guardian) > 0
+	isRelayed := len(options.Relayer) > 0
+	movementGasLimit := service.computeMovementGasLimit(computedData, isGuarded, isRelayed)
+
+	baseMovementGasLimit := service.computeMovementGasLimit(computedData, false, false)
+	executionGasLimit := uint64(0)
+	if totalGasLimit > baseMovementGasLimit {
+		executionGasLimit = totalGasLimit - baseMovementGasLimit
+	}
+
+	return service.computeFeeComponentsGivenEstimation(options, movementGasLimit, executionGasLimit)
+}
+
+func (service *constructionService) applyGasEstimationSafetyMargin(gasLimit uint64) uint64 {
+	safetyMargin := service.provider.GetNetworkConfig().GasEstimationSafetyMargin
+	if safetyMargin <= 0 {
+		return gasLimit
+	}
+
+	return gasLimit + uint64(math.Ceil(float64(gasLimit)*safetyMargin))
+}
+
+// simulateGasLimit asks the observer for the gas units consumed by the (unsigned) transaction.
+// The simulated transaction is built as the actual one: guardian, relayer, version and options included.
+func (service *constructionService) simulateGasLimit(metadata *constructionMetadata) (uint64, error) {
+	tx := &data.Transaction{
+		Sender:       metadata.Sender,
+		Receiver:     metadata.Receiver,
+		Nonce:        metadata.Nonce,
+		Value:        metadata.Amount,
+		GasPrice:     service.provider.GetNetworkConfig().MinGasPrice,
+		Data:         metadata.Data,
+		ChainID:      metadata.ChainID,
+		Version:      uint32(metadata.Version),
+		Options:      metadata.Options,
+		GuardianAddr: metadata.Guardian,
+		RelayerAddr:  metadata.Relayer,
+	}
+
+	cost, err := service.provider.ComputeTransactionCost(tx)
+	if err != nil {
+		return 0, err
+	}
+
+	return cost.GasUnits, nil
+}
+
+func (service *constructionService) computeFeeComponentsGivenEstimation(options *constructionOptions, movementGasLimit uint64, executionGasLimit uint64) (*big.Int, uint64, uint64, *types.Error) {
+	networkConfig := service.provider.GetNetworkConfig()
+	minGasPrice := networkConfig.MinGasPrice
+	gasPriceModifier := networkConfig.GasPriceModifier
+
 	estimatedGasLimit := movementGasLimit + executionGasLimit
 
 	gasLimit := options.coalesceGasLimit(estimatedGasLimit)
-	gasPrice := options.coalesceGasPrice(minGasPrice)
+	gasPrice := options.applyFeeMultiplier(options.coalesceGasPrice(minGasPrice))
 
 	if gasLimit < estimatedGasLimit {
 		return nil, 0, 0, service.errFactory.newErr(ErrInsufficientGasLimit)
@@ -33,9 +171,100 @@ func (service *constructionService) computeFeeComponents(options *constructionOp
 	}
 
 	fee := computeFee(movementGasLimit, executionGasLimit, gasPrice, gasPriceModifier)
+
+	// The caller's "max_fee" must hold even if the whole gas limit is consumed (which is the worst case).
+	maxPossibleFee := computeFee(movementGasLimit, gasLimit-movementGasLimit, gasPrice, gasPriceModifier)
+	errTyped := service.checkMaxFee(options, maxPossibleFee)
+	if errTyped != nil {
+		return nil, 0, 0, errTyped
+	}
+
 	return fee, gasLimit, gasPrice, nil
 }
 
+// checkMaxFee checks the (maximum possible) fee against the "max_fee" provided by the caller (if any).
+func (service *constructionService) checkMaxFee(options *constructionOptions, fee *big.Int) *types.Error {
+	if len(options.MaxFee) == 0 {
+		return nil
+	}
+
+	maxFee, ok := big.NewInt(0).SetString(options.MaxFee, 10)
+	if !ok {
+		return service.errFactory.newErrWithOriginal(ErrConstruction, fmt.Errorf("invalid option 'maxFee': %s", options.MaxFee))
+	}
+
+	if fee.Cmp(maxFee) > 0 {
+		err := fmt.Errorf("fee %s exceeds max_fee %s", fee.String(), maxFee.String())
+		return service.errFactory.newErrWithDetails(ErrMaxFeeExceeded, err, map[string]interface{}{
+			"fee":    fee.String(),
+			"maxFee": maxFee.String(),
+		})
+	}
+
+	return nil
+}
+
+// computeFeeOfPreparedTx computes the fee of a prepared transaction, considering that the whole gas limit is consumed.
+func (service *constructionService) computeFeeOfPreparedTx(tx *data.Transaction) *big.Int {
+	gasPriceModifier := service.provider.GetNetworkConfig().GasPriceModifier
+
+	isGuarded := len(tx.GuardianAddr) > 0
+	isRelayed := len(tx.RelayerAddr) > 0
+	movementGasLimit := service.computeMovementGasLimit(tx.Data, isGuarded, isRelayed)
+
+	if tx.GasLimit <= movementGasLimit {
+		return computeFee(tx.GasLimit, 0, tx.GasPrice, gasPriceModifier)
+	}
+
+	executionGasLimit := tx.GasLimit - movementGasLimit
+	return computeFee(movementGasLimit, executionGasLimit, tx.GasPrice, gasPriceModifier)
+}
+
+func (service *constructionService) computeMovementGasLimit(data []byte, isGuarded bool, isRelayed bool) uint64 {
+	networkConfig := service.provider.GetNetworkConfig()
+	movementGasLimit := networkConfig.MinGasLimit + networkConfig.GasPerDataByte*uint64(len(data))
+
+	if isGuarded {
+		movementGasLimit += networkConfig.ExtraGasLimitGuardedTx
+	}
+	if isRelayed {
+		movementGasLimit += networkConfig.ExtraGasLimitRelayedTxV3
+	}
+
+	return movementGasLimit
+}
+
+func (service *constructionService) estimateExecutionGasLimit(options *constructionOptions) uint64 {
+	networkConfig := service.provider.GetNetworkConfig()
+
+	if options.isMultiTransfer() {
+		// Gas is estimated per transfer (including the native currency, if present).
+		gasLimit := uint64(0)
+		for _, transfer := range options.Transfers {
+			gasLimit += getGasLimitOfTransfer(networkConfig, transfer.CurrencySymbol)
+		}
+
+		return gasLimit
+	}
+
+	isForNativeCurrency := service.extension.isNativeCurrencySymbol(options.CurrencySymbol)
+	if isForNativeCurrency {
+		return 0
+	}
+
+	return getGasLimitOfTransfer(networkConfig, options.CurrencySymbol)
+}
+
+// getGasLimitOfTransfer returns the (configured) execution gas of a token transfer: tokens with a nonce (SFTs, NFTs, MetaESDTs) are more expensive to transfer than fungible ones.
+func getGasLimitOfTransfer(networkConfig *resources.NetworkConfig, currencySymbol string) uint64 {
+	_, nonce, err := provider.ParseTokenIdentifier(currencySymbol)
+	if err == nil && nonce > 0 {
+		return networkConfig.GasLimitNFTTransfer
+	}
+
+	return networkConfig.GasLimitCustomTransfer
+}
+
 func computeFee(movementGasLimit uint64, executionGasLimit uint64, gasPrice uint64, gasPriceModifier float64) *big.Int {
 	movementFee := multiplyUint64(movementGasLimit, gasPrice)
 	executionGasPrice := uint64(float64(gasPrice) * gasPriceModifier)
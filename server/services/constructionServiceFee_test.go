@@ -1,9 +1,13 @@
 package services
 
 import (
+	"encoding/hex"
+	"errors"
 	"math/big"
 	"testing"
 
+	"github.com/multiversx/mx-chain-proxy-go/data"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
 	"github.com/multiversx/mx-chain-rosetta/testscommon"
 	"github.com/stretchr/testify/require"
 )
@@ -12,7 +16,7 @@ func TestConstructionService_ComputeFeeComponents_ForNativeTransfers(t *testing.
 	t.Parallel()
 
 	networkProvider := testscommon.NewNetworkProviderMock()
-	service := NewConstructionService(networkProvider).(*constructionService)
+	service := createConstructionService(networkProvider)
 
 	t.Run("native transfer", func(t *testing.T) {
 		fee, gasLimit, gasPrice, err := service.computeFeeComponents(&constructionOptions{
@@ -27,6 +31,18 @@ func TestConstructionService_ComputeFeeComponents_ForNativeTransfers(t *testing.
 		require.Equal(t, uint64(1000000000), gasPrice)
 	})
 
+	t.Run("native transfer, guarded", func(t *testing.T) {
+		fee, gasLimit, gasPrice, err := service.computeFeeComponents(&constructionOptions{
+			CurrencySymbol: "XeGLD",
+			Guardian:       testscommon.TestAddressCarol,
+		}, []byte{})
+
+		require.Nil(t, err)
+		require.Equal(t, "100000000000000", fee.String())
+		require.Equal(t, uint64(100000), gasLimit)
+		require.Equal(t, uint64(1000000000), gasPrice)
+	})
+
 	t.Run("native transfer, with computed data", func(t *testing.T) {
 		fee, gasLimit, gasPrice, err := service.computeFeeComponents(&constructionOptions{
 			GasLimit:       53000,
@@ -99,7 +115,8 @@ func TestConstructionService_ComputeFeeComponents_ForCustomTokenTransfers(t *tes
 	networkProvider := testscommon.NewNetworkProviderMock()
 	networkProvider.MockNetworkConfig.GasPriceModifier = 0.01
 	networkProvider.MockNetworkConfig.GasLimitCustomTransfer = 200000
-	service := NewConstructionService(networkProvider).(*constructionService)
+	networkProvider.MockNetworkConfig.GasLimitNFTTransfer = 1000000
+	service := createConstructionService(networkProvider)
 
 	t.Run("custom transfer (without explicit gas limit)", func(t *testing.T) {
 		fee, gasLimit, gasPrice, err := service.computeFeeComponents(&constructionOptions{
@@ -138,6 +155,188 @@ func TestConstructionService_ComputeFeeComponents_ForCustomTokenTransfers(t *tes
 		require.Equal(t, uint64(10000000), gasLimit)
 		require.Equal(t, uint64(1000000000), gasPrice)
 	})
+
+	t.Run("nft transfer (without explicit gas limit)", func(t *testing.T) {
+		data := []byte("ESDTNFTTransfer@4e46542d616263646566@0a@01@" + hex.EncodeToString(testscommon.TestPubKeyBob))
+
+		fee, gasLimit, gasPrice, err := service.computeFeeComponents(&constructionOptions{
+			GasPrice:       1000000000,
+			CurrencySymbol: "NFT-abcdef-0a",
+		}, data)
+
+		require.Nil(t, err)
+		// (50000 + 1500 * 107) * 1000000000 + 1000000 * 10000000
+		require.Equal(t, "220500000000000", fee.String())
+		require.Equal(t, uint64(1210500), gasLimit)
+		require.Equal(t, uint64(1000000000), gasPrice)
+	})
+
+	t.Run("multi-token transfer, with an nft (without explicit gas limit)", func(t *testing.T) {
+		fee, gasLimit, _, err := service.computeFeeComponents(&constructionOptions{
+			GasPrice: 1000000000,
+			Transfers: []*constructionTransfer{
+				{Amount: "1", CurrencySymbol: "TEST-abcdef"},
+				{Amount: "1", CurrencySymbol: "NFT-abcdef-0a"},
+			},
+		}, []byte{})
+
+		require.Nil(t, err)
+		// 50000 * 1000000000 + (200000 + 1000000) * 10000000
+		require.Equal(t, "62000000000000", fee.String())
+		require.Equal(t, uint64(1250000), gasLimit)
+	})
+}
+
+func TestConstructionService_ComputeFeeComponentsOfTransaction(t *testing.T) {
+	t.Parallel()
+
+	metadata := &constructionMetadata{
+		Sender:   testscommon.TestAddressAlice,
+		Receiver: testscommon.TestAddressBob,
+		Amount:   "0",
+		Data:     []byte("ESDTTransfer@544553542d616263646566@64"),
+	}
+
+	options := &constructionOptions{
+		GasPrice:       1000000000,
+		CurrencySymbol: "TEST-abcdef",
+	}
+
+	t.Run("static", func(t *testing.T) {
+		t.Parallel()
+
+		networkProvider := testscommon.NewNetworkProviderMock()
+		networkProvider.MockTransactionCostGasUnits = 400000
+		service := createConstructionService(networkProvider)
+
+		fee, gasLimit, _, method, err := service.computeFeeComponentsOfTransaction(options, metadata)
+		require.Nil(t, err)
+		require.Equal(t, "109000000000000", fee.String())
+		require.Equal(t, uint64(307000), gasLimit)
+		require.Equal(t, gasEstimationMethodStatic, method)
+	})
+
+	t.Run("observer, with safety margin", func(t *testing.T) {
+		t.Parallel()
+
+		networkProvider := testscommon.NewNetworkProviderMock()
+		networkProvider.MockNetworkConfig.ShouldEstimateGasWithObserver = true
+		networkProvider.MockNetworkConfig.GasEstimationSafetyMargin = 0.1
+		networkProvider.MockTransactionCostGasUnits = 400000
+		service := createConstructionService(networkProvider)
+
+		fee, gasLimit, _, method, err := service.computeFeeComponentsOfTransaction(options, metadata)
+		require.Nil(t, err)
+		// 107000 * 1000000000 + (440000 - 107000) * 10000000
+		require.Equal(t, "110330000000000", fee.String())
+		require.Equal(t, uint64(440000), gasLimit)
+		require.Equal(t, gasEstimationMethodObserver, method)
+	})
+
+	t.Run("observer, guarded and relayed", func(t *testing.T) {
+		t.Parallel()
+
+		guardedAndRelayedMetadata := *metadata
+		guardedAndRelayedMetadata.setGuardian(testscommon.TestAddressCarol)
+		guardedAndRelayedMetadata.setRelayer(testscommon.TestAddressBob)
+
+		guardedAndRelayedOptions := *options
+		guardedAndRelayedOptions.Guardian = testscommon.TestAddressCarol
+		guardedAndRelayedOptions.Relayer = testscommon.TestAddressBob
+
+		networkProvider := testscommon.NewNetworkProviderMock()
+		networkProvider.MockNetworkConfig.ShouldEstimateGasWithObserver = true
+		networkProvider.ComputeTransactionCostCalled = func(tx *data.Transaction) (*resources.TransactionCost, error) {
+			require.Equal(t, testscommon.TestAddressCarol, tx.GuardianAddr)
+			require.Equal(t, testscommon.TestAddressBob, tx.RelayerAddr)
+			require.Equal(t, uint32(transactionVersionWithOptions), tx.Version)
+			require.Equal(t, uint32(transactionOptionGuarded), tx.Options)
+
+			// The extra gas for guarded and relayed transactions is included.
+			return &resources.TransactionCost{GasUnits: 500000}, nil
+		}
+		service := createConstructionService(networkProvider)
+
+		fee, gasLimit, _, method, err := service.computeFeeComponentsOfTransaction(&guardedAndRelayedOptions, &guardedAndRelayedMetadata)
+		require.Nil(t, err)
+		// (107000 + 50000 + 50000) * 1000000000 + (500000 - 207000) * 10000000
+		require.Equal(t, "209930000000000", fee.String())
+		require.Equal(t, uint64(500000), gasLimit)
+		require.Equal(t, gasEstimationMethodObserver, method)
+	})
+
+	t.Run("observer, with failed simulation", func(t *testing.T) {
+		t.Parallel()
+
+		networkProvider := testscommon.NewNetworkProviderMock()
+		networkProvider.MockNetworkConfig.ShouldEstimateGasWithObserver = true
+		networkProvider.MockNextError = errors.New("insufficient funds")
+		service := createConstructionService(networkProvider)
+
+		_, _, _, _, err := service.computeFeeComponentsOfTransaction(options, metadata)
+		require.Equal(t, int32(ErrUnableToEstimateGasLimit), err.Code)
+	})
+
+	t.Run("observer, but offline (fallback to static)", func(t *testing.T) {
+		t.Parallel()
+
+		networkProvider := testscommon.NewNetworkProviderMock()
+		networkProvider.MockIsOffline = true
+		networkProvider.MockNetworkConfig.ShouldEstimateGasWithObserver = true
+		networkProvider.MockTransactionCostGasUnits = 400000
+		service := createConstructionService(networkProvider)
+
+		fee, gasLimit, _, method, err := service.computeFeeComponentsOfTransaction(options, metadata)
+		require.Nil(t, err)
+		require.Equal(t, "109000000000000", fee.String())
+		require.Equal(t, uint64(307000), gasLimit)
+		require.Equal(t, gasEstimationMethodStatic, method)
+	})
+
+	t.Run("provided", func(t *testing.T) {
+		t.Parallel()
+
+		networkProvider := testscommon.NewNetworkProviderMock()
+		networkProvider.MockNetworkConfig.ShouldEstimateGasWithObserver = true
+		service := createConstructionService(networkProvider)
+
+		fee, gasLimit, _, method, err := service.computeFeeComponentsOfTransaction(&constructionOptions{
+			GasLimit:       500000,
+			GasPrice:       1000000000,
+			CurrencySymbol: "TEST-abcdef",
+		}, metadata)
+		require.Nil(t, err)
+		require.Equal(t, "109000000000000", fee.String())
+		require.Equal(t, uint64(500000), gasLimit)
+		require.Equal(t, gasEstimationMethodProvided, method)
+	})
+
+	t.Run("contract call, offline, without gas limit", func(t *testing.T) {
+		t.Parallel()
+
+		networkProvider := testscommon.NewNetworkProviderMock()
+		networkProvider.MockIsOffline = true
+		service := createConstructionService(networkProvider)
+
+		_, _, _, _, err := service.computeFeeComponentsOfTransaction(&constructionOptions{
+			ContractCall: &constructionContractCall{Function: "add"},
+		}, metadata)
+		require.Equal(t, int32(ErrUnableToEstimateGasLimit), err.Code)
+	})
+}
+
+func TestConstructionService_ApplyGasEstimationSafetyMargin(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	service := createConstructionService(networkProvider)
+
+	networkProvider.MockNetworkConfig.GasEstimationSafetyMargin = 0
+	require.Equal(t, uint64(100000), service.applyGasEstimationSafetyMargin(100000))
+
+	networkProvider.MockNetworkConfig.GasEstimationSafetyMargin = 0.1
+	require.Equal(t, uint64(110000), service.applyGasEstimationSafetyMargin(100000))
+	require.Equal(t, uint64(13), service.applyGasEstimationSafetyMargin(11))
 }
 
 func TestComputeFee(t *testing.T) {
@@ -149,3 +348,92 @@ func TestComputeFee(t *testing.T) {
 	require.Equal(t, big.NewInt(70000000000000), computeFee(70000, 0, 1000000000, 0.01))
 	require.Equal(t, big.NewInt(60000000000000), computeFee(50000, 1000000, 1000000000, 0.01))
 }
+
+func TestConstructionService_ComputeFeeComponents_WithFeeMultiplierAndMaxFee(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	service := createConstructionService(networkProvider)
+
+	t.Run("multiplier applied on the minimum gas price", func(t *testing.T) {
+		fee, gasLimit, gasPrice, err := service.computeFeeComponents(&constructionOptions{
+			CurrencySymbol: "XeGLD",
+			FeeMultiplier:  1.5,
+		}, []byte{})
+
+		require.Nil(t, err)
+		require.Equal(t, "75000000000000", fee.String())
+		require.Equal(t, uint64(50000), gasLimit)
+		require.Equal(t, uint64(1500000000), gasPrice)
+	})
+
+	t.Run("multiplier applied on the provided gas price, with the execution gas priced by the modifier", func(t *testing.T) {
+		fee, gasLimit, gasPrice, err := service.computeFeeComponents(&constructionOptions{
+			GasPrice:       2000000000,
+			CurrencySymbol: "TEST-abcdef",
+			FeeMultiplier:  1.5,
+		}, []byte("ESDTTransfer@544553542d616263646566@64"))
+
+		require.Nil(t, err)
+		// 107000 * 3000000000 + 200000 * 30000000
+		require.Equal(t, "327000000000000", fee.String())
+		require.Equal(t, uint64(307000), gasLimit)
+		require.Equal(t, uint64(3000000000), gasPrice)
+	})
+
+	t.Run("multiplier bringing the gas price below the minimum", func(t *testing.T) {
+		_, _, _, err := service.computeFeeComponents(&constructionOptions{
+			CurrencySymbol: "XeGLD",
+			FeeMultiplier:  0.5,
+		}, []byte{})
+
+		require.Equal(t, int32(ErrGasPriceTooLow), err.Code)
+	})
+
+	t.Run("fee within max fee", func(t *testing.T) {
+		fee, _, _, err := service.computeFeeComponents(&constructionOptions{
+			CurrencySymbol: "XeGLD",
+			FeeMultiplier:  1.5,
+			MaxFee:         "75000000000000",
+		}, []byte{})
+
+		require.Nil(t, err)
+		require.Equal(t, "75000000000000", fee.String())
+	})
+
+	t.Run("fee exceeding max fee, when the whole (provided) gas limit is consumed", func(t *testing.T) {
+		_, _, _, err := service.computeFeeComponents(&constructionOptions{
+			GasLimit:       70000,
+			CurrencySymbol: "XeGLD",
+			MaxFee:         "50000000000000",
+		}, []byte{})
+
+		// 50000 * 1000000000 + 20000 * 10000000
+		require.Equal(t, int32(ErrMaxFeeExceeded), err.Code)
+		require.Equal(t, "50200000000000", err.Details["fee"])
+
+		fee, _, _, err := service.computeFeeComponents(&constructionOptions{
+			GasLimit:       70000,
+			CurrencySymbol: "XeGLD",
+			MaxFee:         "50200000000000",
+		}, []byte{})
+
+		require.Nil(t, err)
+		require.Equal(t, "50000000000000", fee.String())
+	})
+
+	t.Run("fee exceeding max fee", func(t *testing.T) {
+		fee, gasLimit, gasPrice, err := service.computeFeeComponents(&constructionOptions{
+			CurrencySymbol: "XeGLD",
+			FeeMultiplier:  1.5,
+			MaxFee:         "74999999999999",
+		}, []byte{})
+
+		require.Equal(t, int32(ErrMaxFeeExceeded), err.Code)
+		require.Equal(t, "75000000000000", err.Details["fee"])
+		require.Equal(t, "74999999999999", err.Details["maxFee"])
+		require.Nil(t, fee)
+		require.Equal(t, uint64(0), gasLimit)
+		require.Equal(t, uint64(0), gasPrice)
+	})
+}
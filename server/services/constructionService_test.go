@@ -2,9 +2,16 @@ package services
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-core-go/data/api"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-core-go/hashing/keccak"
 	"github.com/multiversx/mx-chain-proxy-go/data"
 	"github.com/multiversx/mx-chain-rosetta/server/resources"
 	"github.com/multiversx/mx-chain-rosetta/testscommon"
@@ -15,8 +22,9 @@ func TestConstructionService_ConstructionPreprocess(t *testing.T) {
 	t.Parallel()
 
 	networkProvider := testscommon.NewNetworkProviderMock()
+	networkProvider.MockCustomCurrencies = []resources.Currency{{Symbol: "TEST-abcdef"}, {Symbol: "NFT-abcdef"}}
 	extension := newNetworkProviderExtension(networkProvider)
-	service := NewConstructionService(networkProvider)
+	service := createConstructionService(networkProvider)
 
 	t.Run("with minimal (empty) 'metadata', 'options' being inferred from 'operations'", func(t *testing.T) {
 		t.Parallel()
@@ -166,6 +174,7 @@ func TestConstructionService_ConstructionPreprocess(t *testing.T) {
 					"gasLimit":       70000,
 					"gasPrice":       1000000000,
 					"data":           []byte("hello"),
+					"guardian":       testscommon.TestAddressCarol,
 				},
 			},
 		)
@@ -180,6 +189,7 @@ func TestConstructionService_ConstructionPreprocess(t *testing.T) {
 			GasLimit:       70000,
 			GasPrice:       1000000000,
 			Data:           []byte("hello"),
+			Guardian:       testscommon.TestAddressCarol,
 		}
 
 		actualOptions := &constructionOptions{}
@@ -188,6 +198,150 @@ func TestConstructionService_ConstructionPreprocess(t *testing.T) {
 		require.Equal(t, expectedOptions, actualOptions)
 	})
 
+	t.Run("with many operations (multi-token transfer)", func(t *testing.T) {
+		t.Parallel()
+
+		operations := []*types.Operation{
+			{
+				OperationIdentifier: indexToOperationIdentifier(0),
+				Type:                opTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressAlice),
+				Amount:              extension.valueToNativeAmount("-1000"),
+			},
+			{
+				OperationIdentifier: indexToOperationIdentifier(1),
+				Type:                opTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressBob),
+				Amount:              extension.valueToNativeAmount("1000"),
+			},
+			{
+				OperationIdentifier: indexToOperationIdentifier(2),
+				Type:                opCustomTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressAlice),
+				Amount:              extension.valueToCustomAmount("-1234", "TEST-abcdef"),
+			},
+			{
+				OperationIdentifier: indexToOperationIdentifier(3),
+				Type:                opCustomTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressBob),
+				Amount:              extension.valueToCustomAmount("1234", "TEST-abcdef"),
+			},
+		}
+
+		response, errTyped := service.ConstructionPreprocess(context.Background(),
+			&types.ConstructionPreprocessRequest{
+				Operations: operations,
+				Metadata:   objectsMap{},
+			},
+		)
+
+		require.Nil(t, errTyped)
+
+		expectedOptions := &constructionOptions{
+			Sender:   testscommon.TestAddressAlice,
+			Receiver: testscommon.TestAddressBob,
+			Transfers: []*constructionTransfer{
+				{Amount: "1000", CurrencySymbol: "XeGLD"},
+				{Amount: "1234", CurrencySymbol: "TEST-abcdef"},
+			},
+		}
+
+		actualOptions := &constructionOptions{}
+		err := fromObjectsMap(response.Options, actualOptions)
+		require.NoError(t, err)
+		require.Equal(t, expectedOptions, actualOptions)
+	})
+
+	t.Run("with many operations (multi-token transfer), with the native currency given as EGLD-000000", func(t *testing.T) {
+		t.Parallel()
+
+		operations := []*types.Operation{
+			{
+				OperationIdentifier: indexToOperationIdentifier(0),
+				Type:                opCustomTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressAlice),
+				Amount:              extension.valueToCustomAmount("-1000", "EGLD-000000"),
+			},
+			{
+				OperationIdentifier: indexToOperationIdentifier(1),
+				Type:                opCustomTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressBob),
+				Amount:              extension.valueToCustomAmount("1000", "EGLD-000000"),
+			},
+			{
+				OperationIdentifier: indexToOperationIdentifier(2),
+				Type:                opCustomTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressAlice),
+				Amount:              extension.valueToCustomAmount("-1234", "TEST-abcdef"),
+			},
+			{
+				OperationIdentifier: indexToOperationIdentifier(3),
+				Type:                opCustomTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressBob),
+				Amount:              extension.valueToCustomAmount("1234", "TEST-abcdef"),
+			},
+		}
+
+		response, errTyped := service.ConstructionPreprocess(context.Background(),
+			&types.ConstructionPreprocessRequest{
+				Operations: operations,
+				Metadata:   objectsMap{},
+			},
+		)
+
+		require.Nil(t, errTyped)
+
+		expectedOptions := &constructionOptions{
+			Sender:   testscommon.TestAddressAlice,
+			Receiver: testscommon.TestAddressBob,
+			Transfers: []*constructionTransfer{
+				{Amount: "1000", CurrencySymbol: "EGLD-000000"},
+				{Amount: "1234", CurrencySymbol: "TEST-abcdef"},
+			},
+		}
+
+		actualOptions := &constructionOptions{}
+		err := fromObjectsMap(response.Options, actualOptions)
+		require.NoError(t, err)
+		require.Equal(t, expectedOptions, actualOptions)
+	})
+
+	t.Run("with many operations (multi-token transfer), but with unbalanced amounts", func(t *testing.T) {
+		t.Parallel()
+
+		operations := []*types.Operation{
+			{
+				OperationIdentifier: indexToOperationIdentifier(0),
+				Type:                opTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressAlice),
+				Amount:              extension.valueToNativeAmount("-1000"),
+			},
+			{
+				OperationIdentifier: indexToOperationIdentifier(1),
+				Type:                opCustomTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressAlice),
+				Amount:              extension.valueToCustomAmount("-1234", "TEST-abcdef"),
+			},
+			{
+				OperationIdentifier: indexToOperationIdentifier(2),
+				Type:                opCustomTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressBob),
+				Amount:              extension.valueToCustomAmount("1234", "TEST-abcdef"),
+			},
+		}
+
+		response, errTyped := service.ConstructionPreprocess(context.Background(),
+			&types.ConstructionPreprocessRequest{
+				Operations: operations,
+				Metadata:   objectsMap{},
+			},
+		)
+
+		require.Equal(t, int32(ErrConstruction), errTyped.Code)
+		require.Contains(t, errTyped.Details["originalError"], "debits and credits do not match for currency: XeGLD")
+		require.Nil(t, response)
+	})
+
 	t.Run("with incomplete 'metadata', without 'operations'", func(t *testing.T) {
 		t.Parallel()
 
@@ -207,12 +361,25 @@ func TestConstructionService_ConstructionPreprocess(t *testing.T) {
 
 func TestConstructionService_ConstructionMetadata(t *testing.T) {
 	networkProvider := testscommon.NewNetworkProviderMock()
+	networkProvider.MockCustomCurrencies = []resources.Currency{{Symbol: "TEST-abcdef"}, {Symbol: "NFT-abcdef"}}
 	networkProvider.MockAccountsByAddress[testscommon.TestAddressAlice] = &resources.Account{
 		Address: testscommon.TestAddressAlice,
 		Nonce:   42,
 	}
 
-	service := NewConstructionService(networkProvider)
+	networkProvider.MockAccountsByAddress[testscommon.TestAddressCarol] = &resources.Account{
+		Address:   testscommon.TestAddressCarol,
+		Nonce:     7,
+		IsGuarded: true,
+	}
+	networkProvider.MockAccountsGuardianData[testscommon.TestAddressCarol] = &api.GuardianData{
+		ActiveGuardian: &api.Guardian{
+			Address: testscommon.TestAddressBob,
+		},
+		Guarded: true,
+	}
+
+	service := createConstructionService(networkProvider)
 
 	t.Run("with native currency, with explicitly providing gas limit and price", func(t *testing.T) {
 		t.Parallel()
@@ -233,15 +400,16 @@ func TestConstructionService_ConstructionMetadata(t *testing.T) {
 		require.Nil(t, errTyped)
 
 		expectedMetadata := &constructionMetadata{
-			Sender:         testscommon.TestAddressAlice,
-			Receiver:       testscommon.TestAddressBob,
-			Nonce:          42,
-			Amount:         "1234",
-			CurrencySymbol: "XeGLD",
-			GasLimit:       100000,
-			GasPrice:       1500000000,
-			ChainID:        "T",
-			Version:        1,
+			Sender:              testscommon.TestAddressAlice,
+			Receiver:            testscommon.TestAddressBob,
+			Nonce:               42,
+			Amount:              "1234",
+			CurrencySymbol:      "XeGLD",
+			GasLimit:            100000,
+			GasPrice:            1500000000,
+			ChainID:             "T",
+			Version:             1,
+			GasEstimationMethod: gasEstimationMethodProvided,
 		}
 
 		actualMetadata := &constructionMetadata{}
@@ -273,16 +441,17 @@ func TestConstructionService_ConstructionMetadata(t *testing.T) {
 		require.Nil(t, errTyped)
 
 		expectedMetadata := &constructionMetadata{
-			Sender:         testscommon.TestAddressAlice,
-			Receiver:       testscommon.TestAddressBob,
-			Nonce:          42,
-			Amount:         "1234",
-			CurrencySymbol: "XeGLD",
-			GasLimit:       70000,
-			GasPrice:       1500000000,
-			Data:           []byte("hello"),
-			ChainID:        "T",
-			Version:        1,
+			Sender:              testscommon.TestAddressAlice,
+			Receiver:            testscommon.TestAddressBob,
+			Nonce:               42,
+			Amount:              "1234",
+			CurrencySymbol:      "XeGLD",
+			GasLimit:            70000,
+			GasPrice:            1500000000,
+			Data:                []byte("hello"),
+			ChainID:             "T",
+			Version:             1,
+			GasEstimationMethod: gasEstimationMethodProvided,
 		}
 
 		actualMetadata := &constructionMetadata{}
@@ -332,16 +501,17 @@ func TestConstructionService_ConstructionMetadata(t *testing.T) {
 		require.Nil(t, errTyped)
 
 		expectedMetadata := &constructionMetadata{
-			Sender:         testscommon.TestAddressAlice,
-			Receiver:       testscommon.TestAddressBob,
-			Nonce:          42,
-			Amount:         "1234",
-			CurrencySymbol: "XeGLD",
-			GasLimit:       57500,
-			GasPrice:       1000000000,
-			Data:           []byte("hello"),
-			ChainID:        "T",
-			Version:        1,
+			Sender:              testscommon.TestAddressAlice,
+			Receiver:            testscommon.TestAddressBob,
+			Nonce:               42,
+			Amount:              "1234",
+			CurrencySymbol:      "XeGLD",
+			GasLimit:            57500,
+			GasPrice:            1000000000,
+			Data:                []byte("hello"),
+			ChainID:             "T",
+			Version:             1,
+			GasEstimationMethod: gasEstimationMethodStatic,
 		}
 
 		actualMetadata := &constructionMetadata{}
@@ -390,16 +560,17 @@ func TestConstructionService_ConstructionMetadata(t *testing.T) {
 		require.Nil(t, errTyped)
 
 		expectedMetadata := &constructionMetadata{
-			Sender:         testscommon.TestAddressAlice,
-			Receiver:       testscommon.TestAddressBob,
-			Nonce:          42,
-			Amount:         "0",
-			CurrencySymbol: "TEST-abcdef",
-			GasLimit:       500000,
-			GasPrice:       1000000000,
-			Data:           []byte("ESDTTransfer@544553542d616263646566@04d2"),
-			ChainID:        "T",
-			Version:        1,
+			Sender:              testscommon.TestAddressAlice,
+			Receiver:            testscommon.TestAddressBob,
+			Nonce:               42,
+			Amount:              "0",
+			CurrencySymbol:      "TEST-abcdef",
+			GasLimit:            500000,
+			GasPrice:            1000000000,
+			Data:                []byte("ESDTTransfer@544553542d616263646566@04d2"),
+			ChainID:             "T",
+			Version:             1,
+			GasEstimationMethod: gasEstimationMethodProvided,
 		}
 
 		actualMetadata := &constructionMetadata{}
@@ -428,16 +599,17 @@ func TestConstructionService_ConstructionMetadata(t *testing.T) {
 		require.Nil(t, errTyped)
 
 		expectedMetadata := &constructionMetadata{
-			Sender:         testscommon.TestAddressAlice,
-			Receiver:       testscommon.TestAddressBob,
-			Nonce:          42,
-			Amount:         "0",
-			CurrencySymbol: "TEST-abcdef",
-			GasLimit:       310000,
-			GasPrice:       1000000000,
-			Data:           []byte("ESDTTransfer@544553542d616263646566@04d2"),
-			ChainID:        "T",
-			Version:        1,
+			Sender:              testscommon.TestAddressAlice,
+			Receiver:            testscommon.TestAddressBob,
+			Nonce:               42,
+			Amount:              "0",
+			CurrencySymbol:      "TEST-abcdef",
+			GasLimit:            310000,
+			GasPrice:            1000000000,
+			Data:                []byte("ESDTTransfer@544553542d616263646566@04d2"),
+			ChainID:             "T",
+			Version:             1,
+			GasEstimationMethod: gasEstimationMethodStatic,
 		}
 
 		actualMetadata := &constructionMetadata{}
@@ -447,90 +619,343 @@ func TestConstructionService_ConstructionMetadata(t *testing.T) {
 		require.Equal(t, "112000000000000", response.SuggestedFee[0].Value)
 		require.Equal(t, expectedMetadata, actualMetadata)
 	})
-}
-
-func TestConstructionService_ConstructionPayloads(t *testing.T) {
-	t.Parallel()
-
-	networkProvider := testscommon.NewNetworkProviderMock()
-	networkProvider.MockAccountsByAddress[testscommon.TestAddressAlice] = &resources.Account{
-		Address: testscommon.TestAddressAlice,
-		Nonce:   42,
-	}
 
-	service := NewConstructionService(networkProvider)
+	t.Run("with non-fungible custom currency, without providing gas limit and price", func(t *testing.T) {
+		t.Parallel()
 
-	response, errTyped := service.ConstructionPayloads(context.Background(),
-		&types.ConstructionPayloadsRequest{
-			Metadata: objectsMap{
-				"sender":         testscommon.TestAddressAlice,
-				"receiver":       testscommon.TestAddressBob,
-				"nonce":          42,
-				"amount":         "1234",
-				"currencySymbol": "XeGLD",
-				"gasLimit":       57500,
-				"gasPrice":       1000000000,
-				"data":           []byte("hello"),
-				"chainID":        "T",
-				"version":        1,
+		response, errTyped := service.ConstructionMetadata(context.Background(),
+			&types.ConstructionMetadataRequest{
+				Options: objectsMap{
+					"receiver":       testscommon.TestAddressBob,
+					"sender":         testscommon.TestAddressAlice,
+					"amount":         "1234",
+					"currencySymbol": "NFT-abcdef-0a",
+				},
 			},
-		},
-	)
+		)
 
-	expectedTxJson := `{"nonce":42,"value":"1234","receiver":"erd1spyavw0956vq68xj8y4tenjpq2wd5a9p2c6j8gsz7ztyrnpxrruqzu66jx","sender":"erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th","gasPrice":1000000000,"gasLimit":57500,"data":"aGVsbG8=","chainID":"T","version":1}`
+		require.Nil(t, errTyped)
 
-	require.Nil(t, errTyped)
-	require.Len(t, response.Payloads, 1)
-	require.Equal(t, expectedTxJson, response.UnsignedTransaction)
-	require.Equal(t, []byte(expectedTxJson), response.Payloads[0].Bytes)
-	require.Equal(t, testscommon.TestAddressAlice, response.Payloads[0].AccountIdentifier.Address)
-	require.Equal(t, types.Ed25519, response.Payloads[0].SignatureType)
-}
+		expectedMetadata := &constructionMetadata{
+			Sender:              testscommon.TestAddressAlice,
+			Receiver:            testscommon.TestAddressAlice,
+			Nonce:               42,
+			Amount:              "0",
+			CurrencySymbol:      "NFT-abcdef-0a",
+			GasLimit:            1213500,
+			GasPrice:            1000000000,
+			Data:                []byte("ESDTNFTTransfer@4e46542d616263646566@0a@04d2@8049d639e5a6980d1cd2392abcce41029cda74a1563523a202f09641cc2618f8"),
+			ChainID:             "T",
+			Version:             1,
+			GasEstimationMethod: gasEstimationMethodStatic,
+		}
 
-func TestConstructionService_ConstructionParse(t *testing.T) {
-	t.Parallel()
+		actualMetadata := &constructionMetadata{}
+		err := fromObjectsMap(response.Metadata, actualMetadata)
+		require.NoError(t, err)
 
-	networkProvider := testscommon.NewNetworkProviderMock()
-	extension := newNetworkProviderExtension(networkProvider)
-	service := NewConstructionService(networkProvider)
+		require.Equal(t, "223500000000000", response.SuggestedFee[0].Value)
+		require.Equal(t, expectedMetadata, actualMetadata)
+	})
 
-	t.Run("native transfer", func(t *testing.T) {
-		notSignedTx := `{"nonce":42,"value":"1234","receiver":"erd1spyavw0956vq68xj8y4tenjpq2wd5a9p2c6j8gsz7ztyrnpxrruqzu66jx","sender":"erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th","gasPrice":1100000000,"gasLimit":57500,"data":"aGVsbG8=","chainID":"T","version":1}`
+	t.Run("with multi-token transfer, with the native currency given as EGLD-000000", func(t *testing.T) {
+		t.Parallel()
 
-		operations := []*types.Operation{
-			{
-				OperationIdentifier: indexToOperationIdentifier(0),
-				Type:                opTransfer,
-				Account:             addressToAccountIdentifier(testscommon.TestAddressAlice),
-				Amount:              extension.valueToNativeAmount("-1234"),
-			},
-			{
-				OperationIdentifier: indexToOperationIdentifier(1),
-				Type:                opTransfer,
-				Account:             addressToAccountIdentifier(testscommon.TestAddressBob),
-				Amount:              extension.valueToNativeAmount("1234"),
+		response, errTyped := service.ConstructionMetadata(context.Background(),
+			&types.ConstructionMetadataRequest{
+				Options: objectsMap{
+					"receiver": testscommon.TestAddressBob,
+					"sender":   testscommon.TestAddressAlice,
+					"transfers": []objectsMap{
+						{"amount": "1000", "currencySymbol": "EGLD-000000"},
+						{"amount": "1234", "currencySymbol": "TEST-abcdef"},
+					},
+				},
 			},
+		)
+
+		require.Nil(t, errTyped)
+
+		expectedMetadata := &constructionMetadata{
+			Sender:              testscommon.TestAddressAlice,
+			Receiver:            testscommon.TestAddressAlice,
+			Nonce:               42,
+			Amount:              "0",
+			GasLimit:            669000,
+			GasPrice:            1000000000,
+			Data:                []byte("MultiESDTNFTTransfer@8049d639e5a6980d1cd2392abcce41029cda74a1563523a202f09641cc2618f8@02@45474c442d303030303030@@03e8@544553542d616263646566@@04d2"),
+			ChainID:             "T",
+			Version:             1,
+			GasEstimationMethod: gasEstimationMethodStatic,
 		}
 
-		response, errTyped := service.ConstructionParse(context.Background(),
-			&types.ConstructionParseRequest{
-				Signed:      false,
-				Transaction: notSignedTx,
+		actualMetadata := &constructionMetadata{}
+		err := fromObjectsMap(response.Metadata, actualMetadata)
+		require.NoError(t, err)
+
+		// (50000 + 1500 * 146) * 1000000000 + (2 * 200000) * 10000000
+		require.Equal(t, "273000000000000", response.SuggestedFee[0].Value)
+		require.Equal(t, expectedMetadata, actualMetadata)
+	})
+
+	t.Run("with multi-token transfer, without providing gas limit and price", func(t *testing.T) {
+		t.Parallel()
+
+		response, errTyped := service.ConstructionMetadata(context.Background(),
+			&types.ConstructionMetadataRequest{
+				Options: objectsMap{
+					"receiver": testscommon.TestAddressBob,
+					"sender":   testscommon.TestAddressAlice,
+					"transfers": []objectsMap{
+						{"amount": "1000", "currencySymbol": "XeGLD"},
+						{"amount": "1234", "currencySymbol": "TEST-abcdef"},
+						{"amount": "1", "currencySymbol": "NFT-abcdef-0a"},
+					},
+				},
 			},
 		)
 
 		require.Nil(t, errTyped)
-		require.Equal(t, operations, response.Operations)
-		require.Nil(t, response.AccountIdentifierSigners)
-	})
 
-	t.Run("custom transfer", func(t *testing.T) {
-		notSignedTx := `{"nonce":42,"value":"1234","receiver":"erd1spyavw0956vq68xj8y4tenjpq2wd5a9p2c6j8gsz7ztyrnpxrruqzu66jx","sender":"erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th","gasPrice":1100000000,"gasLimit":57500,"data":"RVNEVFRyYW5zZmVyQDU0NDU1MzU0MmQ2MTYyNjM2NDY1NjZAMDRkMg==","chainID":"T","version":1}`
+		expectedMetadata := &constructionMetadata{
+			Sender:              testscommon.TestAddressAlice,
+			Receiver:            testscommon.TestAddressAlice,
+			Nonce:               42,
+			Amount:              "0",
+			GasLimit:            1709500,
+			GasPrice:            1000000000,
+			Data:                []byte("MultiESDTNFTTransfer@8049d639e5a6980d1cd2392abcce41029cda74a1563523a202f09641cc2618f8@03@45474c442d303030303030@@03e8@544553542d616263646566@@04d2@4e46542d616263646566@0a@01"),
+			ChainID:             "T",
+			Version:             1,
+			GasEstimationMethod: gasEstimationMethodStatic,
+		}
 
-		operations := []*types.Operation{
-			{
-				OperationIdentifier: indexToOperationIdentifier(0),
-				Type:                opCustomTransfer,
+		actualMetadata := &constructionMetadata{}
+		err := fromObjectsMap(response.Metadata, actualMetadata)
+		require.NoError(t, err)
+
+		require.Equal(t, "323500000000000", response.SuggestedFee[0].Value)
+		require.Equal(t, expectedMetadata, actualMetadata)
+	})
+
+	t.Run("with native currency, with guarded sender", func(t *testing.T) {
+		t.Parallel()
+
+		response, errTyped := service.ConstructionMetadata(context.Background(),
+			&types.ConstructionMetadataRequest{
+				Options: objectsMap{
+					"receiver":       testscommon.TestAddressAlice,
+					"sender":         testscommon.TestAddressCarol,
+					"amount":         "1234",
+					"currencySymbol": "XeGLD",
+				},
+			},
+		)
+
+		require.Nil(t, errTyped)
+
+		expectedMetadata := &constructionMetadata{
+			Sender:              testscommon.TestAddressCarol,
+			Receiver:            testscommon.TestAddressAlice,
+			Nonce:               7,
+			Amount:              "1234",
+			CurrencySymbol:      "XeGLD",
+			GasLimit:            100000,
+			GasPrice:            1000000000,
+			ChainID:             "T",
+			Version:             2,
+			Options:             2,
+			Guardian:            testscommon.TestAddressBob,
+			GasEstimationMethod: gasEstimationMethodStatic,
+		}
+
+		actualMetadata := &constructionMetadata{}
+		err := fromObjectsMap(response.Metadata, actualMetadata)
+		require.NoError(t, err)
+
+		require.Equal(t, "100000000000000", response.SuggestedFee[0].Value)
+		require.Equal(t, expectedMetadata, actualMetadata)
+	})
+
+	t.Run("with native currency, with explicitly providing the guardian", func(t *testing.T) {
+		t.Parallel()
+
+		response, errTyped := service.ConstructionMetadata(context.Background(),
+			&types.ConstructionMetadataRequest{
+				Options: objectsMap{
+					"receiver":       testscommon.TestAddressBob,
+					"sender":         testscommon.TestAddressAlice,
+					"amount":         "1234",
+					"currencySymbol": "XeGLD",
+					"guardian":       testscommon.TestAddressCarol,
+				},
+			},
+		)
+
+		require.Nil(t, errTyped)
+
+		actualMetadata := &constructionMetadata{}
+		err := fromObjectsMap(response.Metadata, actualMetadata)
+		require.NoError(t, err)
+
+		require.Equal(t, testscommon.TestAddressCarol, actualMetadata.Guardian)
+		require.Equal(t, 2, actualMetadata.Version)
+		require.Equal(t, uint32(2), actualMetadata.Options)
+		require.Equal(t, uint64(100000), actualMetadata.GasLimit)
+	})
+
+	t.Run("with custom currency having a malformed identifier", func(t *testing.T) {
+		t.Parallel()
+
+		_, errTyped := service.ConstructionMetadata(context.Background(),
+			&types.ConstructionMetadataRequest{
+				Options: objectsMap{
+					"receiver":       testscommon.TestAddressBob,
+					"sender":         testscommon.TestAddressAlice,
+					"amount":         "1234",
+					"currencySymbol": "NFT-abcdef-xyz",
+				},
+			},
+		)
+
+		require.Equal(t, ErrInvalidCurrency, errCode(errTyped.Code))
+		require.Equal(t, "currencySymbol", errTyped.Details["option"])
+	})
+}
+
+func TestConstructionService_ConstructionPayloads(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	networkProvider.MockAccountsByAddress[testscommon.TestAddressAlice] = &resources.Account{
+		Address: testscommon.TestAddressAlice,
+		Nonce:   42,
+	}
+
+	service := createConstructionService(networkProvider)
+
+	response, errTyped := service.ConstructionPayloads(context.Background(),
+		&types.ConstructionPayloadsRequest{
+			Metadata: objectsMap{
+				"sender":         testscommon.TestAddressAlice,
+				"receiver":       testscommon.TestAddressBob,
+				"nonce":          42,
+				"amount":         "1234",
+				"currencySymbol": "XeGLD",
+				"gasLimit":       57500,
+				"gasPrice":       1000000000,
+				"data":           []byte("hello"),
+				"chainID":        "T",
+				"version":        1,
+			},
+		},
+	)
+
+	expectedTxJson := `{"nonce":42,"value":"1234","receiver":"erd1spyavw0956vq68xj8y4tenjpq2wd5a9p2c6j8gsz7ztyrnpxrruqzu66jx","sender":"erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th","gasPrice":1000000000,"gasLimit":57500,"data":"aGVsbG8=","chainID":"T","version":1}`
+
+	require.Nil(t, errTyped)
+	require.Len(t, response.Payloads, 1)
+	require.Equal(t, expectedTxJson, response.UnsignedTransaction)
+	require.Equal(t, []byte(expectedTxJson), response.Payloads[0].Bytes)
+	require.Equal(t, testscommon.TestAddressAlice, response.Payloads[0].AccountIdentifier.Address)
+	require.Equal(t, types.Ed25519, response.Payloads[0].SignatureType)
+}
+
+func TestConstructionService_ConstructionPayloads_WithGuardian(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	service := createConstructionService(networkProvider)
+
+	response, errTyped := service.ConstructionPayloads(context.Background(),
+		&types.ConstructionPayloadsRequest{
+			Metadata: objectsMap{
+				"sender":         testscommon.TestAddressCarol,
+				"receiver":       testscommon.TestAddressAlice,
+				"nonce":          7,
+				"amount":         "1234",
+				"currencySymbol": "XeGLD",
+				"gasLimit":       100000,
+				"gasPrice":       1000000000,
+				"chainID":        "T",
+				"version":        2,
+				"options":        2,
+				"guardian":       testscommon.TestAddressBob,
+			},
+		},
+	)
+
+	expectedTxJson := `{"nonce":7,"value":"1234","receiver":"erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th","sender":"erd1k2s324ww2g0yj38qn2ch2jwctdy8mnfxep94q9arncc6xecg3xaq6mjse8","gasPrice":1000000000,"gasLimit":100000,"chainID":"T","version":2,"options":2,"guardian":"erd1spyavw0956vq68xj8y4tenjpq2wd5a9p2c6j8gsz7ztyrnpxrruqzu66jx"}`
+
+	require.Nil(t, errTyped)
+	require.Len(t, response.Payloads, 2)
+	require.Equal(t, expectedTxJson, response.UnsignedTransaction)
+	require.Equal(t, []byte(expectedTxJson), response.Payloads[0].Bytes)
+	require.Equal(t, []byte(expectedTxJson), response.Payloads[1].Bytes)
+	require.Equal(t, testscommon.TestAddressCarol, response.Payloads[0].AccountIdentifier.Address)
+	require.Equal(t, testscommon.TestAddressBob, response.Payloads[1].AccountIdentifier.Address)
+	require.Equal(t, types.Ed25519, response.Payloads[1].SignatureType)
+}
+
+func TestConstructionService_ConstructionParse(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	extension := newNetworkProviderExtension(networkProvider)
+	service := createConstructionService(networkProvider)
+
+	t.Run("native transfer", func(t *testing.T) {
+		notSignedTx := `{"nonce":42,"value":"1234","receiver":"erd1spyavw0956vq68xj8y4tenjpq2wd5a9p2c6j8gsz7ztyrnpxrruqzu66jx","sender":"erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th","gasPrice":1100000000,"gasLimit":57500,"data":"aGVsbG8=","chainID":"T","version":1}`
+
+		operations := []*types.Operation{
+			{
+				OperationIdentifier: indexToOperationIdentifier(0),
+				Type:                opTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressAlice),
+				Amount:              extension.valueToNativeAmount("-1234"),
+			},
+			{
+				OperationIdentifier: indexToOperationIdentifier(1),
+				Type:                opTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressBob),
+				Amount:              extension.valueToNativeAmount("1234"),
+			},
+		}
+
+		response, errTyped := service.ConstructionParse(context.Background(),
+			&types.ConstructionParseRequest{
+				Signed:      false,
+				Transaction: notSignedTx,
+			},
+		)
+
+		require.Nil(t, errTyped)
+		require.Equal(t, operations, response.Operations)
+		require.Nil(t, response.AccountIdentifierSigners)
+	})
+
+	t.Run("multi-token transfer, with number of transfers that overflows", func(t *testing.T) {
+		// Data field: "MultiESDTNFTTransfer@010203@5555555555555556@aa@bb".
+		notSignedTx := `{"nonce":42,"value":"0","receiver":"erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th","sender":"erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th","gasPrice":1000000000,"gasLimit":1000000,"data":"TXVsdGlFU0RUTkZUVHJhbnNmZXJAMDEwMjAzQDU1NTU1NTU1NTU1NTU1NTZAYWFAYmI=","chainID":"T","version":1}`
+
+		_, errTyped := service.ConstructionParse(context.Background(),
+			&types.ConstructionParseRequest{
+				Signed:      false,
+				Transaction: notSignedTx,
+			},
+		)
+
+		require.Equal(t, ErrConstruction, errCode(errTyped.Code))
+		require.Contains(t, errTyped.Details["originalError"], "bad number of arguments for multi-token transfer")
+	})
+
+	t.Run("custom transfer", func(t *testing.T) {
+		notSignedTx := `{"nonce":42,"value":"1234","receiver":"erd1spyavw0956vq68xj8y4tenjpq2wd5a9p2c6j8gsz7ztyrnpxrruqzu66jx","sender":"erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th","gasPrice":1100000000,"gasLimit":57500,"data":"RVNEVFRyYW5zZmVyQDU0NDU1MzU0MmQ2MTYyNjM2NDY1NjZAMDRkMg==","chainID":"T","version":1}`
+
+		operations := []*types.Operation{
+			{
+				OperationIdentifier: indexToOperationIdentifier(0),
+				Type:                opCustomTransfer,
 				Account:             addressToAccountIdentifier(testscommon.TestAddressAlice),
 				Amount:              extension.valueToCustomAmount("-1234", "TEST-abcdef"),
 			},
@@ -553,38 +978,1723 @@ func TestConstructionService_ConstructionParse(t *testing.T) {
 		require.Equal(t, operations, response.Operations)
 		require.Nil(t, response.AccountIdentifierSigners)
 	})
-}
 
-func TestConstructionService_ConstructionCombine(t *testing.T) {
-	t.Parallel()
+	t.Run("non-fungible custom transfer", func(t *testing.T) {
+		notSignedTx := `{"nonce":42,"value":"0","receiver":"erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th","sender":"erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th","gasPrice":1000000000,"gasLimit":413500,"data":"RVNEVE5GVFRyYW5zZmVyQDRlNDY1NDJkNjE2MjYzNjQ2NTY2QDBhQDA0ZDJAODA0OWQ2MzllNWE2OTgwZDFjZDIzOTJhYmNjZTQxMDI5Y2RhNzRhMTU2MzUyM2EyMDJmMDk2NDFjYzI2MThmOA==","chainID":"T","version":1}`
 
-	networkProvider := testscommon.NewNetworkProviderMock()
-	service := NewConstructionService(networkProvider)
+		operations := []*types.Operation{
+			{
+				OperationIdentifier: indexToOperationIdentifier(0),
+				Type:                opCustomTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressAlice),
+				Amount:              extension.valueToCustomAmount("-1234", "NFT-abcdef-0a"),
+			},
+			{
+				OperationIdentifier: indexToOperationIdentifier(1),
+				Type:                opCustomTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressBob),
+				Amount:              extension.valueToCustomAmount("1234", "NFT-abcdef-0a"),
+			},
+		}
 
-	notSignedTx := `{"nonce":42,"value":"1234","receiver":"erd1spyavw0956vq68xj8y4tenjpq2wd5a9p2c6j8gsz7ztyrnpxrruqzu66jx","sender":"erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th","gasPrice":1100000000,"gasLimit":57500,"data":"aGVsbG8=","chainID":"T","version":1}`
+		response, errTyped := service.ConstructionParse(context.Background(),
+			&types.ConstructionParseRequest{
+				Signed:      false,
+				Transaction: notSignedTx,
+			},
+		)
+
+		require.Nil(t, errTyped)
+		require.Equal(t, operations, response.Operations)
+		require.Nil(t, response.AccountIdentifierSigners)
+	})
+
+	t.Run("guarded native transfer, signed", func(t *testing.T) {
+		signedTx := `{"nonce":7,"value":"1234","receiver":"erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th","sender":"erd1k2s324ww2g0yj38qn2ch2jwctdy8mnfxep94q9arncc6xecg3xaq6mjse8","gasPrice":1000000000,"gasLimit":100000,"signature":"aabb","chainID":"T","version":2,"options":2,"guardian":"erd1spyavw0956vq68xj8y4tenjpq2wd5a9p2c6j8gsz7ztyrnpxrruqzu66jx","guardianSignature":"ccdd"}`
+
+		response, errTyped := service.ConstructionParse(context.Background(),
+			&types.ConstructionParseRequest{
+				Signed:      true,
+				Transaction: signedTx,
+			},
+		)
+
+		require.Nil(t, errTyped)
+		require.Len(t, response.Operations, 2)
+		require.Equal(t, []*types.AccountIdentifier{
+			addressToAccountIdentifier(testscommon.TestAddressCarol),
+			addressToAccountIdentifier(testscommon.TestAddressBob),
+		}, response.AccountIdentifierSigners)
+	})
+
+	t.Run("multi-token transfer", func(t *testing.T) {
+		notSignedTx := `{"nonce":42,"value":"0","receiver":"erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th","sender":"erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th","gasPrice":1000000000,"gasLimit":909500,"data":"TXVsdGlFU0RUTkZUVHJhbnNmZXJAODA0OWQ2MzllNWE2OTgwZDFjZDIzOTJhYmNjZTQxMDI5Y2RhNzRhMTU2MzUyM2EyMDJmMDk2NDFjYzI2MThmOEAwM0A0NTQ3NGM0NDJkMzAzMDMwMzAzMDMwQEAwM2U4QDU0NDU1MzU0MmQ2MTYyNjM2NDY1NjZAQDA0ZDJANGU0NjU0MmQ2MTYyNjM2NDY1NjZAMGFAMDE=","chainID":"T","version":1}`
+
+		operations := []*types.Operation{
+			{
+				OperationIdentifier: indexToOperationIdentifier(0),
+				Type:                opTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressAlice),
+				Amount:              extension.valueToNativeAmount("-1000"),
+			},
+			{
+				OperationIdentifier: indexToOperationIdentifier(1),
+				Type:                opTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressBob),
+				Amount:              extension.valueToNativeAmount("1000"),
+			},
+			{
+				OperationIdentifier: indexToOperationIdentifier(2),
+				Type:                opCustomTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressAlice),
+				Amount:              extension.valueToCustomAmount("-1234", "TEST-abcdef"),
+			},
+			{
+				OperationIdentifier: indexToOperationIdentifier(3),
+				Type:                opCustomTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressBob),
+				Amount:              extension.valueToCustomAmount("1234", "TEST-abcdef"),
+			},
+			{
+				OperationIdentifier: indexToOperationIdentifier(4),
+				Type:                opCustomTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressAlice),
+				Amount:              extension.valueToCustomAmount("-1", "NFT-abcdef-0a"),
+			},
+			{
+				OperationIdentifier: indexToOperationIdentifier(5),
+				Type:                opCustomTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressBob),
+				Amount:              extension.valueToCustomAmount("1", "NFT-abcdef-0a"),
+			},
+		}
+
+		response, errTyped := service.ConstructionParse(context.Background(),
+			&types.ConstructionParseRequest{
+				Signed:      false,
+				Transaction: notSignedTx,
+			},
+		)
+
+		require.Nil(t, errTyped)
+		require.Equal(t, operations, response.Operations)
+		require.Nil(t, response.AccountIdentifierSigners)
+	})
 
-	response, errTyped := service.ConstructionCombine(context.Background(),
-		&types.ConstructionCombineRequest{
-			UnsignedTransaction: notSignedTx,
-			Signatures: []*types.Signature{
-				{
-					Bytes: []byte{0xaa, 0xbb},
+	t.Run("built-in function, without value (no native transfer)", func(t *testing.T) {
+		notSignedTx := `{"nonce":42,"value":"0","receiver":"erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th","sender":"erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th","gasPrice":1000000000,"gasLimit":300000,"data":"RVNEVExvY2FsTWludEA1NDQ1NTM1NDJkNjE2MjYzNjQ2NTY2QDBh","chainID":"T","version":1}`
+
+		response, errTyped := service.ConstructionParse(context.Background(),
+			&types.ConstructionParseRequest{
+				Signed:      false,
+				Transaction: notSignedTx,
+			},
+		)
+
+		require.Nil(t, errTyped)
+		require.Empty(t, response.Operations)
+		require.Equal(t, intentKindBuiltInFunction, response.Metadata["intent"].(objectsMap)["kind"])
+	})
+}
+
+func TestConstructionService_ConstructionCombine(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	service := createConstructionService(networkProvider)
+
+	sender := newTestSigner(networkProvider, 1)
+
+	notSignedTx := fmt.Sprintf(`{"nonce":42,"value":"1234","receiver":"erd1spyavw0956vq68xj8y4tenjpq2wd5a9p2c6j8gsz7ztyrnpxrruqzu66jx","sender":"%s","gasPrice":1100000000,"gasLimit":57500,"data":"aGVsbG8=","chainID":"T","version":1}`, sender.address)
+	signature := sender.sign([]byte(notSignedTx))
+	signedTx := fmt.Sprintf(`{"nonce":42,"value":"1234","receiver":"erd1spyavw0956vq68xj8y4tenjpq2wd5a9p2c6j8gsz7ztyrnpxrruqzu66jx","sender":"%s","gasPrice":1100000000,"gasLimit":57500,"data":"aGVsbG8=","signature":"%s","chainID":"T","version":1}`, sender.address, hex.EncodeToString(signature))
+
+	t.Run("with signature not referencing a signing payload", func(t *testing.T) {
+		response, errTyped := service.ConstructionCombine(context.Background(),
+			&types.ConstructionCombineRequest{
+				UnsignedTransaction: notSignedTx,
+				Signatures: []*types.Signature{
+					{
+						Bytes: signature,
+					},
+				},
+			},
+		)
+
+		require.Nil(t, errTyped)
+		require.Equal(t, signedTx, response.SignedTransaction)
+	})
+
+	t.Run("with signature referencing a signing payload", func(t *testing.T) {
+		response, errTyped := service.ConstructionCombine(context.Background(),
+			&types.ConstructionCombineRequest{
+				UnsignedTransaction: notSignedTx,
+				Signatures:          []*types.Signature{sender.createSignature([]byte(notSignedTx))},
+			},
+		)
+
+		require.Nil(t, errTyped)
+		require.Equal(t, signedTx, response.SignedTransaction)
+	})
+
+	t.Run("with invalid signature", func(t *testing.T) {
+		_, errTyped := service.ConstructionCombine(context.Background(),
+			&types.ConstructionCombineRequest{
+				UnsignedTransaction: notSignedTx,
+				Signatures: []*types.Signature{
+					{
+						Bytes: []byte{0xaa, 0xbb},
+					},
+				},
+			},
+		)
+
+		require.Equal(t, ErrConstruction, errCode(errTyped.Code))
+		require.False(t, errTyped.Retriable)
+		require.Equal(t, "payload 0 (sender "+sender.address+"): invalid signature", errTyped.Details["originalError"])
+	})
+}
+
+func TestConstructionService_ConstructionCombine_WithGuardian(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	service := createConstructionService(networkProvider)
+
+	sender := newTestSigner(networkProvider, 1)
+	guardian := newTestSigner(networkProvider, 2)
+	other := newTestSigner(networkProvider, 7)
+
+	notSignedTx := fmt.Sprintf(`{"nonce":7,"value":"1234","receiver":"erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th","sender":"%s","gasPrice":1000000000,"gasLimit":100000,"chainID":"T","version":2,"options":2,"guardian":"%s"}`, sender.address, guardian.address)
+	senderSignature := sender.createSignature([]byte(notSignedTx))
+	guardianSignature := guardian.createSignature([]byte(notSignedTx))
+
+	signedTx := fmt.Sprintf(`{"nonce":7,"value":"1234","receiver":"erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th","sender":"%s","gasPrice":1000000000,"gasLimit":100000,"signature":"%s","chainID":"T","version":2,"options":2,"guardian":"%s","guardianSignature":"%s"}`,
+		sender.address,
+		hex.EncodeToString(senderSignature.Bytes),
+		guardian.address,
+		hex.EncodeToString(guardianSignature.Bytes),
+	)
+
+	t.Run("with both signatures (in any order)", func(t *testing.T) {
+		response, errTyped := service.ConstructionCombine(context.Background(),
+			&types.ConstructionCombineRequest{
+				UnsignedTransaction: notSignedTx,
+				Signatures:          []*types.Signature{guardianSignature, senderSignature},
+			},
+		)
+
+		require.Nil(t, errTyped)
+		require.Equal(t, signedTx, response.SignedTransaction)
+	})
+
+	t.Run("with missing guardian signature", func(t *testing.T) {
+		_, errTyped := service.ConstructionCombine(context.Background(),
+			&types.ConstructionCombineRequest{
+				UnsignedTransaction: notSignedTx,
+				Signatures:          []*types.Signature{senderSignature},
+			},
+		)
+
+		require.Equal(t, ErrInvalidInputParam, errCode(errTyped.Code))
+	})
+
+	t.Run("with unexpected signer", func(t *testing.T) {
+		_, errTyped := service.ConstructionCombine(context.Background(),
+			&types.ConstructionCombineRequest{
+				UnsignedTransaction: notSignedTx,
+				Signatures:          []*types.Signature{senderSignature, other.createSignature([]byte(notSignedTx))},
+			},
+		)
+
+		require.Equal(t, ErrInvalidInputParam, errCode(errTyped.Code))
+		require.Equal(t, "payload 1: unexpected signer: "+other.address, errTyped.Details["originalError"])
+		require.Equal(t, 1, errTyped.Details["signatureIndex"])
+	})
+
+	t.Run("with duplicated signature", func(t *testing.T) {
+		_, errTyped := service.ConstructionCombine(context.Background(),
+			&types.ConstructionCombineRequest{
+				UnsignedTransaction: notSignedTx,
+				Signatures:          []*types.Signature{senderSignature, senderSignature},
+			},
+		)
+
+		require.Equal(t, ErrInvalidInputParam, errCode(errTyped.Code))
+		require.Equal(t, "payload 1: duplicated signature for sender: "+sender.address, errTyped.Details["originalError"])
+		require.Equal(t, 1, errTyped.Details["signatureIndex"])
+	})
+
+	t.Run("with guardian signature made by another key", func(t *testing.T) {
+		forgedSignature := guardian.createSignature([]byte(notSignedTx))
+		forgedSignature.Bytes = other.sign([]byte(notSignedTx))
+
+		_, errTyped := service.ConstructionCombine(context.Background(),
+			&types.ConstructionCombineRequest{
+				UnsignedTransaction: notSignedTx,
+				Signatures:          []*types.Signature{senderSignature, forgedSignature},
+			},
+		)
+
+		require.Equal(t, ErrConstruction, errCode(errTyped.Code))
+		require.Equal(t, "payload 1 (guardian "+guardian.address+"): invalid signature", errTyped.Details["originalError"])
+		require.Equal(t, 1, errTyped.Details["signatureIndex"])
+	})
+}
+
+func TestConstructionService_WithRelayedTransactionV3(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	sender := newTestSigner(networkProvider, 1)
+	relayer := newTestSigner(networkProvider, 4)
+
+	networkProvider.MockAccountsByAddress[sender.address] = &resources.Account{
+		Address: sender.address,
+		Nonce:   42,
+	}
+
+	extension := newNetworkProviderExtension(networkProvider)
+	service := createConstructionService(networkProvider)
+
+	notSignedTx := fmt.Sprintf(`{"nonce":42,"value":"1234","receiver":"erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th","sender":"%s","gasPrice":1000000000,"gasLimit":100000,"chainID":"T","version":2,"relayer":"%s"}`, sender.address, relayer.address)
+	senderSignature := sender.createSignature([]byte(notSignedTx))
+	relayerSignature := relayer.createSignature([]byte(notSignedTx))
+
+	signedTx := fmt.Sprintf(`{"nonce":42,"value":"1234","receiver":"erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th","sender":"%s","gasPrice":1000000000,"gasLimit":100000,"signature":"%s","chainID":"T","version":2,"relayer":"%s","relayerSignature":"%s"}`,
+		sender.address,
+		hex.EncodeToString(senderSignature.Bytes),
+		relayer.address,
+		hex.EncodeToString(relayerSignature.Bytes),
+	)
+
+	t.Run("preprocess, with relayer inferred from the 'Fee' operation", func(t *testing.T) {
+		t.Parallel()
+
+		operations := []*types.Operation{
+			{
+				OperationIdentifier: indexToOperationIdentifier(0),
+				Type:                opTransfer,
+				Account:             addressToAccountIdentifier(sender.address),
+				Amount:              extension.valueToNativeAmount("-1234"),
+			},
+			{
+				OperationIdentifier: indexToOperationIdentifier(1),
+				Type:                opTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressAlice),
+				Amount:              extension.valueToNativeAmount("1234"),
+			},
+			{
+				OperationIdentifier: indexToOperationIdentifier(2),
+				Type:                opFee,
+				Account:             addressToAccountIdentifier(relayer.address),
+				Amount:              extension.valueToNativeAmount("-100000000000000"),
+			},
+		}
+
+		response, errTyped := service.ConstructionPreprocess(context.Background(),
+			&types.ConstructionPreprocessRequest{
+				Operations: operations,
+				Metadata:   objectsMap{},
+			},
+		)
+
+		require.Nil(t, errTyped)
+
+		expectedOptions := &constructionOptions{
+			Sender:         sender.address,
+			Receiver:       testscommon.TestAddressAlice,
+			Amount:         "1234",
+			CurrencySymbol: "XeGLD",
+			Relayer:        relayer.address,
+		}
+
+		actualOptions := &constructionOptions{}
+		err := fromObjectsMap(response.Options, actualOptions)
+		require.NoError(t, err)
+		require.Equal(t, expectedOptions, actualOptions)
+	})
+
+	t.Run("preprocess, with relayer being the sender", func(t *testing.T) {
+		t.Parallel()
+
+		_, errTyped := service.ConstructionPreprocess(context.Background(),
+			&types.ConstructionPreprocessRequest{
+				Metadata: objectsMap{
+					"sender":         sender.address,
+					"receiver":       testscommon.TestAddressAlice,
+					"amount":         "1234",
+					"currencySymbol": "XeGLD",
+					"relayer":        sender.address,
+				},
+			},
+		)
+
+		require.Equal(t, ErrConstruction, errCode(errTyped.Code))
+	})
+
+	t.Run("metadata", func(t *testing.T) {
+		t.Parallel()
+
+		response, errTyped := service.ConstructionMetadata(context.Background(),
+			&types.ConstructionMetadataRequest{
+				Options: objectsMap{
+					"sender":         sender.address,
+					"receiver":       testscommon.TestAddressAlice,
+					"amount":         "1234",
+					"currencySymbol": "XeGLD",
+					"relayer":        relayer.address,
+				},
+			},
+		)
+
+		require.Nil(t, errTyped)
+
+		expectedMetadata := &constructionMetadata{
+			Sender:              sender.address,
+			Receiver:            testscommon.TestAddressAlice,
+			Nonce:               42,
+			Amount:              "1234",
+			CurrencySymbol:      "XeGLD",
+			GasLimit:            100000,
+			GasPrice:            1000000000,
+			ChainID:             "T",
+			Version:             2,
+			Relayer:             relayer.address,
+			GasEstimationMethod: gasEstimationMethodStatic,
+		}
+
+		actualMetadata := &constructionMetadata{}
+		err := fromObjectsMap(response.Metadata, actualMetadata)
+		require.NoError(t, err)
+
+		require.Equal(t, "100000000000000", response.SuggestedFee[0].Value)
+		require.Equal(t, expectedMetadata, actualMetadata)
+	})
+
+	t.Run("metadata, with relayer in another shard", func(t *testing.T) {
+		t.Parallel()
+
+		_, errTyped := service.ConstructionMetadata(context.Background(),
+			&types.ConstructionMetadataRequest{
+				Options: objectsMap{
+					"sender":         sender.address,
+					"receiver":       testscommon.TestAddressAlice,
+					"amount":         "1234",
+					"currencySymbol": "XeGLD",
+					"relayer":        testscommon.TestUserShard1.Address,
+				},
+			},
+		)
+
+		require.Equal(t, ErrConstruction, errCode(errTyped.Code))
+	})
+
+	t.Run("payloads", func(t *testing.T) {
+		t.Parallel()
+
+		response, errTyped := service.ConstructionPayloads(context.Background(),
+			&types.ConstructionPayloadsRequest{
+				Metadata: objectsMap{
+					"sender":         sender.address,
+					"receiver":       testscommon.TestAddressAlice,
+					"nonce":          42,
+					"amount":         "1234",
+					"currencySymbol": "XeGLD",
+					"gasLimit":       100000,
+					"gasPrice":       1000000000,
+					"chainID":        "T",
+					"version":        2,
+					"relayer":        relayer.address,
+				},
+			},
+		)
+
+		require.Nil(t, errTyped)
+		require.Equal(t, notSignedTx, response.UnsignedTransaction)
+		require.Len(t, response.Payloads, 2)
+		require.Equal(t, sender.address, response.Payloads[0].AccountIdentifier.Address)
+		require.Equal(t, relayer.address, response.Payloads[1].AccountIdentifier.Address)
+		require.Equal(t, []byte(notSignedTx), response.Payloads[0].Bytes)
+		require.Equal(t, []byte(notSignedTx), response.Payloads[1].Bytes)
+	})
+
+	t.Run("combine", func(t *testing.T) {
+		t.Parallel()
+
+		response, errTyped := service.ConstructionCombine(context.Background(),
+			&types.ConstructionCombineRequest{
+				UnsignedTransaction: notSignedTx,
+				Signatures:          []*types.Signature{relayerSignature, senderSignature},
+			},
+		)
+
+		require.Nil(t, errTyped)
+		require.Equal(t, signedTx, response.SignedTransaction)
+	})
+
+	t.Run("parse", func(t *testing.T) {
+		t.Parallel()
+
+		operations := []*types.Operation{
+			{
+				OperationIdentifier: indexToOperationIdentifier(0),
+				Type:                opTransfer,
+				Account:             addressToAccountIdentifier(sender.address),
+				Amount:              extension.valueToNativeAmount("-1234"),
+			},
+			{
+				OperationIdentifier: indexToOperationIdentifier(1),
+				Type:                opTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressAlice),
+				Amount:              extension.valueToNativeAmount("1234"),
+			},
+			{
+				OperationIdentifier: indexToOperationIdentifier(2),
+				Type:                opFee,
+				Account:             addressToAccountIdentifier(relayer.address),
+				Amount:              extension.valueToNativeAmount("-100000000000000"),
+			},
+		}
+
+		response, errTyped := service.ConstructionParse(context.Background(),
+			&types.ConstructionParseRequest{
+				Signed:      true,
+				Transaction: signedTx,
+			},
+		)
+
+		require.Nil(t, errTyped)
+		require.Equal(t, operations, response.Operations)
+		require.Equal(t, []*types.AccountIdentifier{
+			addressToAccountIdentifier(sender.address),
+			addressToAccountIdentifier(relayer.address),
+		}, response.AccountIdentifierSigners)
+	})
+}
+
+func TestConstructionService_WithTransactionSignedWithHash(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	sender := newTestSigner(networkProvider, 1)
+	networkProvider.MockAccountsByAddress[sender.address] = &resources.Account{
+		Address: sender.address,
+		Nonce:   42,
+	}
+
+	service := createConstructionService(networkProvider)
+
+	notSignedTx := fmt.Sprintf(`{"nonce":42,"value":"1234","receiver":"%s","sender":"%s","gasPrice":1000000000,"gasLimit":50000,"chainID":"T","version":2,"options":1}`, testscommon.TestAddressBob, sender.address)
+	txHash := keccak.NewKeccak().Compute(notSignedTx)
+
+	t.Run("preprocess", func(t *testing.T) {
+		t.Parallel()
+
+		response, errTyped := service.ConstructionPreprocess(context.Background(),
+			&types.ConstructionPreprocessRequest{
+				Metadata: objectsMap{
+					"sender":         sender.address,
+					"receiver":       testscommon.TestAddressBob,
+					"amount":         "1234",
+					"currencySymbol": "XeGLD",
+					"version":        2,
+					"options":        1,
+				},
+			},
+		)
+
+		require.Nil(t, errTyped)
+
+		actualOptions := &constructionOptions{}
+		err := fromObjectsMap(response.Options, actualOptions)
+		require.NoError(t, err)
+		require.Equal(t, 2, actualOptions.Version)
+		require.Equal(t, uint32(1), actualOptions.Options)
+	})
+
+	t.Run("metadata", func(t *testing.T) {
+		t.Parallel()
+
+		response, errTyped := service.ConstructionMetadata(context.Background(),
+			&types.ConstructionMetadataRequest{
+				Options: objectsMap{
+					"sender":         sender.address,
+					"receiver":       testscommon.TestAddressBob,
+					"amount":         "1234",
+					"currencySymbol": "XeGLD",
+					"version":        2,
+					"options":        1,
+				},
+			},
+		)
+
+		require.Nil(t, errTyped)
+
+		expectedMetadata := &constructionMetadata{
+			Sender:              sender.address,
+			Receiver:            testscommon.TestAddressBob,
+			Nonce:               42,
+			Amount:              "1234",
+			CurrencySymbol:      "XeGLD",
+			GasLimit:            50000,
+			GasPrice:            1000000000,
+			ChainID:             "T",
+			Version:             2,
+			Options:             1,
+			GasEstimationMethod: gasEstimationMethodStatic,
+		}
+
+		actualMetadata := &constructionMetadata{}
+		err := fromObjectsMap(response.Metadata, actualMetadata)
+		require.NoError(t, err)
+		require.Equal(t, expectedMetadata, actualMetadata)
+	})
+
+	t.Run("payloads", func(t *testing.T) {
+		t.Parallel()
+
+		response, errTyped := service.ConstructionPayloads(context.Background(),
+			&types.ConstructionPayloadsRequest{
+				Metadata: objectsMap{
+					"sender":         sender.address,
+					"receiver":       testscommon.TestAddressBob,
+					"nonce":          42,
+					"amount":         "1234",
+					"currencySymbol": "XeGLD",
+					"gasLimit":       50000,
+					"gasPrice":       1000000000,
+					"chainID":        "T",
+					"version":        2,
+					"options":        1,
+				},
+			},
+		)
+
+		require.Nil(t, errTyped)
+		require.Equal(t, notSignedTx, response.UnsignedTransaction)
+		require.Len(t, response.Payloads, 1)
+		require.Equal(t, txHash, response.Payloads[0].Bytes)
+	})
+
+	t.Run("combine", func(t *testing.T) {
+		t.Parallel()
+
+		signature := sender.createSignature(txHash)
+		signedTx := fmt.Sprintf(`{"nonce":42,"value":"1234","receiver":"%s","sender":"%s","gasPrice":1000000000,"gasLimit":50000,"signature":"%s","chainID":"T","version":2,"options":1}`,
+			testscommon.TestAddressBob,
+			sender.address,
+			hex.EncodeToString(signature.Bytes),
+		)
+
+		response, errTyped := service.ConstructionCombine(context.Background(),
+			&types.ConstructionCombineRequest{
+				UnsignedTransaction: notSignedTx,
+				Signatures:          []*types.Signature{signature},
+			},
+		)
+
+		require.Nil(t, errTyped)
+		require.Equal(t, signedTx, response.SignedTransaction)
+	})
+
+	t.Run("combine, with signature over the serialized transaction (instead of its hash)", func(t *testing.T) {
+		t.Parallel()
+
+		_, errTyped := service.ConstructionCombine(context.Background(),
+			&types.ConstructionCombineRequest{
+				UnsignedTransaction: notSignedTx,
+				Signatures: []*types.Signature{
+					{
+						Bytes: sender.sign([]byte(notSignedTx)),
+					},
+				},
+			},
+		)
+
+		require.Equal(t, ErrConstruction, errCode(errTyped.Code))
+	})
+}
+
+func TestConstructionService_WithContractCall(t *testing.T) {
+	t.Parallel()
+
+	contract := testscommon.TestContractFooShard0.Address
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	networkProvider.MockCustomCurrencies = []resources.Currency{{Symbol: "TEST-abcdef"}, {Symbol: "NFT-abcdef"}}
+	networkProvider.MockAccountsByAddress[testscommon.TestAddressAlice] = &resources.Account{
+		Address: testscommon.TestAddressAlice,
+		Nonce:   42,
+	}
+	networkProvider.ComputeTransactionCostCalled = func(tx *data.Transaction) (*resources.TransactionCost, error) {
+		require.Equal(t, testscommon.TestAddressAlice, tx.Sender)
+		require.Equal(t, contract, tx.Receiver)
+		require.Equal(t, uint64(42), tx.Nonce)
+
+		switch string(tx.Data) {
+		case "add@07":
+			return &resources.TransactionCost{GasUnits: 1500000}, nil
+		case "ESDTTransfer@544553542d616263646566@64@616464@07":
+			return &resources.TransactionCost{GasUnits: 2000000}, nil
+		default:
+			return nil, errors.New("simulation failed: invalid function (not found)")
+		}
+	}
+
+	extension := newNetworkProviderExtension(networkProvider)
+	service := createConstructionService(networkProvider)
+
+	operations := []*types.Operation{
+		{
+			OperationIdentifier: indexToOperationIdentifier(0),
+			Type:                opTransfer,
+			Account:             addressToAccountIdentifier(testscommon.TestAddressAlice),
+			Amount:              extension.valueToNativeAmount("-1234"),
+		},
+		{
+			OperationIdentifier: indexToOperationIdentifier(1),
+			Type:                opTransfer,
+			Account:             addressToAccountIdentifier(contract),
+			Amount:              extension.valueToNativeAmount("1234"),
+		},
+		{
+			OperationIdentifier: indexToOperationIdentifier(2),
+			Type:                opSmartContractCall,
+			Account:             addressToAccountIdentifier(testscommon.TestAddressAlice),
+			Metadata: objectsMap{
+				"function":  "add",
+				"arguments": []interface{}{"07"},
+				"contract":  contract,
+			},
+		},
+	}
+
+	expectedOptions := &constructionOptions{
+		Sender:         testscommon.TestAddressAlice,
+		Receiver:       contract,
+		Amount:         "1234",
+		CurrencySymbol: "XeGLD",
+		ContractCall: &constructionContractCall{
+			Function:  "add",
+			Arguments: []string{"07"},
+		},
+	}
+
+	t.Run("preprocess, with operations", func(t *testing.T) {
+		t.Parallel()
+
+		response, errTyped := service.ConstructionPreprocess(context.Background(),
+			&types.ConstructionPreprocessRequest{
+				Operations: operations,
+				Metadata:   objectsMap{},
+			},
+		)
+
+		require.Nil(t, errTyped)
+
+		actualOptions := &constructionOptions{}
+		err := fromObjectsMap(response.Options, actualOptions)
+		require.NoError(t, err)
+		require.Equal(t, expectedOptions, actualOptions)
+	})
+
+	t.Run("preprocess, without transfer", func(t *testing.T) {
+		t.Parallel()
+
+		response, errTyped := service.ConstructionPreprocess(context.Background(),
+			&types.ConstructionPreprocessRequest{
+				Operations: operations[2:],
+				Metadata:   objectsMap{},
+			},
+		)
+
+		require.Nil(t, errTyped)
+
+		expectedOptions := &constructionOptions{
+			Sender:   testscommon.TestAddressAlice,
+			Receiver: contract,
+			ContractCall: &constructionContractCall{
+				Function:  "add",
+				Arguments: []string{"07"},
+			},
+		}
+
+		actualOptions := &constructionOptions{}
+		err := fromObjectsMap(response.Options, actualOptions)
+		require.NoError(t, err)
+		require.Equal(t, expectedOptions, actualOptions)
+	})
+
+	t.Run("metadata", func(t *testing.T) {
+		t.Parallel()
+
+		options, err := toObjectsMap(expectedOptions)
+		require.NoError(t, err)
+
+		response, errTyped := service.ConstructionMetadata(context.Background(),
+			&types.ConstructionMetadataRequest{
+				Options: options,
+			},
+		)
+
+		require.Nil(t, errTyped)
+
+		expectedMetadata := &constructionMetadata{
+			Sender:              testscommon.TestAddressAlice,
+			Receiver:            contract,
+			Nonce:               42,
+			Amount:              "1234",
+			CurrencySymbol:      "XeGLD",
+			GasLimit:            1500000,
+			GasPrice:            1000000000,
+			Data:                []byte("add@07"),
+			ChainID:             "T",
+			Version:             1,
+			GasEstimationMethod: gasEstimationMethodObserver,
+		}
+
+		actualMetadata := &constructionMetadata{}
+		err = fromObjectsMap(response.Metadata, actualMetadata)
+		require.NoError(t, err)
+
+		// (50000 + 1500 * 6) * 1000000000 + (1500000 - 59000) * 10000000
+		require.Equal(t, "73410000000000", response.SuggestedFee[0].Value)
+		require.Equal(t, expectedMetadata, actualMetadata)
+	})
+
+	t.Run("metadata, with custom currency", func(t *testing.T) {
+		t.Parallel()
+
+		response, errTyped := service.ConstructionMetadata(context.Background(),
+			&types.ConstructionMetadataRequest{
+				Options: objectsMap{
+					"sender":         testscommon.TestAddressAlice,
+					"receiver":       contract,
+					"amount":         "100",
+					"currencySymbol": "TEST-abcdef",
+					"contractCall": objectsMap{
+						"function":  "add",
+						"arguments": []interface{}{"07"},
+					},
+				},
+			},
+		)
+
+		require.Nil(t, errTyped)
+
+		expectedMetadata := &constructionMetadata{
+			Sender:              testscommon.TestAddressAlice,
+			Receiver:            contract,
+			Nonce:               42,
+			Amount:              "0",
+			CurrencySymbol:      "TEST-abcdef",
+			GasLimit:            2000000,
+			GasPrice:            1000000000,
+			Data:                []byte("ESDTTransfer@544553542d616263646566@64@616464@07"),
+			ChainID:             "T",
+			Version:             1,
+			GasEstimationMethod: gasEstimationMethodObserver,
+		}
+
+		actualMetadata := &constructionMetadata{}
+		err := fromObjectsMap(response.Metadata, actualMetadata)
+		require.NoError(t, err)
+
+		// (50000 + 1500 * 48) * 1000000000 + (2000000 - 122000) * 10000000
+		require.Equal(t, "140780000000000", response.SuggestedFee[0].Value)
+		require.Equal(t, expectedMetadata, actualMetadata)
+	})
+
+	t.Run("metadata, with explicit gas limit (no simulation)", func(t *testing.T) {
+		t.Parallel()
+
+		response, errTyped := service.ConstructionMetadata(context.Background(),
+			&types.ConstructionMetadataRequest{
+				Options: objectsMap{
+					"sender":   testscommon.TestAddressAlice,
+					"receiver": contract,
+					"gasLimit": 3000000,
+					"contractCall": objectsMap{
+						"function": "unknown",
+					},
+				},
+			},
+		)
+
+		require.Nil(t, errTyped)
+
+		actualMetadata := &constructionMetadata{}
+		err := fromObjectsMap(response.Metadata, actualMetadata)
+		require.NoError(t, err)
+
+		// (50000 + 1500 * 7) * 1000000000 + (3000000 - 60500) * 10000000
+		require.Equal(t, "89895000000000", response.SuggestedFee[0].Value)
+		require.Equal(t, uint64(3000000), actualMetadata.GasLimit)
+		require.Equal(t, "0", actualMetadata.Amount)
+		require.Equal(t, []byte("unknown"), actualMetadata.Data)
+	})
+
+	t.Run("metadata, with failed simulation", func(t *testing.T) {
+		t.Parallel()
+
+		_, errTyped := service.ConstructionMetadata(context.Background(),
+			&types.ConstructionMetadataRequest{
+				Options: objectsMap{
+					"sender":   testscommon.TestAddressAlice,
+					"receiver": contract,
+					"contractCall": objectsMap{
+						"function": "unknown",
+					},
+				},
+			},
+		)
+
+		require.Equal(t, ErrUnableToEstimateGasLimit, errCode(errTyped.Code))
+		require.Contains(t, errTyped.Details["originalError"], "invalid function (not found)")
+	})
+
+	t.Run("parse", func(t *testing.T) {
+		t.Parallel()
+
+		notSignedTx := fmt.Sprintf(`{"nonce":42,"value":"1234","receiver":"%s","sender":"%s","gasPrice":1000000000,"gasLimit":1500000,"data":"YWRkQDA3","chainID":"T","version":1}`, contract, testscommon.TestAddressAlice)
+
+		response, errTyped := service.ConstructionParse(context.Background(),
+			&types.ConstructionParseRequest{
+				Signed:      false,
+				Transaction: notSignedTx,
+			},
+		)
+
+		require.Nil(t, errTyped)
+		require.Equal(t, operations, response.Operations)
+
+		intent := popIntentOfParsedMetadata(t, response.Metadata)
+		require.Equal(t, intentKindContractCall, intent.Kind)
+		require.Equal(t, "add", intent.Function)
+		require.Equal(t, []*intentArgument{{Name: "argument", Type: argumentTypeBytes, Value: "07"}}, intent.Arguments)
+
+		require.Equal(t, map[string]interface{}{
+			"function":  "add",
+			"arguments": []interface{}{"07"},
+			"contract":  contract,
+		}, response.Metadata)
+
+		// Round-trip
+		preprocessResponse, errTyped := service.ConstructionPreprocess(context.Background(),
+			&types.ConstructionPreprocessRequest{
+				Operations: response.Operations,
+				Metadata:   objectsMap{},
+			},
+		)
+
+		require.Nil(t, errTyped)
+
+		actualOptions := &constructionOptions{}
+		err := fromObjectsMap(preprocessResponse.Options, actualOptions)
+		require.NoError(t, err)
+		require.Equal(t, expectedOptions, actualOptions)
+	})
+
+	t.Run("parse, with custom currency", func(t *testing.T) {
+		t.Parallel()
+
+		notSignedTx := fmt.Sprintf(`{"nonce":42,"value":"0","receiver":"%s","sender":"%s","gasPrice":1000000000,"gasLimit":2000000,"data":"%s","chainID":"T","version":1}`,
+			contract,
+			testscommon.TestAddressAlice,
+			base64.StdEncoding.EncodeToString([]byte("ESDTTransfer@544553542d616263646566@64@616464@07")),
+		)
+
+		response, errTyped := service.ConstructionParse(context.Background(),
+			&types.ConstructionParseRequest{
+				Signed:      false,
+				Transaction: notSignedTx,
+			},
+		)
+
+		require.Nil(t, errTyped)
+		require.Equal(t, []*types.Operation{
+			{
+				OperationIdentifier: indexToOperationIdentifier(0),
+				Type:                opCustomTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressAlice),
+				Amount:              extension.valueToCustomAmount("-100", "TEST-abcdef"),
+			},
+			{
+				OperationIdentifier: indexToOperationIdentifier(1),
+				Type:                opCustomTransfer,
+				Account:             addressToAccountIdentifier(contract),
+				Amount:              extension.valueToCustomAmount("100", "TEST-abcdef"),
+			},
+			operations[2],
+		}, response.Operations)
+	})
+}
+
+func TestConstructionService_WithContractDeploy(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	networkProvider.MockAccountsByAddress[testscommon.TestAddressAlice] = &resources.Account{
+		Address: testscommon.TestAddressAlice,
+		Nonce:   42,
+	}
+	networkProvider.ComputeTransactionCostCalled = func(tx *data.Transaction) (*resources.TransactionCost, error) {
+		require.Equal(t, systemContractDeployAddress, tx.Receiver)
+		require.Equal(t, []byte("0061736d@0500@0500@2a"), tx.Data)
+		return &resources.TransactionCost{GasUnits: 5000000}, nil
+	}
+
+	extension := newNetworkProviderExtension(networkProvider)
+	service := createConstructionService(networkProvider)
+
+	operations := []*types.Operation{
+		{
+			OperationIdentifier: indexToOperationIdentifier(0),
+			Type:                opTransfer,
+			Account:             addressToAccountIdentifier(testscommon.TestAddressAlice),
+			Amount:              extension.valueToNativeAmount("-1234"),
+		},
+		{
+			OperationIdentifier: indexToOperationIdentifier(1),
+			Type:                opTransfer,
+			Account:             addressToAccountIdentifier(systemContractDeployAddress),
+			Amount:              extension.valueToNativeAmount("1234"),
+		},
+		{
+			OperationIdentifier: indexToOperationIdentifier(2),
+			Type:                opSmartContractDeploy,
+			Account:             addressToAccountIdentifier(testscommon.TestAddressAlice),
+			Metadata: objectsMap{
+				"code":         "0061736d",
+				"codeMetadata": "0500",
+				"arguments":    []interface{}{"2a"},
+			},
+		},
+	}
+
+	expectedOptions := &constructionOptions{
+		Sender:         testscommon.TestAddressAlice,
+		Amount:         "1234",
+		CurrencySymbol: "XeGLD",
+		ContractDeploy: &constructionContractDeploy{
+			Code:         "0061736d",
+			CodeMetadata: "0500",
+			Arguments:    []string{"2a"},
+		},
+	}
+
+	t.Run("preprocess", func(t *testing.T) {
+		t.Parallel()
+
+		response, errTyped := service.ConstructionPreprocess(context.Background(),
+			&types.ConstructionPreprocessRequest{
+				Operations: operations,
+				Metadata:   objectsMap{},
+			},
+		)
+
+		require.Nil(t, errTyped)
+
+		actualOptions := &constructionOptions{}
+		err := fromObjectsMap(response.Options, actualOptions)
+		require.NoError(t, err)
+		require.Equal(t, expectedOptions, actualOptions)
+	})
+
+	t.Run("metadata", func(t *testing.T) {
+		t.Parallel()
+
+		options, err := toObjectsMap(expectedOptions)
+		require.NoError(t, err)
+
+		response, errTyped := service.ConstructionMetadata(context.Background(),
+			&types.ConstructionMetadataRequest{
+				Options: options,
+			},
+		)
+
+		require.Nil(t, errTyped)
+
+		expectedMetadata := &constructionMetadata{
+			Sender:              testscommon.TestAddressAlice,
+			Receiver:            systemContractDeployAddress,
+			Nonce:               42,
+			Amount:              "1234",
+			CurrencySymbol:      "XeGLD",
+			GasLimit:            5000000,
+			GasPrice:            1000000000,
+			Data:                []byte("0061736d@0500@0500@2a"),
+			ChainID:             "T",
+			Version:             1,
+			GasEstimationMethod: gasEstimationMethodObserver,
+		}
+
+		actualMetadata := &constructionMetadata{}
+		err = fromObjectsMap(response.Metadata, actualMetadata)
+		require.NoError(t, err)
+
+		// (50000 + 1500 * 21) * 1000000000 + (5000000 - 81500) * 10000000
+		require.Equal(t, "130685000000000", response.SuggestedFee[0].Value)
+		require.Equal(t, expectedMetadata, actualMetadata)
+	})
+
+	t.Run("parse", func(t *testing.T) {
+		t.Parallel()
+
+		notSignedTx := fmt.Sprintf(`{"nonce":42,"value":"1234","receiver":"%s","sender":"%s","gasPrice":1000000000,"gasLimit":5000000,"data":"%s","chainID":"T","version":1}`,
+			systemContractDeployAddress,
+			testscommon.TestAddressAlice,
+			base64.StdEncoding.EncodeToString([]byte("0061736d@0500@0500@2a")),
+		)
+
+		response, errTyped := service.ConstructionParse(context.Background(),
+			&types.ConstructionParseRequest{
+				Signed:      false,
+				Transaction: notSignedTx,
+			},
+		)
+
+		require.Nil(t, errTyped)
+		require.Equal(t, operations, response.Operations)
+		_ = popIntentOfParsedMetadata(t, response.Metadata)
+		require.Equal(t, operations[2].Metadata, response.Metadata)
+	})
+}
+
+func TestConstructionService_WithDelegation(t *testing.T) {
+	t.Parallel()
+
+	delegationContract := "erd1qqqqqqqqqqqqqqqpqqqqqqqqqqqqqqqqqqqqqqqqqqqqqtllllls002zgc"
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	networkProvider.MockAccountsByAddress[testscommon.TestAddressAlice] = &resources.Account{
+		Address: testscommon.TestAddressAlice,
+		Nonce:   42,
+	}
+	networkProvider.ComputeTransactionCostCalled = func(_ *data.Transaction) (*resources.TransactionCost, error) {
+		return nil, errors.New("unexpected simulation: gas limits of delegation are taken from configuration")
+	}
+
+	extension := newNetworkProviderExtension(networkProvider)
+	service := createConstructionService(networkProvider)
+
+	operations := []*types.Operation{
+		{
+			OperationIdentifier: indexToOperationIdentifier(0),
+			Type:                opTransfer,
+			Account:             addressToAccountIdentifier(testscommon.TestAddressAlice),
+			Amount:              extension.valueToNativeAmount("-1000000000000000000"),
+		},
+		{
+			OperationIdentifier: indexToOperationIdentifier(1),
+			Type:                opTransfer,
+			Account:             addressToAccountIdentifier(delegationContract),
+			Amount:              extension.valueToNativeAmount("1000000000000000000"),
+		},
+		{
+			OperationIdentifier: indexToOperationIdentifier(2),
+			Type:                opDelegate,
+			Account:             addressToAccountIdentifier(testscommon.TestAddressAlice),
+			Metadata: objectsMap{
+				"function": "delegate",
+				"amount":   "1000000000000000000",
+				"contract": delegationContract,
+			},
+		},
+	}
+
+	expectedOptions := &constructionOptions{
+		Sender:   testscommon.TestAddressAlice,
+		Receiver: delegationContract,
+		Delegation: &constructionDelegation{
+			Function: "delegate",
+			Amount:   "1000000000000000000",
+		},
+	}
+
+	t.Run("preprocess, with operations", func(t *testing.T) {
+		t.Parallel()
+
+		response, errTyped := service.ConstructionPreprocess(context.Background(),
+			&types.ConstructionPreprocessRequest{
+				Operations: operations,
+				Metadata:   objectsMap{},
+			},
+		)
+
+		require.Nil(t, errTyped)
+
+		actualOptions := &constructionOptions{}
+		err := fromObjectsMap(response.Options, actualOptions)
+		require.NoError(t, err)
+		require.Equal(t, expectedOptions, actualOptions)
+	})
+
+	t.Run("preprocess, amount given by transfer operations", func(t *testing.T) {
+		t.Parallel()
+
+		response, errTyped := service.ConstructionPreprocess(context.Background(),
+			&types.ConstructionPreprocessRequest{
+				Operations: []*types.Operation{
+					operations[0],
+					operations[1],
+					{
+						OperationIdentifier: indexToOperationIdentifier(2),
+						Type:                opDelegate,
+						Account:             addressToAccountIdentifier(testscommon.TestAddressAlice),
+						Metadata: objectsMap{
+							"contract": delegationContract,
+						},
+					},
+				},
+				Metadata: objectsMap{},
+			},
+		)
+
+		require.Nil(t, errTyped)
+
+		actualOptions := &constructionOptions{}
+		err := fromObjectsMap(response.Options, actualOptions)
+		require.NoError(t, err)
+		require.Equal(t, expectedOptions, actualOptions)
+	})
+
+	t.Run("preprocess, create delegation contract (with metadata)", func(t *testing.T) {
+		t.Parallel()
+
+		response, errTyped := service.ConstructionPreprocess(context.Background(),
+			&types.ConstructionPreprocessRequest{
+				Metadata: objectsMap{
+					"sender": testscommon.TestAddressAlice,
+					"delegation": objectsMap{
+						"function":           "createNewDelegationContract",
+						"amount":             "1250000000000000000000",
+						"totalDelegationCap": "0",
+						"serviceFee":         "1000",
+					},
+				},
+			},
+		)
+
+		require.Nil(t, errTyped)
+
+		actualOptions := &constructionOptions{}
+		err := fromObjectsMap(response.Options, actualOptions)
+		require.NoError(t, err)
+		require.Equal(t, &constructionOptions{
+			Sender:   testscommon.TestAddressAlice,
+			Receiver: delegationManagerContractAddress,
+			Delegation: &constructionDelegation{
+				Function:           "createNewDelegationContract",
+				Amount:             "1250000000000000000000",
+				TotalDelegationCap: "0",
+				ServiceFee:         "1000",
+			},
+		}, actualOptions)
+	})
+
+	t.Run("preprocess, with bad delegation", func(t *testing.T) {
+		t.Parallel()
+
+		_, errTyped := service.ConstructionPreprocess(context.Background(),
+			&types.ConstructionPreprocessRequest{
+				Operations: operations[2:],
+				Metadata: objectsMap{
+					"delegation": objectsMap{
+						"function": "delegate",
+					},
+				},
+			},
+		)
+
+		require.Equal(t, ErrConstruction, errCode(errTyped.Code))
+		require.Contains(t, errTyped.Details["originalError"], "missing option: 'delegation.amount'")
+	})
+
+	t.Run("metadata", func(t *testing.T) {
+		t.Parallel()
+
+		options, err := toObjectsMap(expectedOptions)
+		require.NoError(t, err)
+
+		response, errTyped := service.ConstructionMetadata(context.Background(),
+			&types.ConstructionMetadataRequest{
+				Options: options,
+			},
+		)
+
+		require.Nil(t, errTyped)
+
+		expectedMetadata := &constructionMetadata{
+			Sender:              testscommon.TestAddressAlice,
+			Receiver:            delegationContract,
+			Nonce:               42,
+			Amount:              "1000000000000000000",
+			GasLimit:            12000000,
+			GasPrice:            1000000000,
+			Data:                []byte("delegate"),
+			ChainID:             "T",
+			Version:             1,
+			GasEstimationMethod: gasEstimationMethodConfiguration,
+		}
+
+		actualMetadata := &constructionMetadata{}
+		err = fromObjectsMap(response.Metadata, actualMetadata)
+		require.NoError(t, err)
+
+		// (50000 + 1500 * 8) * 1000000000 + (12000000 - 62000) * 10000000
+		require.Equal(t, "181380000000000", response.SuggestedFee[0].Value)
+		require.Equal(t, expectedMetadata, actualMetadata)
+	})
+
+	t.Run("metadata, undelegate, guarded", func(t *testing.T) {
+		t.Parallel()
+
+		response, errTyped := service.ConstructionMetadata(context.Background(),
+			&types.ConstructionMetadataRequest{
+				Options: objectsMap{
+					"sender":   testscommon.TestAddressAlice,
+					"receiver": delegationContract,
+					"guardian": testscommon.TestAddressCarol,
+					"delegation": objectsMap{
+						"function": "unDelegate",
+						"amount":   "1000000000000000000",
+					},
+				},
+			},
+		)
+
+		require.Nil(t, errTyped)
+
+		actualMetadata := &constructionMetadata{}
+		err := fromObjectsMap(response.Metadata, actualMetadata)
+		require.NoError(t, err)
+
+		// (50000 + 1500 * 27 + 50000) * 1000000000 + (12000000 - 90500) * 10000000
+		require.Equal(t, "259595000000000", response.SuggestedFee[0].Value)
+		require.Equal(t, uint64(12050000), actualMetadata.GasLimit)
+		require.Equal(t, "0", actualMetadata.Amount)
+		require.Equal(t, []byte("unDelegate@0de0b6b3a7640000"), actualMetadata.Data)
+	})
+
+	t.Run("metadata, with explicit gas limit", func(t *testing.T) {
+		t.Parallel()
+
+		response, errTyped := service.ConstructionMetadata(context.Background(),
+			&types.ConstructionMetadataRequest{
+				Options: objectsMap{
+					"sender":   testscommon.TestAddressAlice,
+					"receiver": delegationContract,
+					"gasLimit": 5000000,
+					"delegation": objectsMap{
+						"function": "claimRewards",
+					},
+				},
+			},
+		)
+
+		require.Nil(t, errTyped)
+
+		actualMetadata := &constructionMetadata{}
+		err := fromObjectsMap(response.Metadata, actualMetadata)
+		require.NoError(t, err)
+
+		// (50000 + 1500 * 12) * 1000000000 + (5000000 - 68000) * 10000000
+		require.Equal(t, "117320000000000", response.SuggestedFee[0].Value)
+		require.Equal(t, uint64(5000000), actualMetadata.GasLimit)
+		require.Equal(t, gasEstimationMethodProvided, actualMetadata.GasEstimationMethod)
+		require.Equal(t, []byte("claimRewards"), actualMetadata.Data)
+	})
+
+	t.Run("metadata, with receiver not a delegation contract", func(t *testing.T) {
+		t.Parallel()
+
+		_, errTyped := service.ConstructionMetadata(context.Background(),
+			&types.ConstructionMetadataRequest{
+				Options: objectsMap{
+					"sender":   testscommon.TestAddressAlice,
+					"receiver": testscommon.TestContractFooShard0.Address,
+					"delegation": objectsMap{
+						"function": "claimRewards",
+					},
+				},
+			},
+		)
+
+		require.Equal(t, ErrConstruction, errCode(errTyped.Code))
+		require.Contains(t, errTyped.Details["originalError"], "the receiver of a delegation transaction must be a delegation contract (or the delegation manager)")
+	})
+
+	t.Run("parse", func(t *testing.T) {
+		t.Parallel()
+
+		notSignedTx := fmt.Sprintf(`{"nonce":42,"value":"1000000000000000000","receiver":"%s","sender":"%s","gasPrice":1000000000,"gasLimit":12000000,"data":"%s","chainID":"T","version":1}`,
+			delegationContract,
+			testscommon.TestAddressAlice,
+			base64.StdEncoding.EncodeToString([]byte("delegate")),
+		)
+
+		response, errTyped := service.ConstructionParse(context.Background(),
+			&types.ConstructionParseRequest{
+				Signed:      false,
+				Transaction: notSignedTx,
+			},
+		)
+
+		require.Nil(t, errTyped)
+		require.Equal(t, operations, response.Operations)
+		_ = popIntentOfParsedMetadata(t, response.Metadata)
+		require.Equal(t, operations[2].Metadata, response.Metadata)
+
+		// Round-trip
+		preprocessResponse, errTyped := service.ConstructionPreprocess(context.Background(),
+			&types.ConstructionPreprocessRequest{
+				Operations: response.Operations,
+				Metadata:   objectsMap{},
+			},
+		)
+
+		require.Nil(t, errTyped)
+
+		actualOptions := &constructionOptions{}
+		err := fromObjectsMap(preprocessResponse.Options, actualOptions)
+		require.NoError(t, err)
+		require.Equal(t, expectedOptions, actualOptions)
+	})
+
+	t.Run("parse, claim rewards", func(t *testing.T) {
+		t.Parallel()
+
+		notSignedTx := fmt.Sprintf(`{"nonce":42,"value":"0","receiver":"%s","sender":"%s","gasPrice":1000000000,"gasLimit":6000000,"data":"%s","chainID":"T","version":1}`,
+			delegationContract,
+			testscommon.TestAddressAlice,
+			base64.StdEncoding.EncodeToString([]byte("claimRewards")),
+		)
+
+		response, errTyped := service.ConstructionParse(context.Background(),
+			&types.ConstructionParseRequest{
+				Signed:      false,
+				Transaction: notSignedTx,
+			},
+		)
+
+		require.Nil(t, errTyped)
+		require.Equal(t, []*types.Operation{
+			{
+				OperationIdentifier: indexToOperationIdentifier(0),
+				Type:                opClaimRewards,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressAlice),
+				Metadata: objectsMap{
+					"function": "claimRewards",
+					"contract": delegationContract,
+				},
+			},
+		}, response.Operations)
+	})
+
+	t.Run("parse, create delegation contract", func(t *testing.T) {
+		t.Parallel()
+
+		notSignedTx := fmt.Sprintf(`{"nonce":42,"value":"1250000000000000000000","receiver":"%s","sender":"%s","gasPrice":1000000000,"gasLimit":60000000,"data":"%s","chainID":"T","version":1}`,
+			delegationManagerContractAddress,
+			testscommon.TestAddressAlice,
+			base64.StdEncoding.EncodeToString([]byte("createNewDelegationContract@@03e8")),
+		)
+
+		response, errTyped := service.ConstructionParse(context.Background(),
+			&types.ConstructionParseRequest{
+				Signed:      false,
+				Transaction: notSignedTx,
+			},
+		)
+
+		require.Nil(t, errTyped)
+		require.Equal(t, []*types.Operation{
+			{
+				OperationIdentifier: indexToOperationIdentifier(0),
+				Type:                opTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressAlice),
+				Amount:              extension.valueToNativeAmount("-1250000000000000000000"),
+			},
+			{
+				OperationIdentifier: indexToOperationIdentifier(1),
+				Type:                opTransfer,
+				Account:             addressToAccountIdentifier(delegationManagerContractAddress),
+				Amount:              extension.valueToNativeAmount("1250000000000000000000"),
+			},
+			{
+				OperationIdentifier: indexToOperationIdentifier(2),
+				Type:                opCreateDelegationContract,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressAlice),
+				Metadata: objectsMap{
+					"function":           "createNewDelegationContract",
+					"amount":             "1250000000000000000000",
+					"totalDelegationCap": "0",
+					"serviceFee":         "1000",
+					"contract":           delegationManagerContractAddress,
+				},
+			},
+		}, response.Operations)
+	})
+
+	t.Run("parse, call of a regular contract (not a delegation)", func(t *testing.T) {
+		t.Parallel()
+
+		notSignedTx := fmt.Sprintf(`{"nonce":42,"value":"0","receiver":"%s","sender":"%s","gasPrice":1000000000,"gasLimit":6000000,"data":"%s","chainID":"T","version":1}`,
+			testscommon.TestContractFooShard0.Address,
+			testscommon.TestAddressAlice,
+			base64.StdEncoding.EncodeToString([]byte("withdraw")),
+		)
+
+		response, errTyped := service.ConstructionParse(context.Background(),
+			&types.ConstructionParseRequest{
+				Signed:      false,
+				Transaction: notSignedTx,
+			},
+		)
+
+		require.Nil(t, errTyped)
+		require.Len(t, response.Operations, 1)
+		require.Equal(t, opSmartContractCall, response.Operations[0].Type)
+	})
+}
+
+func TestConstructionService_WithReceiverUsername(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	networkProvider.MockCustomCurrencies = []resources.Currency{{Symbol: "TEST-abcdef"}, {Symbol: "NFT-abcdef"}}
+	networkProvider.MockAccountsByAddress[testscommon.TestAddressAlice] = &resources.Account{
+		Address: testscommon.TestAddressAlice,
+		Nonce:   42,
+	}
+	networkProvider.MockAddressesByUsername["bob.elrond"] = testscommon.TestAddressBob
+
+	extension := newNetworkProviderExtension(networkProvider)
+	service := createConstructionService(networkProvider)
+
+	expectedOptions := &constructionOptions{
+		Sender:           testscommon.TestAddressAlice,
+		Receiver:         testscommon.TestAddressBob,
+		Amount:           "1234",
+		CurrencySymbol:   "XeGLD",
+		ReceiverUsername: "bob.elrond",
+	}
+
+	t.Run("preprocess, with herotag in operations", func(t *testing.T) {
+		t.Parallel()
+
+		response, errTyped := service.ConstructionPreprocess(context.Background(),
+			&types.ConstructionPreprocessRequest{
+				Operations: []*types.Operation{
+					{
+						OperationIdentifier: indexToOperationIdentifier(0),
+						Type:                opTransfer,
+						Account:             addressToAccountIdentifier(testscommon.TestAddressAlice),
+						Amount:              extension.valueToNativeAmount("-1234"),
+					},
+					{
+						OperationIdentifier: indexToOperationIdentifier(1),
+						Type:                opTransfer,
+						Account:             addressToAccountIdentifier("@bob"),
+						Amount:              extension.valueToNativeAmount("1234"),
+					},
+				},
+				Metadata: objectsMap{},
+			},
+		)
+
+		require.Nil(t, errTyped)
+
+		actualOptions := &constructionOptions{}
+		err := fromObjectsMap(response.Options, actualOptions)
+		require.NoError(t, err)
+		require.Equal(t, expectedOptions, actualOptions)
+	})
+
+	t.Run("preprocess, with username in metadata", func(t *testing.T) {
+		t.Parallel()
+
+		response, errTyped := service.ConstructionPreprocess(context.Background(),
+			&types.ConstructionPreprocessRequest{
+				Metadata: objectsMap{
+					"sender":         testscommon.TestAddressAlice,
+					"receiver":       "bob.elrond",
+					"amount":         "1234",
+					"currencySymbol": "XeGLD",
+				},
+			},
+		)
+
+		require.Nil(t, errTyped)
+
+		actualOptions := &constructionOptions{}
+		err := fromObjectsMap(response.Options, actualOptions)
+		require.NoError(t, err)
+		require.Equal(t, expectedOptions, actualOptions)
+	})
+
+	t.Run("preprocess, with unknown username", func(t *testing.T) {
+		t.Parallel()
+
+		_, errTyped := service.ConstructionPreprocess(context.Background(),
+			&types.ConstructionPreprocessRequest{
+				Metadata: objectsMap{
+					"sender":         testscommon.TestAddressAlice,
+					"receiver":       "@nobody",
+					"amount":         "1234",
+					"currencySymbol": "XeGLD",
+				},
+			},
+		)
+
+		require.Equal(t, ErrUnableToResolveUsername, errCode(errTyped.Code))
+		require.False(t, errTyped.Retriable)
+		require.Contains(t, errTyped.Details["originalError"], "username not found: nobody.elrond")
+	})
+
+	t.Run("preprocess, with failed DNS query", func(t *testing.T) {
+		t.Parallel()
+
+		failingNetworkProvider := testscommon.NewNetworkProviderMock()
+		failingNetworkProvider.MockNextError = errors.New("observer unreachable")
+		failingService := createConstructionService(failingNetworkProvider)
+
+		_, errTyped := failingService.ConstructionPreprocess(context.Background(),
+			&types.ConstructionPreprocessRequest{
+				Metadata: objectsMap{
+					"sender":         testscommon.TestAddressAlice,
+					"receiver":       "@bob",
+					"amount":         "1234",
+					"currencySymbol": "XeGLD",
+				},
+			},
+		)
+
+		require.Equal(t, ErrUnableToQueryUsername, errCode(errTyped.Code))
+		require.True(t, errTyped.Retriable)
+		require.Contains(t, errTyped.Details["originalError"], "observer unreachable")
+	})
+
+	t.Run("preprocess, with bad username", func(t *testing.T) {
+		t.Parallel()
+
+		_, errTyped := service.ConstructionPreprocess(context.Background(),
+			&types.ConstructionPreprocessRequest{
+				Metadata: objectsMap{
+					"sender":         testscommon.TestAddressAlice,
+					"receiver":       "@b-o-b",
+					"amount":         "1234",
+					"currencySymbol": "XeGLD",
+				},
+			},
+		)
+
+		require.Equal(t, ErrConstruction, errCode(errTyped.Code))
+		require.Contains(t, errTyped.Details["originalError"], "bad username: '@b-o-b' (only letters and digits are allowed)")
+	})
+
+	t.Run("preprocess, in offline mode", func(t *testing.T) {
+		t.Parallel()
+
+		offlineNetworkProvider := testscommon.NewNetworkProviderMock()
+		offlineNetworkProvider.MockIsOffline = true
+		offlineService := createConstructionService(offlineNetworkProvider)
+
+		_, errTyped := offlineService.ConstructionPreprocess(context.Background(),
+			&types.ConstructionPreprocessRequest{
+				Metadata: objectsMap{
+					"sender":         testscommon.TestAddressAlice,
+					"receiver":       "@bob",
+					"amount":         "1234",
+					"currencySymbol": "XeGLD",
+				},
+			},
+		)
+
+		require.Equal(t, ErrOfflineMode, errCode(errTyped.Code))
+		require.Contains(t, errTyped.Details["originalError"], "cannot resolve username '@bob' in offline mode")
+	})
+
+	t.Run("metadata, payloads and parse", func(t *testing.T) {
+		t.Parallel()
+
+		metadataResponse, errTyped := service.ConstructionMetadata(context.Background(),
+			&types.ConstructionMetadataRequest{
+				Options: objectsMap{
+					"sender":         testscommon.TestAddressAlice,
+					"receiver":       "@bob",
+					"amount":         "1234",
+					"currencySymbol": "XeGLD",
+				},
+			},
+		)
+
+		require.Nil(t, errTyped)
+
+		actualMetadata := &constructionMetadata{}
+		err := fromObjectsMap(metadataResponse.Metadata, actualMetadata)
+		require.NoError(t, err)
+		require.Equal(t, testscommon.TestAddressBob, actualMetadata.Receiver)
+		require.Equal(t, "bob.elrond", actualMetadata.ReceiverUsername)
+
+		payloadsResponse, errTyped := service.ConstructionPayloads(context.Background(),
+			&types.ConstructionPayloadsRequest{
+				Metadata: metadataResponse.Metadata,
+			},
+		)
+
+		require.Nil(t, errTyped)
+		require.Contains(t, payloadsResponse.UnsignedTransaction, fmt.Sprintf(`"receiverUsername":"%s"`, base64.StdEncoding.EncodeToString([]byte("bob.elrond"))))
+
+		parseResponse, errTyped := service.ConstructionParse(context.Background(),
+			&types.ConstructionParseRequest{
+				Signed:      false,
+				Transaction: payloadsResponse.UnsignedTransaction,
+			},
+		)
+
+		require.Nil(t, errTyped)
+		require.Equal(t, testscommon.TestAddressBob, parseResponse.Operations[1].Account.Address)
+		require.Equal(t, testscommon.TestAddressBob, popIntentOfParsedMetadata(t, parseResponse.Metadata).Receiver)
+		require.Equal(t, map[string]interface{}{
+			"receiverUsername": "bob.elrond",
+			"resolvedReceiver": testscommon.TestAddressBob,
+		}, parseResponse.Metadata)
+	})
+
+	t.Run("metadata, multi-token transfer (the username is not set on the transaction)", func(t *testing.T) {
+		t.Parallel()
+
+		response, errTyped := service.ConstructionMetadata(context.Background(),
+			&types.ConstructionMetadataRequest{
+				Options: objectsMap{
+					"sender":   testscommon.TestAddressAlice,
+					"receiver": "@bob",
+					"transfers": []objectsMap{
+						{"amount": "1", "currencySymbol": "XeGLD"},
+						{"amount": "2", "currencySymbol": "TEST-abcdef"},
+					},
 				},
 			},
-		},
-	)
+		)
 
-	signedTx := `{"nonce":42,"value":"1234","receiver":"erd1spyavw0956vq68xj8y4tenjpq2wd5a9p2c6j8gsz7ztyrnpxrruqzu66jx","sender":"erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th","gasPrice":1100000000,"gasLimit":57500,"data":"aGVsbG8=","signature":"aabb","chainID":"T","version":1}`
+		require.Nil(t, errTyped)
 
-	require.Nil(t, errTyped)
-	require.Equal(t, signedTx, response.SignedTransaction)
+		actualMetadata := &constructionMetadata{}
+		err := fromObjectsMap(response.Metadata, actualMetadata)
+		require.NoError(t, err)
+		require.Equal(t, testscommon.TestAddressAlice, actualMetadata.Receiver)
+		require.Empty(t, actualMetadata.ReceiverUsername)
+	})
 }
 
 func TestConstructionService_ConstructionDerive(t *testing.T) {
 	t.Parallel()
 
 	networkProvider := testscommon.NewNetworkProviderMock()
-	service := NewConstructionService(networkProvider)
+	service := createConstructionService(networkProvider)
 
 	response, errTyped := service.ConstructionDerive(context.Background(),
 		&types.ConstructionDeriveRequest{
@@ -604,7 +2714,7 @@ func TestConstructionService_ConstructionHash(t *testing.T) {
 
 	networkProvider := testscommon.NewNetworkProviderMock()
 	networkProvider.MockComputedTransactionHash = "aaaa"
-	service := NewConstructionService(networkProvider)
+	service := createConstructionService(networkProvider)
 
 	signedTx := `{"nonce":42,"value":"1234","receiver":"erd1spyavw0956vq68xj8y4tenjpq2wd5a9p2c6j8gsz7ztyrnpxrruqzu66jx","sender":"erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th","gasPrice":1100000000,"gasLimit":57500,"data":"aGVsbG8=","signature":"aabb","chainID":"T","version":1}`
 
@@ -628,7 +2738,7 @@ func TestConstructionService_ConstructionSubmit(t *testing.T) {
 		return "aaaa", nil
 	}
 
-	service := NewConstructionService(networkProvider)
+	service := createConstructionService(networkProvider)
 
 	signedTx := `{"nonce":42,"value":"1234","receiver":"erd1spyavw0956vq68xj8y4tenjpq2wd5a9p2c6j8gsz7ztyrnpxrruqzu66jx","sender":"erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th","gasPrice":1100000000,"gasLimit":57500,"data":"aGVsbG8=","signature":"aabb","chainID":"T","version":1}`
 
@@ -643,12 +2753,97 @@ func TestConstructionService_ConstructionSubmit(t *testing.T) {
 	require.Equal(t, uint64(42), calledWithTransaction.Nonce)
 }
 
+func TestConstructionService_ConstructionSubmit_WithSimulation(t *testing.T) {
+	t.Parallel()
+
+	signedTx := `{"nonce":42,"value":"0","receiver":"erd1spyavw0956vq68xj8y4tenjpq2wd5a9p2c6j8gsz7ztyrnpxrruqzu66jx","sender":"erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th","gasPrice":1000000000,"gasLimit":500000,"data":"RVNEVFRyYW5zZmVyQDU0NDU1MzU0MmQ2MTYyNjM2NDY1NjZANjQ=","signature":"aabb","chainID":"T","version":1}`
+
+	t.Run("when simulation predicts success", func(t *testing.T) {
+		t.Parallel()
+
+		networkProvider := testscommon.NewNetworkProviderMock()
+		networkProvider.MockNetworkConfig.ShouldSimulateBeforeSubmit = true
+		networkProvider.MockComputedTransactionHash = "aaaa"
+		service := createConstructionService(networkProvider)
+
+		response, errTyped := service.ConstructionSubmit(context.Background(),
+			&types.ConstructionSubmitRequest{
+				SignedTransaction: signedTx,
+			},
+		)
+		require.Nil(t, errTyped)
+		require.Equal(t, "aaaa", response.TransactionIdentifier.Hash)
+	})
+
+	t.Run("when simulation predicts failure", func(t *testing.T) {
+		t.Parallel()
+
+		networkProvider := testscommon.NewNetworkProviderMock()
+		networkProvider.MockNetworkConfig.ShouldSimulateBeforeSubmit = true
+		networkProvider.MockSimulationResults = &transaction.SimulationResults{
+			Status: transaction.TxStatusFail,
+			Logs: &transaction.ApiLogs{
+				Events: []*transaction.Events{
+					{
+						Address:    testscommon.TestAddressAlice,
+						Identifier: "signalError",
+						Topics:     [][]byte{testscommon.TestPubKeyAlice, []byte("insufficient funds")},
+					},
+				},
+			},
+		}
+		networkProvider.SendTransactionCalled = func(tx *data.Transaction) (string, error) {
+			require.Fail(t, "transaction should not be broadcasted")
+			return "", nil
+		}
+
+		service := createConstructionService(networkProvider)
+
+		response, errTyped := service.ConstructionSubmit(context.Background(),
+			&types.ConstructionSubmitRequest{
+				SignedTransaction: signedTx,
+			},
+		)
+		require.Nil(t, response)
+		require.Equal(t, int32(ErrTransactionWouldFail), errTyped.Code)
+		require.False(t, errTyped.Retriable)
+		require.Equal(t, "simulation predicts failure: insufficient funds", errTyped.Details["originalError"])
+		require.Equal(t, "insufficient funds", errTyped.Details["vmError"])
+		require.Equal(t, objectsMap{
+			"address":    testscommon.TestAddressAlice,
+			"identifier": "signalError",
+			"topics":     []string{hex.EncodeToString(testscommon.TestPubKeyAlice), hex.EncodeToString([]byte("insufficient funds"))},
+			"data":       "",
+		}, errTyped.Details["failingEvent"])
+	})
+
+	t.Run("when simulation cannot be performed", func(t *testing.T) {
+		t.Parallel()
+
+		networkProvider := testscommon.NewNetworkProviderMock()
+		networkProvider.MockNetworkConfig.ShouldSimulateBeforeSubmit = true
+		networkProvider.SimulateTransactionCalled = func(tx *data.Transaction) (*transaction.SimulationResults, error) {
+			return nil, errors.New("observer is down")
+		}
+
+		service := createConstructionService(networkProvider)
+
+		_, errTyped := service.ConstructionSubmit(context.Background(),
+			&types.ConstructionSubmitRequest{
+				SignedTransaction: signedTx,
+			},
+		)
+		require.Equal(t, int32(ErrUnableToSubmitTransaction), errTyped.Code)
+		require.True(t, errTyped.Retriable)
+	})
+}
+
 func TestConstructionService_CreateOperationsFromPreparedTx(t *testing.T) {
 	t.Parallel()
 
 	networkProvider := testscommon.NewNetworkProviderMock()
 	extension := newNetworkProviderExtension(networkProvider)
-	service := NewConstructionService(networkProvider).(*constructionService)
+	service := createConstructionService(networkProvider)
 
 	preparedTx := &data.Transaction{
 		Value:    "12345",
@@ -671,7 +2866,359 @@ func TestConstructionService_CreateOperationsFromPreparedTx(t *testing.T) {
 		},
 	}
 
-	operations, err := service.createOperationsFromPreparedTx(preparedTx)
+	parsed, err := service.parsePreparedTx(preparedTx)
+	require.Nil(t, err)
+
+	operations, metadata, err := service.createOperationsFromPreparedTx(preparedTx, parsed)
 	require.Nil(t, err)
 	require.Equal(t, expectedOperations, operations)
+	require.Nil(t, metadata)
+}
+
+func TestParseNonFungibleCustomCurrencyTransfer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("with valid data", func(t *testing.T) {
+		tokenIdentifier, amount, receiverPubKey, err := parseNonFungibleCustomCurrencyTransfer("ESDTNFTTransfer@4e46542d616263646566@0100@04d2@8049d639e5a6980d1cd2392abcce41029cda74a1563523a202f09641cc2618f8")
+		require.Nil(t, err)
+		require.Equal(t, "NFT-abcdef-0100", tokenIdentifier)
+		require.Equal(t, "1234", amount)
+		require.Equal(t, testscommon.TestPubKeyBob, receiverPubKey)
+	})
+
+	t.Run("with missing receiver", func(t *testing.T) {
+		_, _, _, err := parseNonFungibleCustomCurrencyTransfer("ESDTNFTTransfer@4e46542d616263646566@0a@04d2")
+		require.ErrorContains(t, err, "cannot parse data of non-fungible custom currency transfer")
+	})
+
+	t.Run("with zero nonce", func(t *testing.T) {
+		_, _, _, err := parseNonFungibleCustomCurrencyTransfer("ESDTNFTTransfer@4e46542d616263646566@@04d2@8049d639e5a6980d1cd2392abcce41029cda74a1563523a202f09641cc2618f8")
+		require.ErrorContains(t, err, "cannot decode custom token nonce")
+	})
+}
+
+func TestConstructionService_PreprocessAndMetadata_WithMaxFeeAndFeeMultiplier(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	networkProvider.MockAccountsByAddress[testscommon.TestAddressAlice] = &resources.Account{
+		Address: testscommon.TestAddressAlice,
+		Nonce:   42,
+	}
+
+	extension := newNetworkProviderExtension(networkProvider)
+	service := createConstructionService(networkProvider)
+
+	operations := []*types.Operation{
+		{
+			OperationIdentifier: indexToOperationIdentifier(0),
+			Type:                opTransfer,
+			Account:             addressToAccountIdentifier(testscommon.TestAddressAlice),
+			Amount:              extension.valueToNativeAmount("-1234"),
+		},
+		{
+			OperationIdentifier: indexToOperationIdentifier(1),
+			Type:                opTransfer,
+			Account:             addressToAccountIdentifier(testscommon.TestAddressBob),
+			Amount:              extension.valueToNativeAmount("1234"),
+		},
+	}
+
+	preprocessThenGetMetadata := func(maxFee []*types.Amount, feeMultiplier float64) (*types.ConstructionMetadataResponse, *types.Error) {
+		preprocessResponse, errTyped := service.ConstructionPreprocess(context.Background(),
+			&types.ConstructionPreprocessRequest{
+				Operations:             operations,
+				Metadata:               objectsMap{},
+				MaxFee:                 maxFee,
+				SuggestedFeeMultiplier: &feeMultiplier,
+			},
+		)
+		if errTyped != nil {
+			return nil, errTyped
+		}
+
+		return service.ConstructionMetadata(context.Background(),
+			&types.ConstructionMetadataRequest{
+				Options: preprocessResponse.Options,
+			},
+		)
+	}
+
+	t.Run("fee within max fee", func(t *testing.T) {
+		t.Parallel()
+
+		response, errTyped := preprocessThenGetMetadata([]*types.Amount{extension.valueToNativeAmount("100000000000000")}, 1.5)
+		require.Nil(t, errTyped)
+		require.Equal(t, "75000000000000", response.SuggestedFee[0].Value)
+		require.Equal(t, float64(1500000000), response.Metadata["gasPrice"])
+	})
+
+	t.Run("fee exceeding max fee", func(t *testing.T) {
+		t.Parallel()
+
+		_, errTyped := preprocessThenGetMetadata([]*types.Amount{extension.valueToNativeAmount("50000000000000")}, 1.5)
+		require.Equal(t, int32(ErrMaxFeeExceeded), errTyped.Code)
+	})
+
+	t.Run("max fee in a custom currency", func(t *testing.T) {
+		t.Parallel()
+
+		maxFee := []*types.Amount{{Value: "100000000000000", Currency: &types.Currency{Symbol: "TEST-abcdef"}}}
+		_, errTyped := preprocessThenGetMetadata(maxFee, 1)
+		require.Equal(t, int32(ErrConstruction), errTyped.Code)
+		require.Contains(t, errTyped.Details["originalError"], "native currency")
+	})
+
+	t.Run("max fee with multiple amounts", func(t *testing.T) {
+		t.Parallel()
+
+		maxFee := []*types.Amount{extension.valueToNativeAmount("1"), extension.valueToNativeAmount("2")}
+		_, errTyped := preprocessThenGetMetadata(maxFee, 1)
+		require.Equal(t, int32(ErrConstruction), errTyped.Code)
+	})
+
+	t.Run("with bad max fee", func(t *testing.T) {
+		t.Parallel()
+
+		_, errTyped := preprocessThenGetMetadata([]*types.Amount{extension.valueToNativeAmount("-1")}, 1)
+		require.Equal(t, int32(ErrConstruction), errTyped.Code)
+	})
+
+	t.Run("with bad fee multiplier", func(t *testing.T) {
+		t.Parallel()
+
+		_, errTyped := preprocessThenGetMetadata(nil, 0)
+		require.Equal(t, int32(ErrConstruction), errTyped.Code)
+		require.Contains(t, errTyped.Details["originalError"], "suggested_fee_multiplier")
+	})
+}
+
+func TestConstructionService_ConstructionMetadata_InOfflineMode(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	networkProvider.MockIsOffline = true
+	networkProvider.MockCustomCurrencies = []resources.Currency{{Symbol: "TEST-abcdef"}}
+
+	service := createConstructionService(networkProvider)
+
+	t.Run("nonce provided through preprocess", func(t *testing.T) {
+		t.Parallel()
+
+		preprocessResponse, errTyped := service.ConstructionPreprocess(context.Background(),
+			&types.ConstructionPreprocessRequest{
+				Metadata: objectsMap{
+					"sender":         testscommon.TestAddressAlice,
+					"receiver":       testscommon.TestAddressBob,
+					"amount":         "1234",
+					"currencySymbol": "XeGLD",
+					"nonce":          0,
+					"gasPrice":       1500000000,
+				},
+			},
+		)
+		require.Nil(t, errTyped)
+
+		response, errTyped := service.ConstructionMetadata(context.Background(),
+			&types.ConstructionMetadataRequest{
+				Options: preprocessResponse.Options,
+			},
+		)
+		require.Nil(t, errTyped)
+
+		expectedMetadata := &constructionMetadata{
+			Sender:              testscommon.TestAddressAlice,
+			Receiver:            testscommon.TestAddressBob,
+			Nonce:               0,
+			Amount:              "1234",
+			CurrencySymbol:      "XeGLD",
+			GasLimit:            50000,
+			GasPrice:            1500000000,
+			ChainID:             "T",
+			Version:             1,
+			GasEstimationMethod: gasEstimationMethodStatic,
+			Unchecked:           true,
+		}
+
+		actualMetadata := &constructionMetadata{}
+		err := fromObjectsMap(response.Metadata, actualMetadata)
+		require.NoError(t, err)
+		require.Equal(t, expectedMetadata, actualMetadata)
+		require.Equal(t, "75000000000000", response.SuggestedFee[0].Value)
+	})
+
+	t.Run("custom currency, with minimum gas price", func(t *testing.T) {
+		t.Parallel()
+
+		response, errTyped := service.ConstructionMetadata(context.Background(),
+			&types.ConstructionMetadataRequest{
+				Options: objectsMap{
+					"sender":         testscommon.TestAddressAlice,
+					"receiver":       testscommon.TestAddressBob,
+					"amount":         "100",
+					"currencySymbol": "TEST-abcdef",
+					"nonce":          7,
+				},
+			},
+		)
+		require.Nil(t, errTyped)
+
+		actualMetadata := &constructionMetadata{}
+		err := fromObjectsMap(response.Metadata, actualMetadata)
+		require.NoError(t, err)
+		require.Equal(t, uint64(7), actualMetadata.Nonce)
+		require.Equal(t, uint64(307000), actualMetadata.GasLimit)
+		require.Equal(t, uint64(1000000000), actualMetadata.GasPrice)
+		require.True(t, actualMetadata.Unchecked)
+		require.Empty(t, actualMetadata.Guardian)
+		// 107000 * 1000000000 + 200000 * 10000000
+		require.Equal(t, "109000000000000", response.SuggestedFee[0].Value)
+	})
+
+	t.Run("without nonce", func(t *testing.T) {
+		t.Parallel()
+
+		_, errTyped := service.ConstructionMetadata(context.Background(),
+			&types.ConstructionMetadataRequest{
+				Options: objectsMap{
+					"sender":         testscommon.TestAddressAlice,
+					"receiver":       testscommon.TestAddressBob,
+					"amount":         "1234",
+					"currencySymbol": "XeGLD",
+				},
+			},
+		)
+
+		require.Equal(t, ErrOfflineMode, errCode(errTyped.Code))
+		require.Contains(t, errTyped.Details["originalError"], "option 'nonce' is required in offline mode")
+	})
+
+	t.Run("contract call, without gas limit", func(t *testing.T) {
+		t.Parallel()
+
+		_, errTyped := service.ConstructionMetadata(context.Background(),
+			&types.ConstructionMetadataRequest{
+				Options: objectsMap{
+					"sender":   testscommon.TestAddressAlice,
+					"receiver": testscommon.TestContractFooShard0.Address,
+					"nonce":    7,
+					"contractCall": objectsMap{
+						"function": "add",
+					},
+				},
+			},
+		)
+
+		require.Equal(t, ErrUnableToEstimateGasLimit, errCode(errTyped.Code))
+	})
+}
+
+func TestConstructionService_ConstructionMetadata_WithProvidedNonce(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	networkProvider.MockNetworkConfig.ShouldReserveNonces = true
+	networkProvider.MockAccountsByAddress[testscommon.TestAddressAlice] = &resources.Account{
+		Address: testscommon.TestAddressAlice,
+		Nonce:   42,
+	}
+
+	service := createConstructionService(networkProvider)
+
+	response, errTyped := service.ConstructionMetadata(context.Background(),
+		&types.ConstructionMetadataRequest{
+			Options: objectsMap{
+				"sender":         testscommon.TestAddressAlice,
+				"receiver":       testscommon.TestAddressBob,
+				"amount":         "1234",
+				"currencySymbol": "XeGLD",
+				"nonce":          100,
+			},
+		},
+	)
+	require.Nil(t, errTyped)
+
+	actualMetadata := &constructionMetadata{}
+	err := fromObjectsMap(response.Metadata, actualMetadata)
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), actualMetadata.Nonce)
+	require.False(t, actualMetadata.Unchecked)
+
+	// The provided nonce has not been reserved.
+	require.Len(t, service.nonces.bySender, 0)
+}
+
+func TestConstructionService_DecideGuardian(t *testing.T) {
+	t.Parallel()
+
+	createNetworkProvider := func(numGuardianDataRequests *int) NetworkProvider {
+		networkProvider := testscommon.NewNetworkProviderMock()
+		networkProvider.GetAccountGuardianDataCalled = func(address string) (*resources.AccountGuardianDataOnBlock, error) {
+			*numGuardianDataRequests++
+
+			return &resources.AccountGuardianDataOnBlock{
+				GuardianData: api.GuardianData{
+					ActiveGuardian: &api.Guardian{Address: testscommon.TestAddressBob},
+					Guarded:        true,
+				},
+			}, nil
+		}
+
+		return networkProvider
+	}
+
+	t.Run("with guardian provided by the caller", func(t *testing.T) {
+		t.Parallel()
+
+		numGuardianDataRequests := 0
+		service := createConstructionService(createNetworkProvider(&numGuardianDataRequests))
+
+		guardian, err := service.decideGuardian(&constructionOptions{Sender: testscommon.TestAddressAlice, Guardian: testscommon.TestAddressCarol}, nil)
+		require.NoError(t, err)
+		require.Equal(t, testscommon.TestAddressCarol, guardian)
+		require.Equal(t, 0, numGuardianDataRequests)
+	})
+
+	t.Run("with fetched account, not guarded", func(t *testing.T) {
+		t.Parallel()
+
+		numGuardianDataRequests := 0
+		service := createConstructionService(createNetworkProvider(&numGuardianDataRequests))
+
+		guardian, err := service.decideGuardian(&constructionOptions{Sender: testscommon.TestAddressAlice}, &resources.Account{Address: testscommon.TestAddressAlice})
+		require.NoError(t, err)
+		require.Equal(t, "", guardian)
+		require.Equal(t, 0, numGuardianDataRequests)
+	})
+
+	t.Run("with fetched account, guarded", func(t *testing.T) {
+		t.Parallel()
+
+		numGuardianDataRequests := 0
+		service := createConstructionService(createNetworkProvider(&numGuardianDataRequests))
+
+		guardian, err := service.decideGuardian(&constructionOptions{Sender: testscommon.TestAddressAlice}, &resources.Account{Address: testscommon.TestAddressAlice, IsGuarded: true})
+		require.NoError(t, err)
+		require.Equal(t, testscommon.TestAddressBob, guardian)
+		require.Equal(t, 1, numGuardianDataRequests)
+	})
+
+	t.Run("without fetched account (nonce provided by the caller)", func(t *testing.T) {
+		t.Parallel()
+
+		numGuardianDataRequests := 0
+		service := createConstructionService(createNetworkProvider(&numGuardianDataRequests))
+
+		guardian, err := service.decideGuardian(&constructionOptions{Sender: testscommon.TestAddressAlice}, nil)
+		require.NoError(t, err)
+		require.Equal(t, testscommon.TestAddressBob, guardian)
+		require.Equal(t, 1, numGuardianDataRequests)
+	})
+}
+
+// createConstructionService creates a construction service (along with its own nonce reservations and transactions submitter), for inspecting its internals
+func createConstructionService(networkProvider NetworkProvider) *constructionService {
+	nonces := NewNonceReservations(networkProvider)
+	submitter := NewTransactionsSubmitter(networkProvider)
+	return NewConstructionService(networkProvider, nonces, submitter).(*constructionService)
 }
@@ -0,0 +1,236 @@
+package services
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-core-go/hashing/keccak"
+	"github.com/multiversx/mx-chain-proxy-go/data"
+)
+
+// signingHasher is used for transactions that are signed with hash (as opposed to being signed in their serialized form).
+var signingHasher = keccak.NewKeccak()
+
+const (
+	signerRoleSender   = "sender"
+	signerRoleGuardian = "guardian"
+	signerRoleRelayer  = "relayer"
+)
+
+// getSignersOfTransaction returns the accounts that have to sign a transaction:
+// the sender, the guardian (for guarded transactions) and the relayer (for relayed V3 transactions).
+func getSignersOfTransaction(tx *data.Transaction) []string {
+	signers := []string{tx.Sender}
+	if len(tx.GuardianAddr) > 0 {
+		signers = append(signers, tx.GuardianAddr)
+	}
+	if len(tx.RelayerAddr) > 0 {
+		signers = append(signers, tx.RelayerAddr)
+	}
+
+	return signers
+}
+
+// getRoleOfSigner returns the role of the signer within the transaction (sender, guardian or relayer).
+func getRoleOfSigner(tx *data.Transaction, signer string) (string, bool) {
+	switch {
+	case signer == tx.Sender:
+		return signerRoleSender, true
+	case len(tx.GuardianAddr) > 0 && signer == tx.GuardianAddr:
+		return signerRoleGuardian, true
+	case len(tx.RelayerAddr) > 0 && signer == tx.RelayerAddr:
+		return signerRoleRelayer, true
+	default:
+		return "", false
+	}
+}
+
+// getSignerOfSignature returns the account that claims a signature.
+// For backwards compatibility, a single signature that does not reference a signing payload is considered to belong to the sender.
+func getSignerOfSignature(tx *data.Transaction, signatures []*types.Signature, signature *types.Signature) (string, error) {
+	if len(signatures) == 1 && signature.SigningPayload == nil {
+		return tx.Sender, nil
+	}
+
+	if signature.SigningPayload == nil || signature.SigningPayload.AccountIdentifier == nil {
+		return "", errors.New("signature does not reference the account of a signing payload")
+	}
+
+	return signature.SigningPayload.AccountIdentifier.Address, nil
+}
+
+// computeSigningBytes returns the bytes to be signed by each one of the signers of a transaction:
+// the serialized transaction (without signatures) or, if the transaction is flagged to be signed with hash, its (Keccak) hash.
+func computeSigningBytes(tx *data.Transaction) ([]byte, error) {
+	txWithoutSignatures := *tx
+	txWithoutSignatures.Signature = ""
+	txWithoutSignatures.GuardianSignature = ""
+	txWithoutSignatures.RelayerSignature = ""
+
+	txJson, err := json.Marshal(&txWithoutSignatures)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isSignedWithHash(tx) {
+		return txJson, nil
+	}
+
+	return signingHasher.Compute(string(txJson)), nil
+}
+
+func isSignedWithHash(tx *data.Transaction) bool {
+	return tx.Version >= uint32(transactionVersionWithOptions) && tx.Options&transactionOptionSignedWithHash != 0
+}
+
+// signatureError is an error that refers to one of the signatures (and its signing payload), by index.
+// Once known, the signer (role and address) is described, as well.
+type signatureError struct {
+	index  int
+	signer string
+	err    error
+}
+
+func newSignatureError(index int, err error) *signatureError {
+	return &signatureError{
+		index: index,
+		err:   err,
+	}
+}
+
+func newSignatureErrorOfSigner(index int, role string, signer string, err error) *signatureError {
+	return &signatureError{
+		index:  index,
+		signer: fmt.Sprintf("%s %s", role, signer),
+		err:    err,
+	}
+}
+
+// Error returns the message of the error, prefixed by the index of the signing payload (and by the signer, if known)
+func (err *signatureError) Error() string {
+	if len(err.signer) > 0 {
+		return fmt.Sprintf("payload %d (%s): %v", err.index, err.signer, err.err)
+	}
+
+	return fmt.Sprintf("payload %d: %v", err.index, err.err)
+}
+
+// Unwrap returns the underlying error
+func (err *signatureError) Unwrap() error {
+	return err.err
+}
+
+// applySignaturesOnTransaction places each signature in the appropriate field of the transaction, according to the role of its signer.
+// The returned error names the offending signing payload (by index), if any.
+func applySignaturesOnTransaction(tx *data.Transaction, signatures []*types.Signature) error {
+	signers := getSignersOfTransaction(tx)
+	if len(signatures) != len(signers) {
+		return fmt.Errorf("unexpected number of signatures: %d, expected: %d", len(signatures), len(signers))
+	}
+
+	for i, signature := range signatures {
+		signer, err := getSignerOfSignature(tx, signatures, signature)
+		if err != nil {
+			return newSignatureError(i, err)
+		}
+
+		role, ok := getRoleOfSigner(tx, signer)
+		if !ok {
+			return newSignatureError(i, fmt.Errorf("unexpected signer: %s", signer))
+		}
+
+		signatureHex := hex.EncodeToString(signature.Bytes)
+
+		switch role {
+		case signerRoleSender:
+			if len(tx.Signature) > 0 {
+				return newSignatureError(i, fmt.Errorf("duplicated signature for sender: %s", signer))
+			}
+			tx.Signature = signatureHex
+		case signerRoleGuardian:
+			if len(tx.GuardianSignature) > 0 {
+				return newSignatureError(i, fmt.Errorf("duplicated signature for guardian: %s", signer))
+			}
+			tx.GuardianSignature = signatureHex
+		case signerRoleRelayer:
+			if len(tx.RelayerSignature) > 0 {
+				return newSignatureError(i, fmt.Errorf("duplicated signature for relayer: %s", signer))
+			}
+			tx.RelayerSignature = signatureHex
+		}
+	}
+
+	return nil
+}
+
+// verifySignatures checks each signature against the signing bytes and the public key of its signer.
+// The returned error names the offending signing payload (by index), along with the role and the address of the signer.
+func (service *constructionService) verifySignatures(tx *data.Transaction, signingBytes []byte, signatures []*types.Signature) error {
+	for i, signature := range signatures {
+		signer, err := getSignerOfSignature(tx, signatures, signature)
+		if err != nil {
+			return newSignatureError(i, err)
+		}
+
+		role, ok := getRoleOfSigner(tx, signer)
+		if !ok {
+			return newSignatureError(i, fmt.Errorf("unexpected signer: %s", signer))
+		}
+
+		err = service.verifySignature(signer, signingBytes, signature)
+		if err != nil {
+			return newSignatureErrorOfSigner(i, role, signer, err)
+		}
+	}
+
+	return nil
+}
+
+// newErrOfSignatures creates an error holding (in its details) the index of the offending signature, if known
+func (service *constructionService) newErrOfSignatures(code errCode, err error) *types.Error {
+	var errOfSignature *signatureError
+	if !errors.As(err, &errOfSignature) {
+		return service.errFactory.newErrWithOriginal(code, err)
+	}
+
+	return service.errFactory.newErrWithDetails(code, err, map[string]interface{}{"signatureIndex": errOfSignature.index})
+}
+
+func (service *constructionService) verifySignature(signer string, signingBytes []byte, signature *types.Signature) error {
+	if len(signature.SignatureType) > 0 && signature.SignatureType != types.Ed25519 {
+		return fmt.Errorf("unsupported signature type: %s", signature.SignatureType)
+	}
+
+	hasSigningPayloadBytes := signature.SigningPayload != nil && len(signature.SigningPayload.Bytes) > 0
+	if hasSigningPayloadBytes && !bytes.Equal(signature.SigningPayload.Bytes, signingBytes) {
+		return errors.New("signing payload does not match the transaction")
+	}
+
+	pubKey, err := service.provider.ConvertAddressToPubKey(signer)
+	if err != nil {
+		return err
+	}
+	if len(pubKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("unexpected length of public key: %d", len(pubKey))
+	}
+
+	if signature.PublicKey != nil {
+		if signature.PublicKey.CurveType != types.Edwards25519 {
+			return fmt.Errorf("unsupported curve type: %s", signature.PublicKey.CurveType)
+		}
+		if !bytes.Equal(signature.PublicKey.Bytes, pubKey) {
+			return errors.New("public key does not match the signer")
+		}
+	}
+
+	if !ed25519.Verify(pubKey, signingBytes, signature.Bytes) {
+		return errors.New("invalid signature")
+	}
+
+	return nil
+}
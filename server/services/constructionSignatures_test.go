@@ -0,0 +1,200 @@
+package services
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-proxy-go/data"
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+type testSigner struct {
+	address    string
+	publicKey  ed25519.PublicKey
+	privateKey ed25519.PrivateKey
+}
+
+// newTestSigner creates a deterministic signer. Seeds 1, 2 and 4 map to accounts in shard 0 (given 3 shards).
+func newTestSigner(provider NetworkProvider, seed byte) *testSigner {
+	privateKey := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{seed}, ed25519.SeedSize))
+	publicKey := privateKey.Public().(ed25519.PublicKey)
+
+	return &testSigner{
+		address:    provider.ConvertPubKeyToAddress(publicKey),
+		publicKey:  publicKey,
+		privateKey: privateKey,
+	}
+}
+
+func (signer *testSigner) sign(message []byte) []byte {
+	return ed25519.Sign(signer.privateKey, message)
+}
+
+func (signer *testSigner) createSignature(message []byte) *types.Signature {
+	return &types.Signature{
+		SigningPayload: &types.SigningPayload{
+			AccountIdentifier: addressToAccountIdentifier(signer.address),
+			Bytes:             message,
+			SignatureType:     types.Ed25519,
+		},
+		PublicKey: &types.PublicKey{
+			Bytes:     signer.publicKey,
+			CurveType: types.Edwards25519,
+		},
+		SignatureType: types.Ed25519,
+		Bytes:         signer.sign(message),
+	}
+}
+
+func TestGetRoleOfSigner(t *testing.T) {
+	t.Parallel()
+
+	tx := &data.Transaction{
+		Sender:       "alice",
+		GuardianAddr: "bob",
+		RelayerAddr:  "carol",
+	}
+
+	role, ok := getRoleOfSigner(tx, "alice")
+	require.True(t, ok)
+	require.Equal(t, signerRoleSender, role)
+
+	role, ok = getRoleOfSigner(tx, "bob")
+	require.True(t, ok)
+	require.Equal(t, signerRoleGuardian, role)
+
+	role, ok = getRoleOfSigner(tx, "carol")
+	require.True(t, ok)
+	require.Equal(t, signerRoleRelayer, role)
+
+	_, ok = getRoleOfSigner(tx, "dan")
+	require.False(t, ok)
+
+	_, ok = getRoleOfSigner(&data.Transaction{Sender: "alice"}, "")
+	require.False(t, ok)
+}
+
+func TestComputeSigningBytes(t *testing.T) {
+	t.Parallel()
+
+	tx := &data.Transaction{
+		Nonce:             42,
+		Value:             "1234",
+		Receiver:          "bob",
+		Sender:            "alice",
+		GasPrice:          1000000000,
+		GasLimit:          50000,
+		Signature:         "aabb",
+		ChainID:           "T",
+		Version:           2,
+		RelayerAddr:       "carol",
+		RelayerSignature:  "ccdd",
+		GuardianSignature: "eeff",
+	}
+
+	signingBytes, err := computeSigningBytes(tx)
+	require.Nil(t, err)
+	require.Equal(t, `{"nonce":42,"value":"1234","receiver":"bob","sender":"alice","gasPrice":1000000000,"gasLimit":50000,"chainID":"T","version":2,"relayer":"carol"}`, string(signingBytes))
+
+	// The original transaction is left untouched
+	require.Equal(t, "aabb", tx.Signature)
+
+	// When signing with hash, the (Keccak) hash of the serialized transaction is signed
+	tx.Options = transactionOptionSignedWithHash
+	signingBytes, err = computeSigningBytes(tx)
+	require.Nil(t, err)
+	require.Equal(t, "92d52c45eab22fab989359ad6d0f0ecd6d2e69de6b6801cbf89346dc3fb5c23f", hex.EncodeToString(signingBytes))
+
+	// The hash-signing flag is ignored for the initial version
+	tx.Version = 1
+	signingBytes, err = computeSigningBytes(tx)
+	require.Nil(t, err)
+	require.Equal(t, `{"nonce":42,"value":"1234","receiver":"bob","sender":"alice","gasPrice":1000000000,"gasLimit":50000,"chainID":"T","version":1,"options":1,"relayer":"carol"}`, string(signingBytes))
+}
+
+func TestConstructionService_VerifySignatures(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	service := createConstructionService(networkProvider)
+
+	sender := newTestSigner(networkProvider, 1)
+	relayer := newTestSigner(networkProvider, 4)
+	other := newTestSigner(networkProvider, 7)
+
+	tx := &data.Transaction{
+		Sender:      sender.address,
+		Receiver:    other.address,
+		RelayerAddr: relayer.address,
+	}
+
+	signingBytes, err := computeSigningBytes(tx)
+	require.Nil(t, err)
+
+	t.Run("with valid signatures", func(t *testing.T) {
+		err := service.verifySignatures(tx, signingBytes, []*types.Signature{
+			sender.createSignature(signingBytes),
+			relayer.createSignature(signingBytes),
+		})
+
+		require.Nil(t, err)
+	})
+
+	t.Run("with valid signature, without signing payload (backwards compatibility)", func(t *testing.T) {
+		err := service.verifySignatures(&data.Transaction{Sender: sender.address}, []byte("hello"), []*types.Signature{
+			{
+				Bytes: sender.sign([]byte("hello")),
+			},
+		})
+
+		require.Nil(t, err)
+	})
+
+	t.Run("with signature from the wrong key", func(t *testing.T) {
+		signature := relayer.createSignature(signingBytes)
+		signature.Bytes = other.sign(signingBytes)
+
+		err := service.verifySignatures(tx, signingBytes, []*types.Signature{
+			sender.createSignature(signingBytes),
+			signature,
+		})
+
+		require.ErrorContains(t, err, "payload 1 (relayer "+relayer.address+"): invalid signature")
+	})
+
+	t.Run("with signature over other bytes", func(t *testing.T) {
+		signature := sender.createSignature(signingBytes)
+		signature.Bytes = sender.sign([]byte("hello"))
+
+		err := service.verifySignatures(tx, signingBytes, []*types.Signature{signature})
+		require.ErrorContains(t, err, "payload 0 (sender "+sender.address+"): invalid signature")
+	})
+
+	t.Run("with signing payload not matching the transaction", func(t *testing.T) {
+		signature := sender.createSignature([]byte("hello"))
+
+		err := service.verifySignatures(tx, signingBytes, []*types.Signature{signature})
+		require.ErrorContains(t, err, "payload 0 (sender "+sender.address+"): signing payload does not match the transaction")
+	})
+
+	t.Run("with public key not matching the claimed role", func(t *testing.T) {
+		signature := sender.createSignature(signingBytes)
+		signature.PublicKey.Bytes = other.publicKey
+
+		err := service.verifySignatures(tx, signingBytes, []*types.Signature{signature})
+		require.ErrorContains(t, err, "payload 0 (sender "+sender.address+"): public key does not match the signer")
+	})
+
+	t.Run("with unexpected signer", func(t *testing.T) {
+		err := service.verifySignatures(tx, signingBytes, []*types.Signature{
+			sender.createSignature(signingBytes),
+			other.createSignature(signingBytes),
+		})
+
+		require.ErrorContains(t, err, "payload 1: unexpected signer: "+other.address)
+	})
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-proxy-go/data"
+)
+
+// simulationFailure describes a failure predicted by the simulation of a transaction
+type simulationFailure struct {
+	vmError      string
+	failingEvent *transaction.Events
+}
+
+// simulateBeforeSubmit runs the (signed) transaction through the observer's simulation.
+// If the transaction is predicted to fail, a (non-retriable) error is returned, holding the VM error and the failing event (if any).
+func (submitter *transactionsSubmitter) simulateBeforeSubmit(tx *data.Transaction) *types.Error {
+	results, err := submitter.provider.SimulateTransaction(tx)
+	if err != nil {
+		return submitter.errFactory.newErrWithOriginal(ErrUnableToSubmitTransaction, err)
+	}
+
+	failure := findSimulationFailure(results)
+	if failure == nil {
+		return nil
+	}
+
+	log.Debug("constructionService.simulateBeforeSubmit(): transaction would fail", "vmError", failure.vmError)
+
+	details := map[string]interface{}{
+		"vmError": failure.vmError,
+	}
+	if failure.failingEvent != nil {
+		details["failingEvent"] = eventToObjectsMap(failure.failingEvent)
+	}
+
+	originalError := fmt.Errorf("simulation predicts failure: %s", failure.vmError)
+	return submitter.errFactory.newErrWithDetails(ErrTransactionWouldFail, originalError, details)
+}
+
+// findSimulationFailure returns the failure predicted by the simulation, if any.
+// A transaction is predicted to fail if the simulation reports so (status and fail reason), or if an error event ("signalError" or "internalVMErrors") is emitted,
+// either by the transaction itself or by one of its smart contract results.
+func findSimulationFailure(results *transaction.SimulationResults) *simulationFailure {
+	failingEvent := findErrorEventInSimulationResults(results)
+	isFailed := results.Status == transaction.TxStatusFail || len(results.FailReason) > 0 || failingEvent != nil
+	if !isFailed {
+		return nil
+	}
+
+	vmError := results.FailReason
+	if len(vmError) == 0 && failingEvent != nil {
+		vmError = getErrorMessageOfEvent(failingEvent)
+	}
+	if len(vmError) == 0 {
+		vmError = "unknown error"
+	}
+
+	return &simulationFailure{
+		vmError:      vmError,
+		failingEvent: failingEvent,
+	}
+}
+
+func findErrorEventInSimulationResults(results *transaction.SimulationResults) *transaction.Events {
+	event := findErrorEventInLogs(results.Logs)
+	if event != nil {
+		return event
+	}
+
+	// Smart contract results are visited in a deterministic order (by hash).
+	hashes := make([]string, 0, len(results.ScResults))
+	for hash := range results.ScResults {
+		hashes = append(hashes, hash)
+	}
+	sort.Strings(hashes)
+
+	for _, hash := range hashes {
+		scr := results.ScResults[hash]
+		if scr == nil {
+			continue
+		}
+
+		event = findErrorEventInLogs(scr.Logs)
+		if event != nil {
+			return event
+		}
+	}
+
+	return nil
+}
+
+func findErrorEventInLogs(logs *transaction.ApiLogs) *transaction.Events {
+	if logs == nil {
+		return nil
+	}
+
+	for _, event := range logs.Events {
+		if event == nil {
+			continue
+		}
+		if event.Identifier == transactionEventSignalError || event.Identifier == transactionEventInternalVMErrors {
+			return event
+		}
+	}
+
+	return nil
+}
+
+// getErrorMessageOfEvent extracts the error message of a "signalError" event (second topic) or of an "internalVMErrors" event (data).
+func getErrorMessageOfEvent(event *transaction.Events) string {
+	if event.Identifier == transactionEventSignalError && len(event.Topics) > 1 {
+		return string(event.Topics[1])
+	}
+
+	return string(event.Data)
+}
+
+func eventToObjectsMap(event *transaction.Events) objectsMap {
+	topics := make([]string, 0, len(event.Topics))
+	for _, topic := range event.Topics {
+		topics = append(topics, hex.EncodeToString(topic))
+	}
+
+	return objectsMap{
+		"address":    event.Address,
+		"identifier": event.Identifier,
+		"topics":     topics,
+		"data":       string(event.Data),
+	}
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindSimulationFailure(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		failure := findSimulationFailure(&transaction.SimulationResults{
+			Status: transaction.TxStatusSuccess,
+			Logs: &transaction.ApiLogs{
+				Events: []*transaction.Events{
+					{Identifier: transactionEventESDTTransfer},
+				},
+			},
+		})
+
+		require.Nil(t, failure)
+	})
+
+	t.Run("with fail reason", func(t *testing.T) {
+		failure := findSimulationFailure(&transaction.SimulationResults{
+			Status:     transaction.TxStatusFail,
+			FailReason: "insufficient funds",
+		})
+
+		require.Equal(t, &simulationFailure{vmError: "insufficient funds"}, failure)
+	})
+
+	t.Run("with signalError event", func(t *testing.T) {
+		event := &transaction.Events{
+			Address:    "erd1qqqqqqqqqqqqqpgqagjekf5mxv86hy5c62vvtug5vc6jmgcsq6uq8reras",
+			Identifier: transactionEventSignalError,
+			Topics:     [][]byte{{0xaa}, []byte("frozen balance")},
+			Data:       []byte("@04"),
+		}
+
+		failure := findSimulationFailure(&transaction.SimulationResults{
+			Status: transaction.TxStatusSuccess,
+			Logs: &transaction.ApiLogs{
+				Events: []*transaction.Events{
+					{Identifier: transactionEventESDTTransfer},
+					event,
+				},
+			},
+		})
+
+		require.Equal(t, &simulationFailure{vmError: "frozen balance", failingEvent: event}, failure)
+	})
+
+	t.Run("with internalVMErrors event, in smart contract result", func(t *testing.T) {
+		event := &transaction.Events{
+			Identifier: transactionEventInternalVMErrors,
+			Data:       []byte("function not found"),
+		}
+
+		failure := findSimulationFailure(&transaction.SimulationResults{
+			Status: transaction.TxStatusSuccess,
+			ScResults: map[string]*transaction.ApiSmartContractResult{
+				"bbbb": {Logs: &transaction.ApiLogs{Events: []*transaction.Events{event}}},
+				"aaaa": {},
+			},
+		})
+
+		require.Equal(t, &simulationFailure{vmError: "function not found", failingEvent: event}, failure)
+	})
+
+	t.Run("with fail status, without reason", func(t *testing.T) {
+		failure := findSimulationFailure(&transaction.SimulationResults{
+			Status: transaction.TxStatusFail,
+		})
+
+		require.Equal(t, &simulationFailure{vmError: "unknown error"}, failure)
+	})
+}
+
+func TestEventToObjectsMap(t *testing.T) {
+	t.Parallel()
+
+	event := &transaction.Events{
+		Address:    "erd1qqqqqqqqqqqqqpgqagjekf5mxv86hy5c62vvtug5vc6jmgcsq6uq8reras",
+		Identifier: transactionEventSignalError,
+		Topics:     [][]byte{{0xaa, 0xbb}, []byte("frozen balance")},
+		Data:       []byte("@04"),
+	}
+
+	require.Equal(t, objectsMap{
+		"address":    "erd1qqqqqqqqqqqqqpgqagjekf5mxv86hy5c62vvtug5vc6jmgcsq6uq8reras",
+		"identifier": "signalError",
+		"topics":     []string{"aabb", "66726f7a656e2062616c616e6365"},
+		"data":       "@04",
+	}, eventToObjectsMap(event))
+}
@@ -0,0 +1,125 @@
+package services
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+)
+
+const (
+	submissionOutcomeSubmitted    = "submitted"
+	submissionOutcomeDeduplicated = "deduplicated"
+	submissionOutcomeFailed       = "failed"
+	submissionOutcomeRejected     = "rejected"
+)
+
+type recentSubmission struct {
+	computedHash string
+	hash         string
+	isPending    bool
+	expiresAt    time.Time
+}
+
+// recentSubmissions remembers the successfully submitted transactions (by the hash computed locally), for a while (the deduplication window).
+// This way, re-submissions (e.g. retries after a timeout) aren't broadcasted again.
+// A transaction is reserved before being sent, so that concurrent submissions of the same transaction aren't broadcasted twice, either.
+type recentSubmissions struct {
+	mutex   sync.Mutex
+	window  time.Duration
+	byHash  map[string]*recentSubmission
+	queue   []*recentSubmission
+	getTime func() time.Time
+}
+
+func newRecentSubmissions(window time.Duration) *recentSubmissions {
+	return &recentSubmissions{
+		window:  window,
+		byHash:  make(map[string]*recentSubmission),
+		queue:   make([]*recentSubmission, 0),
+		getTime: time.Now,
+	}
+}
+
+// reserve looks for a recent (or pending) submission of the same transaction. If there's none, the transaction is reserved (until confirmed or released).
+// For a pending submission, the hash computed locally is returned (the observer isn't expected to return a different one).
+func (submissions *recentSubmissions) reserve(computedHash string) (string, bool) {
+	submissions.mutex.Lock()
+	defer submissions.mutex.Unlock()
+
+	submissions.dropExpired()
+
+	submission, ok := submissions.byHash[computedHash]
+	if ok {
+		return submission.hash, true
+	}
+
+	submissions.byHash[computedHash] = &recentSubmission{
+		computedHash: computedHash,
+		hash:         computedHash,
+		isPending:    true,
+	}
+
+	return "", false
+}
+
+// confirm remembers a reserved transaction (as accepted by the observer), for the duration of the deduplication window
+func (submissions *recentSubmissions) confirm(computedHash string, hash string) {
+	submissions.mutex.Lock()
+	defer submissions.mutex.Unlock()
+
+	submissions.dropExpired()
+
+	submission, ok := submissions.byHash[computedHash]
+	if ok && !submission.isPending {
+		return
+	}
+	if !ok {
+		submission = &recentSubmission{computedHash: computedHash}
+		submissions.byHash[computedHash] = submission
+	}
+
+	submission.hash = hash
+	submission.isPending = false
+	submission.expiresAt = submissions.getTime().Add(submissions.window)
+	submissions.queue = append(submissions.queue, submission)
+}
+
+// release forgets a reserved transaction (e.g. not accepted by the observer), so that it can be submitted again
+func (submissions *recentSubmissions) release(computedHash string) {
+	submissions.mutex.Lock()
+	defer submissions.mutex.Unlock()
+
+	submission, ok := submissions.byHash[computedHash]
+	if ok && submission.isPending {
+		delete(submissions.byHash, computedHash)
+	}
+}
+
+// dropExpired drops the submissions outside the deduplication window. Since the window is fixed, the queue is ordered by expiration.
+// Pending submissions aren't held in the queue (they don't expire).
+func (submissions *recentSubmissions) dropExpired() {
+	now := submissions.getTime()
+
+	numExpired := 0
+	for _, submission := range submissions.queue {
+		if now.Before(submission.expiresAt) {
+			break
+		}
+
+		delete(submissions.byHash, submission.computedHash)
+		numExpired++
+	}
+
+	submissions.queue = submissions.queue[numExpired:]
+}
+
+func describeErrorForAudit(errTyped *types.Error) string {
+	originalError, ok := errTyped.Details["originalError"]
+	if !ok {
+		return errTyped.Message
+	}
+
+	return fmt.Sprintf("%s: %v", errTyped.Message, originalError)
+}
@@ -0,0 +1,267 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-proxy-go/data"
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecentSubmissions(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1000, 0)
+	submissions := newRecentSubmissions(60 * time.Second)
+	submissions.getTime = func() time.Time {
+		return now
+	}
+
+	_, ok := submissions.reserve("aaaa")
+	require.False(t, ok)
+	submissions.confirm("aaaa", "aaaa")
+
+	now = now.Add(30 * time.Second)
+	_, ok = submissions.reserve("bbbb")
+	require.False(t, ok)
+	submissions.confirm("bbbb", "bbbb")
+
+	hash, ok := submissions.reserve("aaaa")
+	require.True(t, ok)
+	require.Equal(t, "aaaa", hash)
+
+	// "aaaa" is outside the window, "bbbb" isn't.
+	now = now.Add(30 * time.Second)
+	_, ok = submissions.reserve("bbbb")
+	require.True(t, ok)
+	_, ok = submissions.reserve("aaaa")
+	require.False(t, ok)
+	require.Len(t, submissions.queue, 1)
+	require.Len(t, submissions.byHash, 2)
+
+	// "aaaa" is pending (thus, not held in the queue).
+	now = now.Add(30 * time.Second)
+	hash, ok = submissions.reserve("aaaa")
+	require.True(t, ok)
+	require.Equal(t, "aaaa", hash)
+	require.Len(t, submissions.queue, 0)
+	require.Len(t, submissions.byHash, 1)
+
+	submissions.release("aaaa")
+	require.Len(t, submissions.byHash, 0)
+}
+
+func TestRecentSubmissions_ReleaseDoesNotForgetConfirmedSubmissions(t *testing.T) {
+	t.Parallel()
+
+	submissions := newRecentSubmissions(60 * time.Second)
+
+	_, ok := submissions.reserve("aaaa")
+	require.False(t, ok)
+	submissions.confirm("aaaa", "aaaa")
+	submissions.release("aaaa")
+
+	_, ok = submissions.reserve("aaaa")
+	require.True(t, ok)
+}
+
+func TestConstructionService_SubmitIdempotently(t *testing.T) {
+	t.Parallel()
+
+	signedTx := `{"nonce":42,"value":"1234","receiver":"erd1spyavw0956vq68xj8y4tenjpq2wd5a9p2c6j8gsz7ztyrnpxrruqzu66jx","sender":"erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th","gasPrice":1000000000,"gasLimit":50000,"signature":"aabb","chainID":"T","version":1}`
+
+	t.Run("with deduplication", func(t *testing.T) {
+		t.Parallel()
+
+		networkProvider := testscommon.NewNetworkProviderMock()
+		networkProvider.MockNetworkConfig.SubmitDedupWindowSeconds = 60
+		networkProvider.MockComputedTransactionHash = "aaaa"
+
+		numCalls := 0
+		networkProvider.SendTransactionCalled = func(tx *data.Transaction) (string, error) {
+			numCalls++
+			return "aaaa", nil
+		}
+
+		service := createConstructionService(networkProvider)
+
+		for i := 0; i < 3; i++ {
+			response, errTyped := service.ConstructionSubmit(context.Background(), &types.ConstructionSubmitRequest{SignedTransaction: signedTx})
+			require.Nil(t, errTyped)
+			require.Equal(t, "aaaa", response.TransactionIdentifier.Hash)
+		}
+
+		require.Equal(t, 1, numCalls)
+
+		// Also applies to batches.
+		results, errTyped := service.submitter.submitBatch([]string{signedTx})
+		require.Nil(t, errTyped)
+		require.Equal(t, "aaaa", results[0].Hash)
+		require.Equal(t, 1, numCalls)
+	})
+
+	t.Run("with deduplication, failed submissions aren't remembered", func(t *testing.T) {
+		t.Parallel()
+
+		networkProvider := testscommon.NewNetworkProviderMock()
+		networkProvider.MockNetworkConfig.SubmitDedupWindowSeconds = 60
+		networkProvider.MockComputedTransactionHash = "aaaa"
+
+		numCalls := 0
+		networkProvider.SendTransactionCalled = func(tx *data.Transaction) (string, error) {
+			numCalls++
+			if numCalls == 1 {
+				return "", errors.New("arbitrary error")
+			}
+			return "aaaa", nil
+		}
+
+		service := createConstructionService(networkProvider)
+
+		_, errTyped := service.ConstructionSubmit(context.Background(), &types.ConstructionSubmitRequest{SignedTransaction: signedTx})
+		require.Equal(t, int32(ErrUnableToSubmitTransaction), errTyped.Code)
+
+		response, errTyped := service.ConstructionSubmit(context.Background(), &types.ConstructionSubmitRequest{SignedTransaction: signedTx})
+		require.Nil(t, errTyped)
+		require.Equal(t, "aaaa", response.TransactionIdentifier.Hash)
+		require.Equal(t, 2, numCalls)
+	})
+
+	t.Run("with deduplication, concurrent submissions", func(t *testing.T) {
+		t.Parallel()
+
+		networkProvider := testscommon.NewNetworkProviderMock()
+		networkProvider.MockNetworkConfig.SubmitDedupWindowSeconds = 60
+		networkProvider.MockComputedTransactionHash = "aaaa"
+
+		sendStarted := make(chan struct{})
+		sendUnblocked := make(chan struct{})
+		numCalls := 0
+		networkProvider.SendTransactionCalled = func(tx *data.Transaction) (string, error) {
+			numCalls++
+			close(sendStarted)
+			<-sendUnblocked
+			return "aaaa", nil
+		}
+
+		service := createConstructionService(networkProvider)
+
+		done := make(chan *types.Error)
+		go func() {
+			_, errTyped := service.ConstructionSubmit(context.Background(), &types.ConstructionSubmitRequest{SignedTransaction: signedTx})
+			done <- errTyped
+		}()
+
+		// While the first submission is in flight, a retry isn't broadcasted again.
+		<-sendStarted
+		response, errTyped := service.ConstructionSubmit(context.Background(), &types.ConstructionSubmitRequest{SignedTransaction: signedTx})
+		require.Nil(t, errTyped)
+		require.Equal(t, "aaaa", response.TransactionIdentifier.Hash)
+
+		close(sendUnblocked)
+		require.Nil(t, <-done)
+		require.Equal(t, 1, numCalls)
+	})
+
+	t.Run("without deduplication", func(t *testing.T) {
+		t.Parallel()
+
+		networkProvider := testscommon.NewNetworkProviderMock()
+		networkProvider.MockComputedTransactionHash = "aaaa"
+
+		numCalls := 0
+		networkProvider.SendTransactionCalled = func(tx *data.Transaction) (string, error) {
+			numCalls++
+			return "aaaa", nil
+		}
+
+		service := createConstructionService(networkProvider)
+
+		for i := 0; i < 3; i++ {
+			_, errTyped := service.ConstructionSubmit(context.Background(), &types.ConstructionSubmitRequest{SignedTransaction: signedTx})
+			require.Nil(t, errTyped)
+		}
+
+		require.Equal(t, 3, numCalls)
+	})
+}
+
+func TestConstructionService_AuditSubmissions(t *testing.T) {
+	t.Parallel()
+
+	signedTx := `{"nonce":42,"value":"0","receiver":"erd1spyavw0956vq68xj8y4tenjpq2wd5a9p2c6j8gsz7ztyrnpxrruqzu66jx","sender":"erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th","gasPrice":1000000000,"gasLimit":50000,"signature":"aabb","chainID":"T","version":1}`
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	networkProvider.MockNetworkConfig.SubmitDedupWindowSeconds = 60
+	networkProvider.MockNetworkConfig.ShouldSimulateBeforeSubmit = true
+	networkProvider.MockComputedTransactionHash = "aaaa"
+
+	nextSimulationResults := &transaction.SimulationResults{Status: transaction.TxStatusSuccess}
+	networkProvider.SimulateTransactionCalled = func(tx *data.Transaction) (*transaction.SimulationResults, error) {
+		return nextSimulationResults, nil
+	}
+
+	var nextSendError error
+	networkProvider.SendTransactionCalled = func(tx *data.Transaction) (string, error) {
+		if nextSendError != nil {
+			return "", nextSendError
+		}
+		return "aaaa", nil
+	}
+
+	auditLog, err := NewSubmissionsAuditLog(filepath.Join(t.TempDir(), "audit.log"), 1)
+	require.Nil(t, err)
+	defer func() {
+		_ = auditLog.Close()
+	}()
+
+	service := createConstructionService(networkProvider)
+	service.submitter.UseSubmissionsAuditLog(auditLog)
+
+	submit := func(signedTransaction string) {
+		_, _ = service.ConstructionSubmit(context.Background(), &types.ConstructionSubmitRequest{SignedTransaction: signedTransaction})
+	}
+
+	submit("{not a transaction")
+
+	nextSimulationResults = &transaction.SimulationResults{Status: transaction.TxStatusFail, FailReason: "insufficient funds"}
+	submit(signedTx)
+
+	nextSimulationResults = &transaction.SimulationResults{Status: transaction.TxStatusSuccess}
+	nextSendError = errors.New("arbitrary error")
+	submit(signedTx)
+
+	nextSendError = nil
+	submit(signedTx)
+	submit(signedTx)
+
+	records, err := auditLog.query(submissionsAuditFilter{})
+	require.Nil(t, err)
+	require.Len(t, records, 5)
+
+	require.Equal(t, submissionOutcomeRejected, records[0].Outcome)
+	require.Equal(t, "{not a transaction", records[0].SignedTransaction)
+	require.Empty(t, records[0].Sender)
+
+	require.Equal(t, submissionOutcomeRejected, records[1].Outcome)
+	require.Equal(t, "transaction would fail (as predicted by simulation): simulation predicts failure: insufficient funds", records[1].ObserverResponse)
+	require.Equal(t, testscommon.TestAddressAlice, records[1].Sender)
+	require.Equal(t, uint64(42), records[1].Nonce)
+	require.Equal(t, "aaaa", records[1].Hash)
+
+	require.Equal(t, submissionOutcomeFailed, records[2].Outcome)
+	require.Equal(t, "arbitrary error", records[2].ObserverResponse)
+
+	require.Equal(t, submissionOutcomeSubmitted, records[3].Outcome)
+	require.Equal(t, "aaaa", records[3].ObserverResponse)
+	require.Equal(t, signedTx, records[3].SignedTransaction)
+
+	require.Equal(t, submissionOutcomeDeduplicated, records[4].Outcome)
+	require.Equal(t, "aaaa", records[4].Hash)
+}
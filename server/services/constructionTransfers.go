@@ -0,0 +1,250 @@
+package services
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-rosetta/server/provider"
+)
+
+// The data field of a MultiESDTNFTTransfer consists of a header (function, receiver, number of transfers), followed by the transfers (token identifier, nonce, amount).
+const (
+	numPartsOfMultiTransferHeader      = 3
+	numPartsPerTransferOfMultiTransfer = 3
+)
+
+// constructionTransfer is one of the (many) transfers of a multi-token transfer (MultiESDTNFTTransfer)
+type constructionTransfer struct {
+	Amount         string `json:"amount"`
+	CurrencySymbol string `json:"currencySymbol"`
+}
+
+// parsedTransfer is a transfer (of any currency, native included) decoded from the data field of a transaction
+type parsedTransfer struct {
+	tokenIdentifier string
+	amount          string
+	isNative        bool
+}
+
+func countDebitOperations(operations []*types.Operation) int {
+	count := 0
+
+	for _, operation := range operations {
+		if operation.Amount != nil && strings.HasPrefix(operation.Amount.Value, "-") {
+			count++
+		}
+	}
+
+	return count
+}
+
+// extractMultiTransferFromOperations extracts the sender, the receiver and the transfers of a multi-token transfer.
+// All debit operations must belong to one sender, all credit operations must belong to one receiver, and, for each currency, the debits must match the credits.
+func extractMultiTransferFromOperations(operations []*types.Operation) (string, string, []*constructionTransfer, error) {
+	sender := ""
+	receiver := ""
+	transfers := make([]*constructionTransfer, 0, len(operations)/2)
+	balanceByCurrency := make(map[string]*big.Int)
+	currencies := make([]string, 0)
+
+	for _, operation := range operations {
+		if operation.Account == nil || operation.Amount == nil || operation.Amount.Currency == nil {
+			return "", "", nil, errors.New("operations of multi-token transfer must have an account and an amount")
+		}
+
+		address := operation.Account.Address
+		symbol := operation.Amount.Currency.Symbol
+		value, ok := big.NewInt(0).SetString(operation.Amount.Value, 10)
+		if !ok {
+			return "", "", nil, fmt.Errorf("cannot parse amount of operation: %s", operation.Amount.Value)
+		}
+
+		if _, ok := balanceByCurrency[symbol]; !ok {
+			balanceByCurrency[symbol] = big.NewInt(0)
+			currencies = append(currencies, symbol)
+		}
+
+		balanceByCurrency[symbol].Add(balanceByCurrency[symbol], value)
+
+		isDebit := value.Sign() < 0
+		if isDebit {
+			if sender != "" && sender != address {
+				return "", "", nil, errors.New("multi-token transfers must have a single sender")
+			}
+
+			sender = address
+			transfers = append(transfers, &constructionTransfer{
+				Amount:         getMagnitudeOfAmount(operation.Amount.Value),
+				CurrencySymbol: symbol,
+			})
+		} else {
+			if receiver != "" && receiver != address {
+				return "", "", nil, errors.New("multi-token transfers must have a single receiver")
+			}
+
+			receiver = address
+		}
+	}
+
+	for _, symbol := range currencies {
+		if balanceByCurrency[symbol].Sign() != 0 {
+			return "", "", nil, fmt.Errorf("debits and credits do not match for currency: %s", symbol)
+		}
+	}
+
+	return sender, receiver, transfers, nil
+}
+
+// computeDataForMultiTransfer computes the data field of a MultiESDTNFTTransfer (sent to self).
+// The native currency is transferred as "EGLD-000000".
+func (service *constructionService) computeDataForMultiTransfer(receiver string, transfers []*constructionTransfer) ([]byte, error) {
+	receiverPubKey, err := service.provider.ConvertAddressToPubKey(receiver)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := []string{
+		builtInFunctionMultiESDTNFTTransfer,
+		hex.EncodeToString(receiverPubKey),
+		nonceToHex(uint64(len(transfers))),
+	}
+
+	for _, transfer := range transfers {
+		baseIdentifier := nativeAsESDTIdentifier
+		nonce := uint64(0)
+
+		if !service.isNativeCurrencyOfMultiTransfer(transfer.CurrencySymbol) {
+			baseIdentifier, nonce, err = provider.ParseTokenIdentifier(transfer.CurrencySymbol)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		parts = append(parts, stringToHex(baseIdentifier), nonceToHex(nonce), amountToHex(transfer.Amount))
+	}
+
+	return []byte(strings.Join(parts, argumentsSeparator)), nil
+}
+
+func (service *constructionService) isNativeCurrencyOfMultiTransfer(symbol string) bool {
+	return service.extension.isNativeCurrencySymbol(symbol) || symbol == nativeAsESDTIdentifier
+}
+
+// parseNumTransfersOfMultiTransfer decodes the number of transfers of a MultiESDTNFTTransfer, given the parts of its data field.
+// The number of transfers must be backed by enough parts (it is checked before being used for any computation or allocation).
+func parseNumTransfersOfMultiTransfer(parts []string) (int, error) {
+	if len(parts) < numPartsOfMultiTransferHeader {
+		return 0, errors.New("cannot parse data of multi-token transfer")
+	}
+
+	numTransfers, err := hexToNonce(parts[2])
+	if err != nil || numTransfers == 0 {
+		return 0, errors.New("cannot decode number of transfers of multi-token transfer")
+	}
+
+	maxNumTransfers := uint64(len(parts)-numPartsOfMultiTransferHeader) / numPartsPerTransferOfMultiTransfer
+	if numTransfers > maxNumTransfers {
+		return 0, errors.New("bad number of arguments for multi-token transfer")
+	}
+
+	return int(numTransfers), nil
+}
+
+// parseMultiTransfer parses a MultiESDTNFTTransfer. It returns the public key of the actual receiver and the transfers.
+func parseMultiTransfer(txData string) ([]byte, []*parsedTransfer, error) {
+	parts := strings.Split(txData, argumentsSeparator)
+
+	numTransfers, err := parseNumTransfersOfMultiTransfer(parts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	receiverPubKey, err := hex.DecodeString(parts[1])
+	if err != nil {
+		return nil, nil, errors.New("cannot decode receiver of multi-token transfer")
+	}
+
+	if len(parts) != numPartsOfMultiTransferHeader+numTransfers*numPartsPerTransferOfMultiTransfer {
+		return nil, nil, errors.New("bad number of arguments for multi-token transfer")
+	}
+
+	transfers := make([]*parsedTransfer, 0, numTransfers)
+
+	for i := 0; i < numTransfers; i++ {
+		offset := numPartsOfMultiTransferHeader + i*numPartsPerTransferOfMultiTransfer
+
+		baseIdentifierBytes, err := hex.DecodeString(parts[offset])
+		if err != nil {
+			return nil, nil, errors.New("cannot decode custom token identifier")
+		}
+
+		nonce := uint64(0)
+		if len(parts[offset+1]) > 0 {
+			nonce, err = hexToNonce(parts[offset+1])
+			if err != nil {
+				return nil, nil, errors.New("cannot decode custom token nonce")
+			}
+		}
+
+		amount, err := hexToAmount(parts[offset+2])
+		if err != nil {
+			return nil, nil, errors.New("cannot decode custom token amount")
+		}
+
+		baseIdentifier := string(baseIdentifierBytes)
+		tokenIdentifier := baseIdentifier
+		if nonce > 0 {
+			tokenIdentifier = fmt.Sprintf("%s-%s", baseIdentifier, nonceToHex(nonce))
+		}
+
+		transfers = append(transfers, &parsedTransfer{
+			tokenIdentifier: tokenIdentifier,
+			amount:          amount,
+			isNative:        baseIdentifier == nativeAsESDTIdentifier,
+		})
+	}
+
+	return receiverPubKey, transfers, nil
+}
+
+func (service *constructionService) createOperationsFromParsedTransfers(sender string, receiver string, transfers []*parsedTransfer) []*types.Operation {
+	operations := make([]*types.Operation, 0, len(transfers)*2)
+
+	for _, transfer := range transfers {
+		if transfer.isNative {
+			operations = append(operations,
+				&types.Operation{
+					Type:    opTransfer,
+					Account: addressToAccountIdentifier(sender),
+					Amount:  service.extension.valueToNativeAmount("-" + transfer.amount),
+				},
+				&types.Operation{
+					Type:    opTransfer,
+					Account: addressToAccountIdentifier(receiver),
+					Amount:  service.extension.valueToNativeAmount(transfer.amount),
+				},
+			)
+
+			continue
+		}
+
+		operations = append(operations,
+			&types.Operation{
+				Type:    opCustomTransfer,
+				Account: addressToAccountIdentifier(sender),
+				Amount:  service.extension.valueToCustomAmount("-"+transfer.amount, transfer.tokenIdentifier),
+			},
+			&types.Operation{
+				Type:    opCustomTransfer,
+				Account: addressToAccountIdentifier(receiver),
+				Amount:  service.extension.valueToCustomAmount(transfer.amount, transfer.tokenIdentifier),
+			},
+		)
+	}
+
+	return operations
+}
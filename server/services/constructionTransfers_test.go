@@ -0,0 +1,118 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractMultiTransferFromOperations(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	extension := newNetworkProviderExtension(networkProvider)
+
+	t.Run("with one sender, one receiver", func(t *testing.T) {
+		operations := []*types.Operation{
+			{Account: addressToAccountIdentifier("alice"), Amount: extension.valueToCustomAmount("-10", "FOO-abcdef")},
+			{Account: addressToAccountIdentifier("alice"), Amount: extension.valueToCustomAmount("-20", "BAR-abcdef")},
+			{Account: addressToAccountIdentifier("bob"), Amount: extension.valueToCustomAmount("20", "BAR-abcdef")},
+			{Account: addressToAccountIdentifier("bob"), Amount: extension.valueToCustomAmount("10", "FOO-abcdef")},
+		}
+
+		sender, receiver, transfers, err := extractMultiTransferFromOperations(operations)
+		require.Nil(t, err)
+		require.Equal(t, "alice", sender)
+		require.Equal(t, "bob", receiver)
+		require.Equal(t, []*constructionTransfer{
+			{Amount: "10", CurrencySymbol: "FOO-abcdef"},
+			{Amount: "20", CurrencySymbol: "BAR-abcdef"},
+		}, transfers)
+	})
+
+	t.Run("with many senders", func(t *testing.T) {
+		operations := []*types.Operation{
+			{Account: addressToAccountIdentifier("alice"), Amount: extension.valueToCustomAmount("-10", "FOO-abcdef")},
+			{Account: addressToAccountIdentifier("carol"), Amount: extension.valueToCustomAmount("-20", "BAR-abcdef")},
+		}
+
+		_, _, _, err := extractMultiTransferFromOperations(operations)
+		require.ErrorContains(t, err, "multi-token transfers must have a single sender")
+	})
+
+	t.Run("with many receivers", func(t *testing.T) {
+		operations := []*types.Operation{
+			{Account: addressToAccountIdentifier("bob"), Amount: extension.valueToCustomAmount("10", "FOO-abcdef")},
+			{Account: addressToAccountIdentifier("carol"), Amount: extension.valueToCustomAmount("20", "BAR-abcdef")},
+		}
+
+		_, _, _, err := extractMultiTransferFromOperations(operations)
+		require.ErrorContains(t, err, "multi-token transfers must have a single receiver")
+	})
+
+	t.Run("with bad amount", func(t *testing.T) {
+		operations := []*types.Operation{
+			{Account: addressToAccountIdentifier("alice"), Amount: extension.valueToCustomAmount("-foo", "FOO-abcdef")},
+		}
+
+		_, _, _, err := extractMultiTransferFromOperations(operations)
+		require.ErrorContains(t, err, "cannot parse amount of operation: -foo")
+	})
+}
+
+func TestParseMultiTransfer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("with native and custom currencies", func(t *testing.T) {
+		receiverPubKey, transfers, err := parseMultiTransfer("MultiESDTNFTTransfer@8049d639e5a6980d1cd2392abcce41029cda74a1563523a202f09641cc2618f8@02@45474c442d303030303030@@03e8@4e46542d616263646566@0a@01")
+		require.Nil(t, err)
+		require.Equal(t, testscommon.TestPubKeyBob, receiverPubKey)
+		require.Equal(t, []*parsedTransfer{
+			{tokenIdentifier: "EGLD-000000", amount: "1000", isNative: true},
+			{tokenIdentifier: "NFT-abcdef-0a", amount: "1", isNative: false},
+		}, transfers)
+	})
+
+	t.Run("with bad number of arguments", func(t *testing.T) {
+		_, _, err := parseMultiTransfer("MultiESDTNFTTransfer@8049d639e5a6980d1cd2392abcce41029cda74a1563523a202f09641cc2618f8@02@45474c442d303030303030@@03e8")
+		require.ErrorContains(t, err, "bad number of arguments for multi-token transfer")
+	})
+
+	t.Run("with missing number of transfers", func(t *testing.T) {
+		_, _, err := parseMultiTransfer("MultiESDTNFTTransfer@8049d639e5a6980d1cd2392abcce41029cda74a1563523a202f09641cc2618f8")
+		require.ErrorContains(t, err, "cannot parse data of multi-token transfer")
+	})
+
+	t.Run("with number of transfers that overflows (when multiplied by the number of arguments per transfer)", func(t *testing.T) {
+		// 0x5555555555555556 * 3 = 2 (mod 2^64)
+		_, _, err := parseMultiTransfer("MultiESDTNFTTransfer@010203@5555555555555556@aa@bb")
+		require.ErrorContains(t, err, "bad number of arguments for multi-token transfer")
+	})
+}
+
+func TestParseNumTransfersOfMultiTransfer(t *testing.T) {
+	t.Parallel()
+
+	numTransfers, err := parseNumTransfersOfMultiTransfer([]string{"MultiESDTNFTTransfer", "aa", "02", "bb", "", "01", "cc", "", "01"})
+	require.Nil(t, err)
+	require.Equal(t, 2, numTransfers)
+
+	// The transfers might be followed by a contract call.
+	numTransfers, err = parseNumTransfersOfMultiTransfer([]string{"MultiESDTNFTTransfer", "aa", "01", "bb", "", "01", "616464"})
+	require.Nil(t, err)
+	require.Equal(t, 1, numTransfers)
+
+	_, err = parseNumTransfersOfMultiTransfer([]string{"MultiESDTNFTTransfer", "aa", "03", "bb", "", "01", "cc", "", "01"})
+	require.ErrorContains(t, err, "bad number of arguments for multi-token transfer")
+
+	_, err = parseNumTransfersOfMultiTransfer([]string{"MultiESDTNFTTransfer", "aa", "ffffffffffffffff", "bb", "", "01"})
+	require.ErrorContains(t, err, "bad number of arguments for multi-token transfer")
+
+	_, err = parseNumTransfersOfMultiTransfer([]string{"MultiESDTNFTTransfer", "aa", "00"})
+	require.ErrorContains(t, err, "cannot decode number of transfers of multi-token transfer")
+
+	_, err = parseNumTransfersOfMultiTransfer([]string{"MultiESDTNFTTransfer", "aa"})
+	require.ErrorContains(t, err, "cannot parse data of multi-token transfer")
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-proxy-go/data"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+)
+
+const (
+	herotagPrefix     = "@"
+	usernameSuffix    = ".elrond"
+	minUsernameLength = 3
+	maxUsernameLength = 25
+)
+
+// isUsername returns true if the receiver is given as a username (herotag), e.g. "@alice" or "alice.elrond", instead of an address
+func isUsername(receiver string) bool {
+	return strings.HasPrefix(receiver, herotagPrefix) || strings.HasSuffix(receiver, usernameSuffix)
+}
+
+// normalizeUsername converts a herotag (e.g. "@Alice") to the username registered in the DNS (e.g. "alice.elrond")
+func normalizeUsername(receiver string) (string, error) {
+	name := strings.TrimPrefix(receiver, herotagPrefix)
+	name = strings.TrimSuffix(name, usernameSuffix)
+	name = strings.ToLower(name)
+
+	if len(name) < minUsernameLength || len(name) > maxUsernameLength {
+		return "", fmt.Errorf("bad username: '%s' (must have between %d and %d characters)", receiver, minUsernameLength, maxUsernameLength)
+	}
+
+	for _, character := range name {
+		isLetter := character >= 'a' && character <= 'z'
+		isDigit := character >= '0' && character <= '9'
+		if !isLetter && !isDigit {
+			return "", fmt.Errorf("bad username: '%s' (only letters and digits are allowed)", receiver)
+		}
+	}
+
+	return name + usernameSuffix, nil
+}
+
+// resolveReceiverUsername replaces a receiver given as a username with its address (as resolved through the DNS).
+// The username is retained in the options, as "receiverUsername".
+func (service *constructionService) resolveReceiverUsername(options *constructionOptions) *types.Error {
+	if !isUsername(options.Receiver) {
+		return nil
+	}
+
+	if service.provider.IsOffline() {
+		err := fmt.Errorf("cannot resolve username '%s' in offline mode, the address of the receiver should be provided instead", options.Receiver)
+		return service.errFactory.newErrWithOriginal(ErrOfflineMode, err)
+	}
+
+	username, err := normalizeUsername(options.Receiver)
+	if err != nil {
+		return service.errFactory.newErrWithOriginal(ErrConstruction, err)
+	}
+
+	address, err := service.provider.ResolveUsername(username)
+	if errors.Is(err, resources.ErrUsernameNotFound) {
+		return service.errFactory.newErrWithOriginal(ErrUnableToResolveUsername, err)
+	}
+	if err != nil {
+		// Failures to query the DNS (e.g. the observer is unreachable) are transient, thus retriable.
+		return service.errFactory.newErrWithOriginal(ErrUnableToQueryUsername, err)
+	}
+
+	log.Debug("constructionService.resolveReceiverUsername()", "username", username, "address", address)
+
+	options.Receiver = address
+	options.ReceiverUsername = username
+	return nil
+}
+
+// addUsernameMappingToParsedMetadata flags (in the metadata returned by "/construction/parse") the username the receiver has been resolved from, if any
+func addUsernameMappingToParsedMetadata(tx *data.Transaction, metadata objectsMap) objectsMap {
+	if len(tx.ReceiverUsername) == 0 {
+		return metadata
+	}
+
+	result := make(objectsMap, len(metadata)+2)
+	for key, value := range metadata {
+		result[key] = value
+	}
+
+	result["receiverUsername"] = string(tx.ReceiverUsername)
+	result["resolvedReceiver"] = tx.Receiver
+	return result
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/multiversx/mx-chain-proxy-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsUsername(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, isUsername("@alice"))
+	require.True(t, isUsername("alice.elrond"))
+	require.False(t, isUsername("erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th"))
+	require.False(t, isUsername("alice"))
+	require.False(t, isUsername(""))
+}
+
+func TestNormalizeUsername(t *testing.T) {
+	t.Parallel()
+
+	username, err := normalizeUsername("@alice")
+	require.Nil(t, err)
+	require.Equal(t, "alice.elrond", username)
+
+	username, err = normalizeUsername("@Alice42")
+	require.Nil(t, err)
+	require.Equal(t, "alice42.elrond", username)
+
+	username, err = normalizeUsername("alice.elrond")
+	require.Nil(t, err)
+	require.Equal(t, "alice.elrond", username)
+
+	_, err = normalizeUsername("@al")
+	require.ErrorContains(t, err, "bad username: '@al' (must have between 3 and 25 characters)")
+
+	_, err = normalizeUsername("@abcdefghijklmnopqrstuvwxyz")
+	require.ErrorContains(t, err, "must have between 3 and 25 characters")
+
+	_, err = normalizeUsername("@alice.bob")
+	require.ErrorContains(t, err, "bad username: '@alice.bob' (only letters and digits are allowed)")
+}
+
+func TestAddUsernameMappingToParsedMetadata(t *testing.T) {
+	t.Parallel()
+
+	metadata := objectsMap{"function": "add"}
+
+	require.Equal(t, metadata, addUsernameMappingToParsedMetadata(&data.Transaction{Receiver: "erd1bob"}, metadata))
+	require.Nil(t, addUsernameMappingToParsedMetadata(&data.Transaction{Receiver: "erd1bob"}, nil))
+
+	tx := &data.Transaction{Receiver: "erd1bob", ReceiverUsername: []byte("bob.elrond")}
+	require.Equal(t, objectsMap{
+		"function":         "add",
+		"receiverUsername": "bob.elrond",
+		"resolvedReceiver": "erd1bob",
+	}, addUsernameMappingToParsedMetadata(tx, metadata))
+
+	// The original metadata is not altered.
+	require.Equal(t, objectsMap{"function": "add"}, metadata)
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-rosetta/server/provider"
+)
+
+// Amounts larger than this number of whole units (of any currency) are rejected, as they are most probably given in the wrong denomination.
+const maxNumDigitsOfWholeAmount = 15
+
+// validateStrictly checks the addresses, the currencies and the amounts of the options, beyond their mere presence (see "constructionOptions.validate").
+// It should be called after the receiver's username (if any) has been resolved.
+func (service *constructionService) validateStrictly(options *constructionOptions) *types.Error {
+	_, err := service.provider.ConvertAddressToPubKey(options.Sender)
+	if err != nil {
+		return service.newErrOfOption(ErrInvalidSenderAddress, "sender", err)
+	}
+
+	if len(options.Receiver) > 0 {
+		_, err = service.provider.ConvertAddressToPubKey(options.Receiver)
+		if err != nil {
+			return service.newErrOfOption(ErrInvalidReceiverAddress, "receiver", err)
+		}
+	}
+
+	if len(options.Guardian) > 0 {
+		_, err = service.provider.ConvertAddressToPubKey(options.Guardian)
+		if err != nil {
+			return service.newErrOfOption(ErrInvalidAccountAddress, "guardian", err)
+		}
+	}
+
+	if len(options.Relayer) > 0 {
+		_, err = service.provider.ConvertAddressToPubKey(options.Relayer)
+		if err != nil {
+			return service.newErrOfOption(ErrInvalidAccountAddress, "relayer", err)
+		}
+	}
+
+	if options.isDelegation() {
+		return service.validateAmount("delegation.amount", options.Delegation.Amount, service.extension.getNativeCurrencySymbol())
+	}
+
+	if len(options.CurrencySymbol) > 0 {
+		errTyped := service.validateCurrencyAndAmount("currencySymbol", options.CurrencySymbol, "amount", options.Amount)
+		if errTyped != nil {
+			return errTyped
+		}
+	}
+
+	for i, transfer := range options.Transfers {
+		amountOptionName := fmt.Sprintf("transfers[%d].amount", i)
+
+		// Within multi-token transfers, the native currency can also be given as "EGLD-000000".
+		if service.isNativeCurrencyOfMultiTransfer(transfer.CurrencySymbol) {
+			errTyped := service.validateAmount(amountOptionName, transfer.Amount, service.extension.getNativeCurrencySymbol())
+			if errTyped != nil {
+				return errTyped
+			}
+
+			continue
+		}
+
+		errTyped := service.validateCurrencyAndAmount(
+			fmt.Sprintf("transfers[%d].currencySymbol", i),
+			transfer.CurrencySymbol,
+			amountOptionName,
+			transfer.Amount,
+		)
+		if errTyped != nil {
+			return errTyped
+		}
+	}
+
+	return nil
+}
+
+func (service *constructionService) validateCurrencyAndAmount(currencyOptionName string, currencySymbol string, amountOptionName string, amount string) *types.Error {
+	_, _, err := provider.ParseTokenIdentifier(currencySymbol)
+	if err != nil && !service.extension.isNativeCurrencySymbol(currencySymbol) {
+		return service.newErrOfOption(ErrInvalidCurrency, currencyOptionName, err)
+	}
+
+	if !service.isSupportedCurrency(currencySymbol) {
+		err := fmt.Errorf("currency %s is neither native, nor configured as a custom currency", currencySymbol)
+		return service.newErrOfOption(ErrUnsupportedCurrency, currencyOptionName, err)
+	}
+
+	return service.validateAmount(amountOptionName, amount, currencySymbol)
+}
+
+// isSupportedCurrency returns true for the native currency and for the configured custom currencies.
+// For SFTs, NFTs and MetaESDTs, the collection (e.g. "NFT-abcdef", for "NFT-abcdef-0a") should be configured.
+func (service *constructionService) isSupportedCurrency(symbol string) bool {
+	if service.extension.isNativeCurrencySymbol(symbol) {
+		return true
+	}
+
+	baseIdentifier, _, err := provider.ParseTokenIdentifier(symbol)
+	if err != nil {
+		return false
+	}
+
+	return service.provider.HasCustomCurrency(baseIdentifier)
+}
+
+// validateAmount checks that a (non-empty, non-zero) amount is a positive integer (in the smallest units of the currency), within the accepted range
+func (service *constructionService) validateAmount(optionName string, amount string, currencySymbol string) *types.Error {
+	if isZeroAmount(amount) {
+		return nil
+	}
+
+	if !isCanonicalPositiveInteger(amount) {
+		err := fmt.Errorf("%s is not a positive integer (in the smallest units of the currency)", amount)
+		return service.newErrOfOption(ErrInvalidAmount, optionName, err)
+	}
+
+	value, _ := big.NewInt(0).SetString(amount, 10)
+	decimals := service.getDecimalsOfCurrency(currencySymbol)
+	maxValue := big.NewInt(0).Exp(big.NewInt(10), big.NewInt(int64(decimals)+maxNumDigitsOfWholeAmount), nil)
+
+	if value.Cmp(maxValue) >= 0 {
+		err := fmt.Errorf("%s is too large (currency %s has %d decimals)", amount, currencySymbol, decimals)
+		return service.newErrOfOption(ErrAmountOutOfRange, optionName, err)
+	}
+
+	return nil
+}
+
+// newErrOfOption creates a validation error, designating the faulty option (also) in its details
+func (service *constructionService) newErrOfOption(code errCode, optionName string, err error) *types.Error {
+	originalError := fmt.Errorf("bad option '%s': %w", optionName, err)
+	return service.errFactory.newErrWithDetails(code, originalError, map[string]interface{}{"option": optionName})
+}
+
+func (service *constructionService) getDecimalsOfCurrency(symbol string) int32 {
+	if service.extension.isNativeCurrencySymbol(symbol) {
+		return service.provider.GetNativeCurrency().Decimals
+	}
+
+	baseIdentifier, _, err := provider.ParseTokenIdentifier(symbol)
+	if err != nil {
+		return 0
+	}
+
+	currency, _ := service.provider.GetCustomCurrencyBySymbol(baseIdentifier)
+	return currency.Decimals
+}
+
+// isCanonicalPositiveInteger returns true for strings such as "1" or "1000000000000000000", and false for strings such as "0", "01", "+1", "1.5" or "1e18"
+func isCanonicalPositiveInteger(value string) bool {
+	if len(value) == 0 || value[0] == '0' {
+		return false
+	}
+
+	for _, character := range value {
+		if character < '0' || character > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// addShardsToMetadata adds (as information only) the shards of the sender and of the receiver to the metadata returned by "/construction/metadata"
+func (service *constructionService) addShardsToMetadata(metadata *constructionMetadata, metadataAsObjectsMap objectsMap) {
+	senderPubKey, err := service.provider.ConvertAddressToPubKey(metadata.Sender)
+	if err == nil {
+		metadataAsObjectsMap["senderShard"] = service.provider.ComputeShardIdOfPubKey(senderPubKey)
+	}
+
+	receiverPubKey, err := service.provider.ConvertAddressToPubKey(metadata.Receiver)
+	if err == nil {
+		metadataAsObjectsMap["receiverShard"] = service.provider.ComputeShardIdOfPubKey(receiverPubKey)
+	}
+}
@@ -0,0 +1,185 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsCanonicalPositiveInteger(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, isCanonicalPositiveInteger("1"))
+	require.True(t, isCanonicalPositiveInteger("1000000000000000000"))
+
+	require.False(t, isCanonicalPositiveInteger(""))
+	require.False(t, isCanonicalPositiveInteger("0"))
+	require.False(t, isCanonicalPositiveInteger("01"))
+	require.False(t, isCanonicalPositiveInteger("+1"))
+	require.False(t, isCanonicalPositiveInteger("-1"))
+	require.False(t, isCanonicalPositiveInteger("1.5"))
+	require.False(t, isCanonicalPositiveInteger("1e18"))
+	require.False(t, isCanonicalPositiveInteger("1 000"))
+}
+
+func TestConstructionService_ValidateStrictly(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	networkProvider.MockCustomCurrencies = []resources.Currency{{Symbol: "USDC-c76f1f", Decimals: 6}, {Symbol: "NFT-abcdef"}}
+	service := createConstructionService(networkProvider)
+
+	validate := func(options *constructionOptions) errCode {
+		errTyped := service.validateStrictly(options)
+		if errTyped == nil {
+			return 0
+		}
+
+		return errCode(errTyped.Code)
+	}
+
+	alice, bob := testscommon.TestAddressAlice, testscommon.TestAddressBob
+
+	require.Equal(t, errCode(0), validate(&constructionOptions{Sender: alice, Receiver: bob, Amount: "1234", CurrencySymbol: "XeGLD"}))
+	require.Equal(t, errCode(0), validate(&constructionOptions{Sender: alice, Receiver: bob, Amount: "1234", CurrencySymbol: "USDC-c76f1f"}))
+	require.Equal(t, errCode(0), validate(&constructionOptions{Sender: alice, Receiver: bob, Amount: "1", CurrencySymbol: "NFT-abcdef-0a"}))
+	require.Equal(t, errCode(0), validate(&constructionOptions{Sender: alice, Receiver: bob, Amount: "0", CurrencySymbol: "XeGLD"}))
+	require.Equal(t, errCode(0), validate(&constructionOptions{Sender: alice}))
+
+	require.Equal(t, ErrInvalidSenderAddress, validate(&constructionOptions{Sender: "erd1bad", Receiver: bob}))
+	require.Equal(t, ErrInvalidReceiverAddress, validate(&constructionOptions{Sender: alice, Receiver: "erd1bad"}))
+	require.Equal(t, ErrInvalidReceiverAddress, validate(&constructionOptions{Sender: alice, Receiver: "bob"}))
+	require.Equal(t, ErrInvalidAccountAddress, validate(&constructionOptions{Sender: alice, Receiver: bob, Guardian: "erd1bad"}))
+	require.Equal(t, ErrInvalidAccountAddress, validate(&constructionOptions{Sender: alice, Receiver: bob, Relayer: "erd1bad"}))
+
+	require.Equal(t, ErrUnsupportedCurrency, validate(&constructionOptions{Sender: alice, Receiver: bob, Amount: "1234", CurrencySymbol: "TEST-abcdef"}))
+	require.Equal(t, ErrUnsupportedCurrency, validate(&constructionOptions{Sender: alice, Receiver: bob, Amount: "1", CurrencySymbol: "SFT-abcdef-01"}))
+	require.Equal(t, ErrInvalidCurrency, validate(&constructionOptions{Sender: alice, Receiver: bob, Amount: "1", CurrencySymbol: "NFT-abcdef-xyz"}))
+	require.Equal(t, ErrUnsupportedCurrency, validate(&constructionOptions{Sender: alice, Receiver: bob, Transfers: []*constructionTransfer{
+		{Amount: "1", CurrencySymbol: "XeGLD"},
+		{Amount: "1", CurrencySymbol: "TEST-abcdef"},
+	}}))
+
+	// Within multi-token transfers, the native currency can be given as "EGLD-000000" (with native decimals).
+	require.Equal(t, errCode(0), validate(&constructionOptions{Sender: alice, Receiver: bob, Transfers: []*constructionTransfer{
+		{Amount: "1000000000000000000", CurrencySymbol: "EGLD-000000"},
+		{Amount: "1", CurrencySymbol: "USDC-c76f1f"},
+	}}))
+	require.Equal(t, ErrAmountOutOfRange, validate(&constructionOptions{Sender: alice, Receiver: bob, Transfers: []*constructionTransfer{
+		{Amount: "1000000000000000000000000000000000", CurrencySymbol: "EGLD-000000"},
+	}}))
+	require.Equal(t, ErrInvalidAmount, validate(&constructionOptions{Sender: alice, Receiver: bob, Transfers: []*constructionTransfer{
+		{Amount: "1.5", CurrencySymbol: "EGLD-000000"},
+	}}))
+
+	require.Equal(t, ErrInvalidAmount, validate(&constructionOptions{Sender: alice, Receiver: bob, Amount: "1.5", CurrencySymbol: "XeGLD"}))
+	require.Equal(t, ErrInvalidAmount, validate(&constructionOptions{Sender: alice, Receiver: bob, Amount: "-5", CurrencySymbol: "XeGLD"}))
+	require.Equal(t, ErrInvalidAmount, validate(&constructionOptions{Sender: alice, Receiver: bob, Amount: "+5", CurrencySymbol: "XeGLD"}))
+	require.Equal(t, ErrInvalidAmount, validate(&constructionOptions{Sender: alice, Receiver: bob, Transfers: []*constructionTransfer{
+		{Amount: "1e18", CurrencySymbol: "XeGLD"},
+	}}))
+
+	// 10^15 whole units is the limit: 10^(18 + 15) for the native currency, 10^(6 + 15) for USDC.
+	require.Equal(t, errCode(0), validate(&constructionOptions{Sender: alice, Receiver: bob, Amount: "999999999999999999999999999999999", CurrencySymbol: "XeGLD"}))
+	require.Equal(t, ErrAmountOutOfRange, validate(&constructionOptions{Sender: alice, Receiver: bob, Amount: "1000000000000000000000000000000000", CurrencySymbol: "XeGLD"}))
+	require.Equal(t, errCode(0), validate(&constructionOptions{Sender: alice, Receiver: bob, Amount: "999999999999999999999", CurrencySymbol: "USDC-c76f1f"}))
+	require.Equal(t, ErrAmountOutOfRange, validate(&constructionOptions{Sender: alice, Receiver: bob, Amount: "1000000000000000000000", CurrencySymbol: "USDC-c76f1f"}))
+
+	require.Equal(t, ErrInvalidAmount, validate(&constructionOptions{Sender: alice, Receiver: bob, Delegation: &constructionDelegation{Function: "delegate", Amount: "1.5"}}))
+}
+
+func TestConstructionService_StrictValidationInPreprocessAndMetadata(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	networkProvider.MockAccountsByAddress[testscommon.TestAddressAlice] = &resources.Account{
+		Address: testscommon.TestAddressAlice,
+		Nonce:   42,
+	}
+
+	service := createConstructionService(networkProvider)
+
+	t.Run("preprocess, with bad receiver", func(t *testing.T) {
+		t.Parallel()
+
+		_, errTyped := service.ConstructionPreprocess(context.Background(),
+			&types.ConstructionPreprocessRequest{
+				Metadata: objectsMap{
+					"sender":         testscommon.TestAddressAlice,
+					"receiver":       "erd1spyavw0956vq68xj8y4tenjpq2wd5a9p2c6j8gsz7ztyrnpxrruqzu66jy",
+					"amount":         "1234",
+					"currencySymbol": "XeGLD",
+				},
+			},
+		)
+
+		require.Equal(t, ErrInvalidReceiverAddress, errCode(errTyped.Code))
+		require.Contains(t, errTyped.Details["originalError"], "bad option 'receiver'")
+		require.Equal(t, "receiver", errTyped.Details["option"])
+	})
+
+	t.Run("metadata, with bad guardian and bad relayer", func(t *testing.T) {
+		t.Parallel()
+
+		for _, optionName := range []string{"guardian", "relayer"} {
+			_, errTyped := service.ConstructionMetadata(context.Background(),
+				&types.ConstructionMetadataRequest{
+					Options: objectsMap{
+						"sender":         testscommon.TestAddressAlice,
+						"receiver":       testscommon.TestAddressBob,
+						"amount":         "1234",
+						"currencySymbol": "XeGLD",
+						optionName:       "erd1bad",
+					},
+				},
+			)
+
+			require.Equal(t, ErrInvalidAccountAddress, errCode(errTyped.Code))
+			require.Contains(t, errTyped.Details["originalError"], fmt.Sprintf("bad option '%s'", optionName))
+			require.Equal(t, optionName, errTyped.Details["option"])
+		}
+	})
+
+	t.Run("metadata, with unsupported currency", func(t *testing.T) {
+		t.Parallel()
+
+		_, errTyped := service.ConstructionMetadata(context.Background(),
+			&types.ConstructionMetadataRequest{
+				Options: objectsMap{
+					"sender":         testscommon.TestAddressAlice,
+					"receiver":       testscommon.TestAddressBob,
+					"amount":         "1234",
+					"currencySymbol": "TEST-abcdef",
+				},
+			},
+		)
+
+		require.Equal(t, ErrUnsupportedCurrency, errCode(errTyped.Code))
+		require.Equal(t, "bad option 'currencySymbol': currency TEST-abcdef is neither native, nor configured as a custom currency", errTyped.Details["originalError"])
+		require.Equal(t, "currencySymbol", errTyped.Details["option"])
+	})
+
+	t.Run("metadata, with shards of sender and receiver", func(t *testing.T) {
+		t.Parallel()
+
+		response, errTyped := service.ConstructionMetadata(context.Background(),
+			&types.ConstructionMetadataRequest{
+				Options: objectsMap{
+					"sender":         testscommon.TestAddressAlice,
+					"receiver":       testscommon.TestAddressBob,
+					"amount":         "1234",
+					"currencySymbol": "XeGLD",
+				},
+			},
+		)
+
+		require.Nil(t, errTyped)
+		require.Equal(t, uint32(1), response.Metadata["senderShard"])
+		require.Equal(t, uint32(0), response.Metadata["receiverShard"])
+	})
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"encoding/hex"
 	"math/big"
+	"strconv"
 
 	"github.com/coinbase/rosetta-sdk-go/types"
 	"github.com/multiversx/mx-chain-core-go/data/api"
@@ -107,3 +108,18 @@ func hexToAmount(hexString string) (string, error) {
 	amountBig := big.NewInt(0).SetBytes(amountBytes)
 	return amountBig.String(), nil
 }
+
+func nonceToHex(nonce uint64) string {
+	if nonce == 0 {
+		// Same as for amounts: zero is encoded as an empty string.
+		return ""
+	}
+
+	encoded := strconv.FormatUint(nonce, 16)
+	encoded = ensureEvenLengthOfHexString(encoded)
+	return encoded
+}
+
+func hexToNonce(hexString string) (uint64, error) {
+	return strconv.ParseUint(hexString, 16, 64)
+}
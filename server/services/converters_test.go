@@ -64,3 +64,23 @@ func TestHexToAmount(t *testing.T) {
 	require.Nil(t, err)
 	require.Equal(t, "100", amount)
 }
+
+func TestNonceToHex(t *testing.T) {
+	require.Equal(t, "", nonceToHex(0))
+	require.Equal(t, "07", nonceToHex(7))
+	require.Equal(t, "0a", nonceToHex(10))
+	require.Equal(t, "0100", nonceToHex(256))
+}
+
+func TestHexToNonce(t *testing.T) {
+	nonce, err := hexToNonce("0a")
+	require.Nil(t, err)
+	require.Equal(t, uint64(10), nonce)
+
+	nonce, err = hexToNonce("0100")
+	require.Nil(t, err)
+	require.Equal(t, uint64(256), nonce)
+
+	_, err = hexToNonce("xyz")
+	require.Error(t, err)
+}
@@ -28,6 +28,17 @@ const (
 	ErrInvalidInputParam
 	ErrOfflineMode
 	ErrUnableToGetGenesisBlock
+	ErrUnableToEstimateGasLimit
+	ErrTransactionWouldFail
+	ErrUnableToResolveUsername
+	ErrInvalidSenderAddress
+	ErrInvalidReceiverAddress
+	ErrUnsupportedCurrency
+	ErrInvalidAmount
+	ErrAmountOutOfRange
+	ErrMaxFeeExceeded
+	ErrInvalidCurrency
+	ErrUnableToQueryUsername
 )
 
 type errPrototype struct {
@@ -146,6 +157,61 @@ func createErrPrototypes() ([]errPrototype, map[errCode]errPrototype) {
 			message:   "unable to get genesis block",
 			retriable: true,
 		},
+		{
+			code:      ErrUnableToEstimateGasLimit,
+			message:   "unable to estimate gas limit",
+			retriable: false,
+		},
+		{
+			code:      ErrTransactionWouldFail,
+			message:   "transaction would fail (as predicted by simulation)",
+			retriable: false,
+		},
+		{
+			code:      ErrUnableToResolveUsername,
+			message:   "unable to resolve username",
+			retriable: false,
+		},
+		{
+			code:      ErrInvalidSenderAddress,
+			message:   "invalid sender address (cannot be decoded as bech32)",
+			retriable: false,
+		},
+		{
+			code:      ErrInvalidReceiverAddress,
+			message:   "invalid receiver address (cannot be decoded as bech32)",
+			retriable: false,
+		},
+		{
+			code:      ErrUnsupportedCurrency,
+			message:   "unsupported currency (neither native, nor configured as a custom currency)",
+			retriable: false,
+		},
+		{
+			code:      ErrInvalidAmount,
+			message:   "invalid amount (must be a positive integer, in the smallest units of the currency)",
+			retriable: false,
+		},
+		{
+			code:      ErrAmountOutOfRange,
+			message:   "amount out of range (too large, given the decimals of the currency)",
+			retriable: false,
+		},
+		{
+			code:      ErrMaxFeeExceeded,
+			message:   "the fee of the transaction exceeds the provided max_fee",
+			retriable: false,
+		},
+		{
+			code:      ErrInvalidCurrency,
+			message:   "invalid currency (malformed token identifier)",
+			retriable: false,
+		},
+		{
+			code:      ErrUnableToQueryUsername,
+			message:   "unable to query the DNS for the username (observer failure)",
+			retriable: true,
+		},
 	}
 
 	prototypesMap := make(map[errCode]errPrototype)
@@ -180,6 +246,16 @@ func (factory *errFactory) newErrWithOriginal(code errCode, originalError error)
 	return err
 }
 
+// newErrWithDetails creates an error holding the original error, along with additional (structured) details
+func (factory *errFactory) newErrWithDetails(code errCode, originalError error, details map[string]interface{}) *types.Error {
+	err := factory.newErrWithOriginal(code, originalError)
+	for key, value := range details {
+		err.Details[key] = value
+	}
+
+	return err
+}
+
 func (factory *errFactory) newErr(code errCode) *types.Error {
 	prototype := factory.getPrototypeByCode(code)
 
@@ -200,4 +276,12 @@ func (factory *errFactory) getPrototypeByCode(code errCode) errPrototype {
 }
 
 var errCannotRecognizeEvent = errors.New("cannot recognize transaction event")
+var errBadEventHandler = errors.New("bad transaction event handler")
+var errEventHandlerAlreadyRegistered = errors.New("transaction event handler already registered")
 var errCannotParseRelayedV1 = errors.New("cannot parse relayed V1 transaction")
+var errNothingToExplain = errors.New("either a block identifier or a transaction hash must be provided")
+var errCannotExplainGenesisBlock = errors.New("the genesis block cannot be explained")
+var errTransactionNotInBlock = errors.New("transaction is not (yet) in a block")
+var errInvariantViolated = errors.New("invariant violated")
+var errUnknownInvariant = errors.New("unknown invariant")
+var errCannotParseBalance = errors.New("cannot parse balance")
@@ -0,0 +1,60 @@
+package services
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/coinbase/rosetta-sdk-go/server"
+)
+
+type eventsDiagnosticsController struct {
+	txsTransformer *transactionsTransformer
+	routes         []server.Route
+}
+
+type eventsDiagnosticsResponse struct {
+	IsStrictMode           bool                     `json:"is_strict_mode"`
+	BalanceAffectingEvents []string                 `json:"balance_affecting_events"`
+	UnrecognizedEvents     []unrecognizedEventStats `json:"unrecognized_events"`
+}
+
+// NewEventsDiagnosticsController creates a controller (non-Rosetta routes) for inspecting the events (of transactions in blocks) that Rosetta couldn't recognize
+func NewEventsDiagnosticsController(txsTransformer *transactionsTransformer) *eventsDiagnosticsController {
+	controller := &eventsDiagnosticsController{
+		txsTransformer: txsTransformer,
+	}
+
+	controller.routes = []server.Route{
+		{
+			Method:      http.MethodPost,
+			Pattern:     "/diagnostics/events",
+			HandlerFunc: controller.handleGetDiagnostics,
+		},
+	}
+
+	return controller
+}
+
+// Routes returns the routes of the controller
+func (controller *eventsDiagnosticsController) Routes() server.Routes {
+	return controller.routes
+}
+
+func (controller *eventsDiagnosticsController) handleGetDiagnostics(w http.ResponseWriter, _ *http.Request) {
+	registry := controller.txsTransformer.eventHandlers
+
+	balanceAffectingEvents := make([]string, 0, len(registry.balanceAffectingEvents))
+	for identifier := range registry.balanceAffectingEvents {
+		balanceAffectingEvents = append(balanceAffectingEvents, identifier)
+	}
+
+	sort.Strings(balanceAffectingEvents)
+
+	response := &eventsDiagnosticsResponse{
+		IsStrictMode:           registry.isStrict,
+		BalanceAffectingEvents: balanceAffectingEvents,
+		UnrecognizedEvents:     registry.diagnostics.getStats(),
+	}
+
+	server.EncodeJSONResponse(response, http.StatusOK, w)
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventsDiagnosticsController(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	networkProvider.MockNetworkConfig.BalanceAffectingEvents = []string{"upgradedTransfer"}
+	txsTransformer := newTransactionsTransformer(networkProvider)
+
+	controller := NewEventsDiagnosticsController(txsTransformer)
+	require.Len(t, controller.Routes(), 1)
+
+	_, err := txsTransformer.eventHandlers.produceOperations(&transaction.ApiTransactionResult{
+		Hash:       "aaaa",
+		BlockNonce: 7,
+		Logs: &transaction.ApiLogs{
+			Events: []*transaction.Events{
+				{Identifier: "upgradedTransfer"},
+			},
+		},
+	})
+	require.Nil(t, err)
+
+	_, err = txsTransformer.eventHandlers.produceOperations(&transaction.ApiTransactionResult{
+		Hash:       "aaaa",
+		BlockNonce: 7,
+		Logs: &transaction.ApiLogs{
+			Events: []*transaction.Events{
+				{Identifier: "ESDTLocalBurn", Topics: [][]byte{[]byte("FOO-abcdef")}},
+			},
+		},
+	})
+	require.ErrorIs(t, err, errCannotRecognizeEvent)
+
+	request := httptest.NewRequest(http.MethodPost, "/diagnostics/events", nil)
+	recorder := httptest.NewRecorder()
+	controller.Routes()[0].HandlerFunc(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	response := &eventsDiagnosticsResponse{}
+	err = json.Unmarshal(recorder.Body.Bytes(), response)
+	require.Nil(t, err)
+
+	require.False(t, response.IsStrictMode)
+	require.Contains(t, response.BalanceAffectingEvents, "upgradedTransfer")
+	require.Contains(t, response.BalanceAffectingEvents, "ESDTTransfer")
+	require.Equal(t, []unrecognizedEventStats{
+		{
+			Identifier:         "ESDTLocalBurn",
+			IsBalanceAffecting: true,
+			NumMalformed:       1,
+			LastTxHash:         "aaaa",
+			LastBlockNonce:     7,
+			LastError:          "cannot recognize transaction event: bad number of topics for ESDTLocalBurn event = 1",
+		},
+		{
+			Identifier:         "upgradedTransfer",
+			IsBalanceAffecting: true,
+			NumUnknown:         1,
+			LastTxHash:         "aaaa",
+			LastBlockNonce:     7,
+		},
+	}, response.UnrecognizedEvents)
+}
@@ -0,0 +1,52 @@
+package services
+
+import (
+	"net/http"
+
+	"github.com/coinbase/rosetta-sdk-go/server"
+)
+
+type invariantsDiagnosticsController struct {
+	txsTransformer *transactionsTransformer
+	routes         []server.Route
+}
+
+type invariantsDiagnosticsResponse struct {
+	IsStrictMode      bool                      `json:"is_strict_mode"`
+	EnabledInvariants []string                  `json:"enabled_invariants"`
+	Violations        []invariantViolationStats `json:"violations"`
+}
+
+// NewInvariantsDiagnosticsController creates a controller (non-Rosetta routes) for inspecting the violations of the invariants checked on transformed blocks
+func NewInvariantsDiagnosticsController(txsTransformer *transactionsTransformer) *invariantsDiagnosticsController {
+	controller := &invariantsDiagnosticsController{
+		txsTransformer: txsTransformer,
+	}
+
+	controller.routes = []server.Route{
+		{
+			Method:      http.MethodPost,
+			Pattern:     "/diagnostics/invariants",
+			HandlerFunc: controller.handleGetDiagnostics,
+		},
+	}
+
+	return controller
+}
+
+// Routes returns the routes of the controller
+func (controller *invariantsDiagnosticsController) Routes() server.Routes {
+	return controller.routes
+}
+
+func (controller *invariantsDiagnosticsController) handleGetDiagnostics(w http.ResponseWriter, _ *http.Request) {
+	checker := controller.txsTransformer.invariants
+
+	response := &invariantsDiagnosticsResponse{
+		IsStrictMode:      checker.isStrict,
+		EnabledInvariants: checker.getEnabledInvariants(),
+		Violations:        checker.diagnostics.getStats(),
+	}
+
+	server.EncodeJSONResponse(response, http.StatusOK, w)
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvariantsDiagnosticsController(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	networkProvider.MockNetworkConfig.CheckedInvariants = []string{invariantSignsOfAmounts}
+	txsTransformer := newTransactionsTransformer(networkProvider)
+	extension := newNetworkProviderExtension(networkProvider)
+
+	controller := NewInvariantsDiagnosticsController(txsTransformer)
+	require.Len(t, controller.Routes(), 1)
+
+	err := txsTransformer.invariants.checkTransaction(7, nil, &types.Transaction{
+		TransactionIdentifier: hashToTransactionIdentifier("aaaa"),
+		Operations: []*types.Operation{
+			{
+				Type:    opFeeRefund,
+				Account: addressToAccountIdentifier(testscommon.TestAddressAlice),
+				Amount:  extension.valueToNativeAmount("-1"),
+			},
+		},
+	})
+	require.Nil(t, err)
+
+	request := httptest.NewRequest(http.MethodPost, "/diagnostics/invariants", nil)
+	recorder := httptest.NewRecorder()
+	controller.Routes()[0].HandlerFunc(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	response := &invariantsDiagnosticsResponse{}
+	err = json.Unmarshal(recorder.Body.Bytes(), response)
+	require.Nil(t, err)
+
+	require.False(t, response.IsStrictMode)
+	require.Equal(t, []string{invariantSignsOfAmounts}, response.EnabledInvariants)
+	require.Equal(t, []invariantViolationStats{
+		{
+			Invariant:      invariantSignsOfAmounts,
+			NumViolations:  1,
+			LastTxHash:     "aaaa",
+			LastBlockNonce: 7,
+			LastError:      "operation FeeRefund has a negative amount: -1",
+		},
+	}, response.Violations)
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/coinbase/rosetta-sdk-go/server"
+)
+
+type nonceReservationsController struct {
+	provider NetworkProvider
+	nonces   *nonceReservations
+	routes   []server.Route
+}
+
+type nonceReservationsRequest struct {
+	Address string  `json:"address"`
+	Nonce   *uint64 `json:"nonce,omitempty"`
+}
+
+type nonceReservationsResponse struct {
+	Address        string   `json:"address"`
+	ReservedNonces []uint64 `json:"reserved_nonces"`
+	NumReleased    int      `json:"num_released"`
+	NetworkNonce   *uint64  `json:"network_nonce,omitempty"`
+}
+
+type nonceReservationsErrorResponse struct {
+	Message string `json:"message"`
+}
+
+// NewNonceReservationsController creates a controller (non-Rosetta routes) for inspecting, releasing and resyncing the nonces reserved by the construction service
+func NewNonceReservationsController(networkProvider NetworkProvider, nonces *nonceReservations) *nonceReservationsController {
+	controller := &nonceReservationsController{
+		provider: networkProvider,
+		nonces:   nonces,
+	}
+
+	controller.routes = []server.Route{
+		{
+			Method:      http.MethodPost,
+			Pattern:     "/nonces/reservations",
+			HandlerFunc: controller.handleGetReservations,
+		},
+		{
+			Method:      http.MethodPost,
+			Pattern:     "/nonces/release",
+			HandlerFunc: controller.handleRelease,
+		},
+		{
+			Method:      http.MethodPost,
+			Pattern:     "/nonces/resync",
+			HandlerFunc: controller.handleResync,
+		},
+	}
+
+	return controller
+}
+
+// Routes returns the routes of the controller
+func (controller *nonceReservationsController) Routes() server.Routes {
+	return controller.routes
+}
+
+func (controller *nonceReservationsController) handleGetReservations(w http.ResponseWriter, r *http.Request) {
+	request, err := controller.decodeRequest(r)
+	if err != nil {
+		controller.respondWithError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	controller.respond(w, &nonceReservationsResponse{
+		Address:        request.Address,
+		ReservedNonces: controller.nonces.getReservedNonces(request.Address),
+	})
+}
+
+// handleRelease releases a given nonce of the sender, or all the reserved nonces (if no nonce is given)
+func (controller *nonceReservationsController) handleRelease(w http.ResponseWriter, r *http.Request) {
+	request, err := controller.decodeRequest(r)
+	if err != nil {
+		controller.respondWithError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	numReleased := 0
+
+	if request.Nonce != nil {
+		if controller.nonces.release(request.Address, *request.Nonce) {
+			numReleased = 1
+		}
+	} else {
+		numReleased = controller.nonces.releaseAll(request.Address)
+	}
+
+	log.Info("nonceReservationsController.handleRelease()", "address", request.Address, "numReleased", numReleased)
+
+	controller.respond(w, &nonceReservationsResponse{
+		Address:        request.Address,
+		ReservedNonces: controller.nonces.getReservedNonces(request.Address),
+		NumReleased:    numReleased,
+	})
+}
+
+// handleResync releases all the reserved nonces of the sender, and returns the (fresh) network nonce
+func (controller *nonceReservationsController) handleResync(w http.ResponseWriter, r *http.Request) {
+	request, err := controller.decodeRequest(r)
+	if err != nil {
+		controller.respondWithError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	networkNonce, err := getNetworkNonceOfSender(controller.provider, request.Address)
+	if err != nil {
+		controller.respondWithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	numReleased := controller.nonces.releaseAll(request.Address)
+
+	log.Info("nonceReservationsController.handleResync()", "address", request.Address, "numReleased", numReleased, "networkNonce", networkNonce)
+
+	controller.respond(w, &nonceReservationsResponse{
+		Address:        request.Address,
+		ReservedNonces: []uint64{},
+		NumReleased:    numReleased,
+		NetworkNonce:   &networkNonce,
+	})
+}
+
+func (controller *nonceReservationsController) decodeRequest(r *http.Request) (*nonceReservationsRequest, error) {
+	request := &nonceReservationsRequest{}
+
+	err := json.NewDecoder(r.Body).Decode(request)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = controller.provider.ConvertAddressToPubKey(request.Address)
+	if err != nil {
+		return nil, errors.New("bad address")
+	}
+
+	return request, nil
+}
+
+func (controller *nonceReservationsController) respond(w http.ResponseWriter, response *nonceReservationsResponse) {
+	server.EncodeJSONResponse(response, http.StatusOK, w)
+}
+
+func (controller *nonceReservationsController) respondWithError(w http.ResponseWriter, status int, err error) {
+	server.EncodeJSONResponse(&nonceReservationsErrorResponse{Message: err.Error()}, status, w)
+}
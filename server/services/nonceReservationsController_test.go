@@ -0,0 +1,79 @@
+package services
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNonceReservationsController(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	networkProvider.MockNetworkConfig.ShouldReserveNonces = true
+	networkProvider.MockNetworkConfig.NonceReservationTTLSeconds = 120
+	networkProvider.MockAccountsByAddress[testscommon.TestAddressAlice] = &resources.Account{
+		Address: testscommon.TestAddressAlice,
+		Nonce:   42,
+	}
+
+	nonces := NewNonceReservations(networkProvider)
+	controller := NewNonceReservationsController(networkProvider, nonces)
+	require.Len(t, controller.Routes(), 3)
+
+	handlersByPattern := make(map[string]http.HandlerFunc)
+	for _, route := range controller.Routes() {
+		handlersByPattern[route.Pattern] = route.HandlerFunc
+	}
+
+	doRequest := func(pattern string, body string) (int, *nonceReservationsResponse) {
+		request := httptest.NewRequest(http.MethodPost, pattern, strings.NewReader(body))
+		recorder := httptest.NewRecorder()
+		handlersByPattern[pattern](recorder, request)
+
+		response := &nonceReservationsResponse{}
+		_ = json.Unmarshal(recorder.Body.Bytes(), response)
+		return recorder.Code, response
+	}
+
+	body := `{"address": "` + testscommon.TestAddressAlice + `"}`
+	bodyWithNonce := `{"address": "` + testscommon.TestAddressAlice + `", "nonce": 43}`
+
+	nonces.reserve(testscommon.TestAddressAlice, 42)
+	nonces.reserve(testscommon.TestAddressAlice, 42)
+	nonces.reserve(testscommon.TestAddressAlice, 42)
+
+	code, response := doRequest("/nonces/reservations", body)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, []uint64{42, 43, 44}, response.ReservedNonces)
+
+	code, response = doRequest("/nonces/release", bodyWithNonce)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, 1, response.NumReleased)
+	require.Equal(t, []uint64{42, 44}, response.ReservedNonces)
+
+	code, response = doRequest("/nonces/release", body)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, 2, response.NumReleased)
+	require.Empty(t, response.ReservedNonces)
+
+	nonces.reserve(testscommon.TestAddressAlice, 42)
+
+	code, response = doRequest("/nonces/resync", body)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, 1, response.NumReleased)
+	require.Equal(t, uint64(42), *response.NetworkNonce)
+	require.Empty(t, nonces.getReservedNonces(testscommon.TestAddressAlice))
+
+	code, _ = doRequest("/nonces/reservations", `{"address": "bad"}`)
+	require.Equal(t, http.StatusBadRequest, code)
+
+	code, _ = doRequest("/nonces/release", `not json`)
+	require.Equal(t, http.StatusBadRequest, code)
+}
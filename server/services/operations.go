@@ -16,6 +16,14 @@ const (
 	opFeeOfInvalidTx             = "FeeOfInvalidTransaction"
 	opFeeRefund                  = "FeeRefund"
 	opCustomTransfer             = "CustomTransfer"
+	opSmartContractCall          = "SmartContractCall"
+	opSmartContractDeploy        = "SmartContractDeploy"
+	opDelegate                   = "Delegate"
+	opUndelegate                 = "Undelegate"
+	opClaimRewards               = "ClaimRewards"
+	opWithdraw                   = "Withdraw"
+	opReDelegateRewards          = "ReDelegateRewards"
+	opCreateDelegationContract   = "CreateDelegationContract"
 )
 
 var (
@@ -32,6 +40,14 @@ var (
 		opFeeOfInvalidTx,
 		opFeeRefund,
 		opCustomTransfer,
+		opSmartContractCall,
+		opSmartContractDeploy,
+		opDelegate,
+		opUndelegate,
+		opClaimRewards,
+		opWithdraw,
+		opReDelegateRewards,
+		opCreateDelegationContract,
 	}
 
 	opStatusSuccess = "Success"
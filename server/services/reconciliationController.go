@@ -0,0 +1,49 @@
+package services
+
+import (
+	"net/http"
+
+	"github.com/coinbase/rosetta-sdk-go/server"
+)
+
+type reconciliationController struct {
+	reconciler *balancesReconciler
+	routes     []server.Route
+}
+
+type reconciliationErrorResponse struct {
+	Message string `json:"message"`
+}
+
+// NewReconciliationController creates a controller (non-Rosetta routes) for inspecting the status of the (background) reconciliation of balances
+func NewReconciliationController(reconciler *balancesReconciler) *reconciliationController {
+	controller := &reconciliationController{
+		reconciler: reconciler,
+	}
+
+	controller.routes = []server.Route{
+		{
+			Method:      http.MethodPost,
+			Pattern:     "/diagnostics/reconciliation",
+			HandlerFunc: controller.handleGetStatus,
+		},
+	}
+
+	return controller
+}
+
+// Routes returns the routes of the controller
+func (controller *reconciliationController) Routes() server.Routes {
+	return controller.routes
+}
+
+func (controller *reconciliationController) handleGetStatus(w http.ResponseWriter, _ *http.Request) {
+	if controller.reconciler == nil {
+		response := &reconciliationErrorResponse{Message: "reconciliation of balances is not enabled"}
+		server.EncodeJSONResponse(response, http.StatusNotFound, w)
+		return
+	}
+
+	status := controller.reconciler.getStatus()
+	server.EncodeJSONResponse(&status, http.StatusOK, w)
+}
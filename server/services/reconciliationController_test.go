@@ -0,0 +1,49 @@
+package services
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconciliationController(t *testing.T) {
+	t.Parallel()
+
+	doGetStatus := func(controller *reconciliationController) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodPost, "/diagnostics/reconciliation", nil)
+		recorder := httptest.NewRecorder()
+		controller.Routes()[0].HandlerFunc(recorder, request)
+		return recorder
+	}
+
+	t.Run("when reconciliation is enabled", func(t *testing.T) {
+		networkProvider := testscommon.NewNetworkProviderMock()
+		networkProvider.MockNetworkConfig.ShouldReconcileBalances = true
+		reconciler := NewBalancesReconciler(networkProvider, newTransactionsTransformer(networkProvider))
+
+		controller := NewReconciliationController(reconciler)
+		require.Len(t, controller.Routes(), 1)
+
+		reconciler.recordRound(7, 10)
+
+		recorder := doGetStatus(controller)
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		status := &reconciliationStatus{}
+		err := json.Unmarshal(recorder.Body.Bytes(), status)
+		require.Nil(t, err)
+		require.Equal(t, uint64(1), status.NumRounds)
+		require.Equal(t, uint64(7), status.LastFromNonce)
+		require.Equal(t, uint64(10), status.LastToNonce)
+		require.Empty(t, status.Drifts)
+	})
+
+	t.Run("when reconciliation is not enabled", func(t *testing.T) {
+		recorder := doGetStatus(NewReconciliationController(nil))
+		require.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+}
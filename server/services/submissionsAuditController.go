@@ -0,0 +1,84 @@
+package services
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/coinbase/rosetta-sdk-go/server"
+)
+
+type submissionsAuditController struct {
+	auditLog *submissionsAuditLog
+	routes   []server.Route
+}
+
+type submissionsAuditQueryResponse struct {
+	Records []*submissionAuditRecord `json:"records"`
+}
+
+type submissionsAuditRotateResponse struct {
+	RotatedPath string `json:"rotated_path"`
+}
+
+type submissionsAuditErrorResponse struct {
+	Message string `json:"message"`
+}
+
+// NewSubmissionsAuditController creates a controller (non-Rosetta routes) for querying and rotating the audit log of submitted transactions
+func NewSubmissionsAuditController(auditLog *submissionsAuditLog) *submissionsAuditController {
+	controller := &submissionsAuditController{
+		auditLog: auditLog,
+	}
+
+	controller.routes = []server.Route{
+		{
+			Method:      http.MethodPost,
+			Pattern:     "/audit/submissions",
+			HandlerFunc: controller.handleQuery,
+		},
+		{
+			Method:      http.MethodPost,
+			Pattern:     "/audit/submissions/rotate",
+			HandlerFunc: controller.handleRotate,
+		},
+	}
+
+	return controller
+}
+
+// Routes returns the routes of the controller
+func (controller *submissionsAuditController) Routes() server.Routes {
+	return controller.routes
+}
+
+func (controller *submissionsAuditController) handleQuery(w http.ResponseWriter, r *http.Request) {
+	filter := submissionsAuditFilter{}
+
+	err := json.NewDecoder(r.Body).Decode(&filter)
+	if err != nil {
+		controller.respondWithError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	records, err := controller.auditLog.query(filter)
+	if err != nil {
+		controller.respondWithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	server.EncodeJSONResponse(&submissionsAuditQueryResponse{Records: records}, http.StatusOK, w)
+}
+
+func (controller *submissionsAuditController) handleRotate(w http.ResponseWriter, _ *http.Request) {
+	rotatedPath, err := controller.auditLog.rotate()
+	if err != nil {
+		controller.respondWithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	server.EncodeJSONResponse(&submissionsAuditRotateResponse{RotatedPath: rotatedPath}, http.StatusOK, w)
+}
+
+func (controller *submissionsAuditController) respondWithError(w http.ResponseWriter, status int, err error) {
+	server.EncodeJSONResponse(&submissionsAuditErrorResponse{Message: err.Error()}, status, w)
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubmissionsAuditController(t *testing.T) {
+	t.Parallel()
+
+	auditLog, err := NewSubmissionsAuditLog(filepath.Join(t.TempDir(), "audit.log"), 1)
+	require.Nil(t, err)
+	defer func() {
+		_ = auditLog.Close()
+	}()
+
+	require.Nil(t, auditLog.append(&submissionAuditRecord{Hash: "aaaa", Sender: "alice", Outcome: submissionOutcomeSubmitted}))
+	require.Nil(t, auditLog.append(&submissionAuditRecord{Hash: "bbbb", Sender: "bob", Outcome: submissionOutcomeSubmitted}))
+
+	controller := NewSubmissionsAuditController(auditLog)
+	require.Len(t, controller.Routes(), 2)
+
+	handlersByPattern := make(map[string]http.HandlerFunc)
+	for _, route := range controller.Routes() {
+		handlersByPattern[route.Pattern] = route.HandlerFunc
+	}
+
+	doRequest := func(pattern string, body string, response interface{}) int {
+		request := httptest.NewRequest(http.MethodPost, pattern, strings.NewReader(body))
+		recorder := httptest.NewRecorder()
+		handlersByPattern[pattern](recorder, request)
+
+		_ = json.Unmarshal(recorder.Body.Bytes(), response)
+		return recorder.Code
+	}
+
+	queryResponse := &submissionsAuditQueryResponse{}
+	code := doRequest("/audit/submissions", `{"sender": "bob"}`, queryResponse)
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, queryResponse.Records, 1)
+	require.Equal(t, "bbbb", queryResponse.Records[0].Hash)
+
+	rotateResponse := &submissionsAuditRotateResponse{}
+	code = doRequest("/audit/submissions/rotate", ``, rotateResponse)
+	require.Equal(t, http.StatusOK, code)
+	require.True(t, strings.HasPrefix(rotateResponse.RotatedPath, auditLog.path+"."))
+
+	queryResponse = &submissionsAuditQueryResponse{}
+	code = doRequest("/audit/submissions", `{}`, queryResponse)
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, queryResponse.Records, 2)
+
+	errorResponse := &submissionsAuditErrorResponse{}
+	code = doRequest("/audit/submissions", `not json`, errorResponse)
+	require.Equal(t, http.StatusBadRequest, code)
+	require.NotEmpty(t, errorResponse.Message)
+}
@@ -0,0 +1,299 @@
+package services
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	auditLogFilePermissions        = 0640
+	auditLogRotatedSuffixFormat    = "20060102T150405.000000000"
+	auditLogMaxLineSize            = 1024 * 1024
+	defaultAuditLogQueryLimit      = 100
+	maxAuditLogQueryLimit          = 10000
+	numBytesInMegabyte             = 1024 * 1024
+	defaultAuditLogMaxSizeMegabyte = 100
+)
+
+// submissionAuditRecord is a line of the audit log
+type submissionAuditRecord struct {
+	Timestamp         int64  `json:"timestamp"`
+	Hash              string `json:"hash,omitempty"`
+	Sender            string `json:"sender,omitempty"`
+	Nonce             uint64 `json:"nonce"`
+	SignedTransaction string `json:"signed_transaction"`
+	Outcome           string `json:"outcome"`
+	ObserverResponse  string `json:"observer_response,omitempty"`
+}
+
+// submissionsAuditFilter selects records of the audit log. Empty fields match any record.
+type submissionsAuditFilter struct {
+	Hash          string `json:"hash,omitempty"`
+	Sender        string `json:"sender,omitempty"`
+	FromTimestamp int64  `json:"from_timestamp,omitempty"`
+	ToTimestamp   int64  `json:"to_timestamp,omitempty"`
+	Limit         int    `json:"limit,omitempty"`
+}
+
+// submissionsAuditLog is an append-only, local log (JSON lines) of the submitted transactions.
+// Each record is flushed to disk (fsync) before returning. When the file exceeds the maximum size, it's rotated:
+// renamed to "{path}.{timestamp}", then a new file is started. Rotated files are never deleted by Rosetta (and are still queried).
+type submissionsAuditLog struct {
+	mutex sync.Mutex
+	// rotationMutex is held (for writing) while rotating, and (for reading) while querying, so that files aren't renamed while being read.
+	// It's always acquired while holding "mutex" (never the other way around).
+	rotationMutex sync.RWMutex
+	path          string
+	maxSize       int64
+	file          *os.File
+	size          int64
+	getTime       func() time.Time
+}
+
+// NewSubmissionsAuditLog opens (or creates) the audit log of submitted transactions
+func NewSubmissionsAuditLog(path string, maxSizeMegabytes uint32) (*submissionsAuditLog, error) {
+	if maxSizeMegabytes == 0 {
+		maxSizeMegabytes = defaultAuditLogMaxSizeMegabyte
+	}
+
+	auditLog := &submissionsAuditLog{
+		path:    path,
+		maxSize: int64(maxSizeMegabytes) * numBytesInMegabyte,
+		getTime: time.Now,
+	}
+
+	err := auditLog.open()
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("NewSubmissionsAuditLog()", "path", path, "size", auditLog.size, "maxSize", auditLog.maxSize)
+	return auditLog, nil
+}
+
+func (auditLog *submissionsAuditLog) open() error {
+	file, err := os.OpenFile(auditLog.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, auditLogFilePermissions)
+	if err != nil {
+		return fmt.Errorf("cannot open audit log: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("cannot open audit log: %w", err)
+	}
+
+	auditLog.file = file
+	auditLog.size = info.Size()
+	return nil
+}
+
+// append writes a record (timestamped now), and flushes it to disk. The file is rotated afterwards, if it became too large.
+func (auditLog *submissionsAuditLog) append(record *submissionAuditRecord) error {
+	auditLog.mutex.Lock()
+	defer auditLog.mutex.Unlock()
+
+	record.Timestamp = auditLog.getTime().UnixMilli()
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	line = append(line, '\n')
+
+	numWritten, err := auditLog.file.Write(line)
+	auditLog.size += int64(numWritten)
+	if err != nil {
+		return err
+	}
+
+	err = auditLog.file.Sync()
+	if err != nil {
+		return err
+	}
+
+	if auditLog.size >= auditLog.maxSize {
+		_, err = auditLog.doRotate()
+		return err
+	}
+
+	return nil
+}
+
+// rotate renames the current file (and starts a new one), returning the path of the rotated file
+func (auditLog *submissionsAuditLog) rotate() (string, error) {
+	auditLog.mutex.Lock()
+	defer auditLog.mutex.Unlock()
+
+	return auditLog.doRotate()
+}
+
+func (auditLog *submissionsAuditLog) doRotate() (string, error) {
+	auditLog.rotationMutex.Lock()
+	defer auditLog.rotationMutex.Unlock()
+
+	err := auditLog.file.Close()
+	if err != nil {
+		return "", err
+	}
+
+	rotatedPath := fmt.Sprintf("%s.%s", auditLog.path, auditLog.getTime().UTC().Format(auditLogRotatedSuffixFormat))
+
+	err = os.Rename(auditLog.path, rotatedPath)
+	if err != nil {
+		// Continue with the original file (it's rotated later on).
+		errReopen := auditLog.open()
+		if errReopen != nil {
+			return "", fmt.Errorf("cannot rotate audit log: %v, cannot reopen it: %w", err, errReopen)
+		}
+
+		return "", fmt.Errorf("cannot rotate audit log: %w", err)
+	}
+
+	log.Info("submissionsAuditLog.rotate()", "rotatedPath", rotatedPath)
+
+	return rotatedPath, auditLog.open()
+}
+
+// query returns the records matching the filter, in chronological order (rotated files first), up to the given limit.
+// Appends aren't blocked while querying: the current file is only read up to its size at the moment of the query.
+func (auditLog *submissionsAuditLog) query(filter submissionsAuditFilter) ([]*submissionAuditRecord, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultAuditLogQueryLimit
+	}
+	if limit > maxAuditLogQueryLimit {
+		limit = maxAuditLogQueryLimit
+	}
+
+	auditLog.mutex.Lock()
+	paths, err := auditLog.getAllPaths()
+	if err != nil {
+		auditLog.mutex.Unlock()
+		return nil, err
+	}
+
+	currentSize := auditLog.size
+	auditLog.rotationMutex.RLock()
+	auditLog.mutex.Unlock()
+	defer auditLog.rotationMutex.RUnlock()
+
+	records := make([]*submissionAuditRecord, 0)
+	currentPath := paths[len(paths)-1]
+
+	for _, path := range paths {
+		if len(records) >= limit {
+			break
+		}
+
+		maxSize := int64(-1)
+		if path == currentPath {
+			maxSize = currentSize
+		}
+
+		records, err = queryAuditLogFile(path, maxSize, filter, records, limit)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return records, nil
+}
+
+// getAllPaths returns the paths of the rotated files (oldest first; the timestamp suffix sorts chronologically), followed by the path of the current file.
+// Other files sharing the prefix of the audit log (e.g. "{path}.bak") are ignored.
+func (auditLog *submissionsAuditLog) getAllPaths() ([]string, error) {
+	candidates, err := filepath.Glob(auditLog.path + ".*")
+	if err != nil {
+		return nil, err
+	}
+
+	rotatedPaths := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		if isRotatedAuditLogPath(auditLog.path, candidate) {
+			rotatedPaths = append(rotatedPaths, candidate)
+		}
+	}
+
+	sort.Strings(rotatedPaths)
+	return append(rotatedPaths, auditLog.path), nil
+}
+
+func isRotatedAuditLogPath(path string, candidate string) bool {
+	suffix := strings.TrimPrefix(candidate, path+".")
+	if len(suffix) != len(auditLogRotatedSuffixFormat) {
+		return false
+	}
+
+	_, err := time.Parse(auditLogRotatedSuffixFormat, suffix)
+	return err == nil
+}
+
+// queryAuditLogFile reads (up to "maxSize" bytes, if non-negative) a file of the audit log, collecting the records matching the filter
+func queryAuditLogFile(path string, maxSize int64, filter submissionsAuditFilter, records []*submissionAuditRecord, limit int) ([]*submissionAuditRecord, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	var reader io.Reader = file
+	if maxSize >= 0 {
+		reader = io.LimitReader(file, maxSize)
+	}
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), auditLogMaxLineSize)
+
+	for scanner.Scan() && len(records) < limit {
+		record := &submissionAuditRecord{}
+
+		err := json.Unmarshal(scanner.Bytes(), record)
+		if err != nil {
+			log.Warn("queryAuditLogFile(): skipping malformed line", "path", path, "err", err)
+			continue
+		}
+
+		if filter.matches(record) {
+			records = append(records, record)
+		}
+	}
+
+	return records, scanner.Err()
+}
+
+func (filter *submissionsAuditFilter) matches(record *submissionAuditRecord) bool {
+	if len(filter.Hash) > 0 && filter.Hash != record.Hash {
+		return false
+	}
+	if len(filter.Sender) > 0 && filter.Sender != record.Sender {
+		return false
+	}
+	if filter.FromTimestamp > 0 && record.Timestamp < filter.FromTimestamp {
+		return false
+	}
+	if filter.ToTimestamp > 0 && record.Timestamp > filter.ToTimestamp {
+		return false
+	}
+
+	return true
+}
+
+// Close closes the underlying file
+func (auditLog *submissionsAuditLog) Close() error {
+	auditLog.mutex.Lock()
+	defer auditLog.mutex.Unlock()
+
+	return auditLog.file.Close()
+}
@@ -0,0 +1,223 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubmissionsAuditLog_AppendAndQuery(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	auditLog, err := NewSubmissionsAuditLog(path, 1)
+	require.Nil(t, err)
+
+	now := time.UnixMilli(1000)
+	auditLog.getTime = func() time.Time {
+		return now
+	}
+
+	require.Nil(t, auditLog.append(&submissionAuditRecord{Hash: "aaaa", Sender: "alice", Nonce: 1, SignedTransaction: "{}", Outcome: submissionOutcomeSubmitted}))
+	now = time.UnixMilli(2000)
+	require.Nil(t, auditLog.append(&submissionAuditRecord{Hash: "bbbb", Sender: "bob", Nonce: 7, SignedTransaction: "{}", Outcome: submissionOutcomeFailed, ObserverResponse: "error"}))
+	now = time.UnixMilli(3000)
+	require.Nil(t, auditLog.append(&submissionAuditRecord{Hash: "cccc", Sender: "alice", Nonce: 2, SignedTransaction: "{}", Outcome: submissionOutcomeSubmitted}))
+
+	// Records are on disk, as JSON lines.
+	content, err := os.ReadFile(path)
+	require.Nil(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, 3)
+	require.Equal(t, `{"timestamp":2000,"hash":"bbbb","sender":"bob","nonce":7,"signed_transaction":"{}","outcome":"failed","observer_response":"error"}`, lines[1])
+
+	records, err := auditLog.query(submissionsAuditFilter{})
+	require.Nil(t, err)
+	require.Len(t, records, 3)
+
+	records, err = auditLog.query(submissionsAuditFilter{Sender: "alice"})
+	require.Nil(t, err)
+	require.Len(t, records, 2)
+	require.Equal(t, "aaaa", records[0].Hash)
+	require.Equal(t, "cccc", records[1].Hash)
+
+	records, err = auditLog.query(submissionsAuditFilter{Hash: "bbbb"})
+	require.Nil(t, err)
+	require.Len(t, records, 1)
+	require.Equal(t, int64(2000), records[0].Timestamp)
+
+	records, err = auditLog.query(submissionsAuditFilter{FromTimestamp: 2000, ToTimestamp: 3000})
+	require.Nil(t, err)
+	require.Len(t, records, 2)
+
+	records, err = auditLog.query(submissionsAuditFilter{Limit: 1})
+	require.Nil(t, err)
+	require.Len(t, records, 1)
+	require.Equal(t, "aaaa", records[0].Hash)
+
+	// Records survive re-opening.
+	require.Nil(t, auditLog.Close())
+	auditLog, err = NewSubmissionsAuditLog(path, 1)
+	require.Nil(t, err)
+	defer func() {
+		_ = auditLog.Close()
+	}()
+
+	require.Equal(t, int64(len(content)), auditLog.size)
+	records, err = auditLog.query(submissionsAuditFilter{})
+	require.Nil(t, err)
+	require.Len(t, records, 3)
+}
+
+func TestSubmissionsAuditLog_Rotate(t *testing.T) {
+	t.Parallel()
+
+	folder := t.TempDir()
+	path := filepath.Join(folder, "audit.log")
+
+	auditLog, err := NewSubmissionsAuditLog(path, 1)
+	require.Nil(t, err)
+	defer func() {
+		_ = auditLog.Close()
+	}()
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	auditLog.getTime = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+
+	require.Nil(t, auditLog.append(&submissionAuditRecord{Hash: "aaaa", Outcome: submissionOutcomeSubmitted}))
+
+	// Rotation on demand.
+	rotatedPath, err := auditLog.rotate()
+	require.Nil(t, err)
+	require.Equal(t, path+".20260101T000002.000000000", rotatedPath)
+
+	require.Nil(t, auditLog.append(&submissionAuditRecord{Hash: "bbbb", Outcome: submissionOutcomeSubmitted}))
+
+	// Rotation by size.
+	auditLog.maxSize = auditLog.size + 1
+	require.Nil(t, auditLog.append(&submissionAuditRecord{Hash: "cccc", Outcome: submissionOutcomeSubmitted}))
+	require.Equal(t, int64(0), auditLog.size)
+
+	require.Nil(t, auditLog.append(&submissionAuditRecord{Hash: "dddd", Outcome: submissionOutcomeSubmitted}))
+
+	entries, err := os.ReadDir(folder)
+	require.Nil(t, err)
+	require.Len(t, entries, 3)
+
+	// Rotated files are queried, as well (in chronological order).
+	records, err := auditLog.query(submissionsAuditFilter{})
+	require.Nil(t, err)
+	require.Len(t, records, 4)
+	require.Equal(t, "aaaa", records[0].Hash)
+	require.Equal(t, "bbbb", records[1].Hash)
+	require.Equal(t, "cccc", records[2].Hash)
+	require.Equal(t, "dddd", records[3].Hash)
+}
+
+func TestSubmissionsAuditLog_RotateWhenRenameFails(t *testing.T) {
+	t.Parallel()
+
+	folder := t.TempDir()
+	path := filepath.Join(folder, "audit.log")
+
+	auditLog, err := NewSubmissionsAuditLog(path, 1)
+	require.Nil(t, err)
+	defer func() {
+		_ = auditLog.Close()
+	}()
+
+	auditLog.getTime = func() time.Time {
+		return time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	require.Nil(t, auditLog.append(&submissionAuditRecord{Hash: "aaaa", Outcome: submissionOutcomeSubmitted}))
+
+	// The destination of the rename is a (non-empty) folder.
+	blockingPath := path + ".20260101T000000.000000000"
+	require.Nil(t, os.MkdirAll(filepath.Join(blockingPath, "child"), 0750))
+
+	_, err = auditLog.rotate()
+	require.ErrorContains(t, err, "cannot rotate audit log")
+
+	// The original file is still in use.
+	require.Nil(t, auditLog.append(&submissionAuditRecord{Hash: "bbbb", Outcome: submissionOutcomeSubmitted}))
+
+	content, err := os.ReadFile(path)
+	require.Nil(t, err)
+	require.Len(t, strings.Split(strings.TrimSpace(string(content)), "\n"), 2)
+}
+
+func TestSubmissionsAuditLog_QueryIgnoresUnrelatedFiles(t *testing.T) {
+	t.Parallel()
+
+	folder := t.TempDir()
+	path := filepath.Join(folder, "audit.log")
+
+	auditLog, err := NewSubmissionsAuditLog(path, 1)
+	require.Nil(t, err)
+	defer func() {
+		_ = auditLog.Close()
+	}()
+
+	require.Nil(t, auditLog.append(&submissionAuditRecord{Hash: "aaaa", Outcome: submissionOutcomeSubmitted}))
+
+	unrelatedRecord := []byte(`{"timestamp":1,"hash":"bbbb","signed_transaction":"{}","outcome":"submitted"}` + "\n")
+	require.Nil(t, os.WriteFile(path+".bak", unrelatedRecord, 0640))
+	require.Nil(t, os.WriteFile(path+".20260101T000000", unrelatedRecord, 0640))
+
+	records, err := auditLog.query(submissionsAuditFilter{})
+	require.Nil(t, err)
+	require.Len(t, records, 1)
+	require.Equal(t, "aaaa", records[0].Hash)
+}
+
+func TestSubmissionsAuditLog_ConcurrentAppendsAndQueries(t *testing.T) {
+	t.Parallel()
+
+	auditLog, err := NewSubmissionsAuditLog(filepath.Join(t.TempDir(), "audit.log"), 1)
+	require.Nil(t, err)
+	defer func() {
+		_ = auditLog.Close()
+	}()
+
+	numRecords := 100
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for i := 0; i < numRecords; i++ {
+			_ = auditLog.append(&submissionAuditRecord{Hash: "aaaa", Outcome: submissionOutcomeSubmitted})
+			if i%10 == 0 {
+				_, _ = auditLog.rotate()
+			}
+		}
+	}()
+
+	for i := 0; i < 10; i++ {
+		_, err := auditLog.query(submissionsAuditFilter{Limit: maxAuditLogQueryLimit})
+		require.Nil(t, err)
+	}
+
+	<-done
+
+	records, err := auditLog.query(submissionsAuditFilter{Limit: maxAuditLogQueryLimit})
+	require.Nil(t, err)
+	require.Len(t, records, numRecords)
+}
+
+func TestNewSubmissionsAuditLog_WithBadPath(t *testing.T) {
+	t.Parallel()
+
+	auditLog, err := NewSubmissionsAuditLog(filepath.Join(t.TempDir(), "missing", "audit.log"), 1)
+	require.ErrorContains(t, err, "cannot open audit log")
+	require.Nil(t, auditLog)
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/coinbase/rosetta-sdk-go/server"
+)
+
+type submittedTransactionsController struct {
+	tracker *submittedTransactionsTracker
+	routes  []server.Route
+}
+
+type submittedTransactionStatusRequest struct {
+	Hash string `json:"hash"`
+}
+
+type submittedTransactionsErrorResponse struct {
+	Message string `json:"message"`
+}
+
+// NewSubmittedTransactionsController creates a controller (non-Rosetta routes) for inspecting the status of the transactions submitted (and tracked) by the construction service
+func NewSubmittedTransactionsController(tracker *submittedTransactionsTracker) *submittedTransactionsController {
+	controller := &submittedTransactionsController{
+		tracker: tracker,
+	}
+
+	controller.routes = []server.Route{
+		{
+			Method:      http.MethodPost,
+			Pattern:     "/transactions/submitted/status",
+			HandlerFunc: controller.handleGetStatus,
+		},
+	}
+
+	return controller
+}
+
+// Routes returns the routes of the controller
+func (controller *submittedTransactionsController) Routes() server.Routes {
+	return controller.routes
+}
+
+func (controller *submittedTransactionsController) handleGetStatus(w http.ResponseWriter, r *http.Request) {
+	request := &submittedTransactionStatusRequest{}
+
+	err := json.NewDecoder(r.Body).Decode(request)
+	if err != nil {
+		controller.respondWithError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if controller.tracker == nil {
+		controller.respondWithError(w, http.StatusNotFound, errors.New("tracking of submitted transactions is not enabled"))
+		return
+	}
+
+	tracked, ok := controller.tracker.get(request.Hash)
+	if !ok {
+		controller.respondWithError(w, http.StatusNotFound, errors.New("transaction not tracked (not submitted through Rosetta, or evicted)"))
+		return
+	}
+
+	server.EncodeJSONResponse(&tracked, http.StatusOK, w)
+}
+
+func (controller *submittedTransactionsController) respondWithError(w http.ResponseWriter, status int, err error) {
+	server.EncodeJSONResponse(&submittedTransactionsErrorResponse{Message: err.Error()}, status, w)
+}
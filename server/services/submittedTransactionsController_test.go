@@ -0,0 +1,60 @@
+package services
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubmittedTransactionsController(t *testing.T) {
+	t.Parallel()
+
+	doRequest := func(controller *submittedTransactionsController, body string) (int, map[string]interface{}) {
+		request := httptest.NewRequest(http.MethodPost, "/transactions/submitted/status", strings.NewReader(body))
+		recorder := httptest.NewRecorder()
+		controller.Routes()[0].HandlerFunc(recorder, request)
+
+		response := make(map[string]interface{})
+		_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+		return recorder.Code, response
+	}
+
+	t.Run("when tracking is enabled", func(t *testing.T) {
+		t.Parallel()
+
+		networkProvider := testscommon.NewNetworkProviderMock()
+		networkProvider.MockNetworkConfig.ShouldTrackSubmittedTxs = true
+		tracker := NewSubmittedTransactionsTracker(networkProvider)
+		tracker.track("aaaa")
+
+		controller := NewSubmittedTransactionsController(tracker)
+		require.Len(t, controller.Routes(), 1)
+
+		code, response := doRequest(controller, `{"hash": "aaaa"}`)
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, "aaaa", response["hash"])
+		require.Equal(t, "pending", response["status"])
+
+		code, response = doRequest(controller, `{"hash": "bbbb"}`)
+		require.Equal(t, http.StatusNotFound, code)
+		require.Contains(t, response["message"], "transaction not tracked")
+
+		code, _ = doRequest(controller, `not json`)
+		require.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("when tracking is disabled", func(t *testing.T) {
+		t.Parallel()
+
+		controller := NewSubmittedTransactionsController(nil)
+
+		code, response := doRequest(controller, `{"hash": "aaaa"}`)
+		require.Equal(t, http.StatusNotFound, code)
+		require.Equal(t, "tracking of submitted transactions is not enabled", response["message"])
+	})
+}
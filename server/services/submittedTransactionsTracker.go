@@ -0,0 +1,322 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+)
+
+const (
+	trackedTransactionStatusPending = "pending"
+	trackedTransactionStatusSuccess = "success"
+	trackedTransactionStatusFail    = "fail"
+	trackedTransactionStatusInvalid = "invalid"
+)
+
+const (
+	defaultTrackerMaxNumTxs    = 10000
+	defaultTrackerPollInterval = 6 * time.Second
+	webhookTimeout             = 5 * time.Second
+	webhookQueueSize           = 1000
+)
+
+// trackedTransaction is a transaction submitted through Rosetta, along with its (eventually, final) status
+type trackedTransaction struct {
+	Hash            string                 `json:"hash"`
+	Status          string                 `json:"status"`
+	BlockIdentifier *types.BlockIdentifier `json:"block_identifier,omitempty"`
+	SubmittedAt     int64                  `json:"submitted_at"`
+	ResolvedAt      int64                  `json:"resolved_at,omitempty"`
+}
+
+type argsNewSubmittedTransactionsTracker struct {
+	provider     NetworkProvider
+	maxNumTxs    int
+	pollInterval time.Duration
+	webhookUrl   string
+}
+
+// submittedTransactionsTracker keeps (in a bounded, in-memory store) the submitted transactions,
+// and resolves their final status by polling the observer. Once the oldest ones are evicted (capacity exceeded), they are forgotten.
+// Notifications (if a webhook is configured) are sent by a separate worker, so that a slow webhook doesn't delay the polling.
+type submittedTransactionsTracker struct {
+	provider     NetworkProvider
+	maxNumTxs    int
+	pollInterval time.Duration
+	webhookUrl   string
+	httpClient   *http.Client
+	getTime      func() time.Time
+
+	mutex  sync.RWMutex
+	byHash map[string]*trackedTransaction
+	// Hashes, in the order of submission (oldest first), for eviction.
+	hashes []string
+
+	notifications chan trackedTransaction
+
+	stopChan chan struct{}
+	stopOnce sync.Once
+}
+
+// NewSubmittedTransactionsTracker creates the tracker of submitted transactions (shared by the transactions submitter and the submitted transactions controller)
+func NewSubmittedTransactionsTracker(networkProvider NetworkProvider) *submittedTransactionsTracker {
+	networkConfig := networkProvider.GetNetworkConfig()
+
+	return newSubmittedTransactionsTracker(argsNewSubmittedTransactionsTracker{
+		provider:     networkProvider,
+		maxNumTxs:    int(networkConfig.TrackerMaxNumTxs),
+		pollInterval: time.Duration(networkConfig.TrackerPollIntervalSeconds) * time.Second,
+		webhookUrl:   networkConfig.TrackerWebhookUrl,
+	})
+}
+
+func newSubmittedTransactionsTracker(args argsNewSubmittedTransactionsTracker) *submittedTransactionsTracker {
+	maxNumTxs := args.maxNumTxs
+	if maxNumTxs <= 0 {
+		maxNumTxs = defaultTrackerMaxNumTxs
+	}
+
+	pollInterval := args.pollInterval
+	if pollInterval <= 0 {
+		pollInterval = defaultTrackerPollInterval
+	}
+
+	return &submittedTransactionsTracker{
+		provider:      args.provider,
+		maxNumTxs:     maxNumTxs,
+		pollInterval:  pollInterval,
+		webhookUrl:    args.webhookUrl,
+		httpClient:    &http.Client{Timeout: webhookTimeout},
+		getTime:       time.Now,
+		byHash:        make(map[string]*trackedTransaction),
+		hashes:        make([]string, 0),
+		notifications: make(chan trackedTransaction, webhookQueueSize),
+		stopChan:      make(chan struct{}),
+	}
+}
+
+// track starts tracking a (just submitted) transaction. Tracking the same transaction twice has no effect.
+func (tracker *submittedTransactionsTracker) track(hash string) {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	_, alreadyTracked := tracker.byHash[hash]
+	if alreadyTracked {
+		return
+	}
+
+	tracker.byHash[hash] = &trackedTransaction{
+		Hash:        hash,
+		Status:      trackedTransactionStatusPending,
+		SubmittedAt: tracker.getTime().UnixMilli(),
+	}
+	tracker.hashes = append(tracker.hashes, hash)
+
+	for len(tracker.hashes) > tracker.maxNumTxs {
+		oldest := tracker.hashes[0]
+		tracker.hashes = tracker.hashes[1:]
+		delete(tracker.byHash, oldest)
+	}
+}
+
+// get returns (a copy of) a tracked transaction, if any
+func (tracker *submittedTransactionsTracker) get(hash string) (trackedTransaction, bool) {
+	tracker.mutex.RLock()
+	defer tracker.mutex.RUnlock()
+
+	tracked, ok := tracker.byHash[hash]
+	if !ok {
+		return trackedTransaction{}, false
+	}
+
+	return *tracked, true
+}
+
+func (tracker *submittedTransactionsTracker) getPendingHashes() []string {
+	tracker.mutex.RLock()
+	defer tracker.mutex.RUnlock()
+
+	pending := make([]string, 0)
+	for _, hash := range tracker.hashes {
+		if tracker.byHash[hash].Status == trackedTransactionStatusPending {
+			pending = append(pending, hash)
+		}
+	}
+
+	return pending
+}
+
+// Start polls the observer (and notifies the webhook, if any) in the background, until "Close" is called
+func (tracker *submittedTransactionsTracker) Start() {
+	log.Info("submittedTransactionsTracker.Start()", "maxNumTxs", tracker.maxNumTxs, "pollInterval", tracker.pollInterval, "webhookUrl", tracker.webhookUrl)
+
+	if len(tracker.webhookUrl) > 0 {
+		go tracker.sendNotifications()
+	}
+
+	go func() {
+		ticker := time.NewTicker(tracker.pollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				tracker.pollOnce()
+			case <-tracker.stopChan:
+				return
+			}
+		}
+	}()
+}
+
+// Close stops the polling (and the notifications). Pending notifications are dropped.
+func (tracker *submittedTransactionsTracker) Close() error {
+	tracker.stopOnce.Do(func() {
+		close(tracker.stopChan)
+	})
+
+	return nil
+}
+
+// pollOnce tries to resolve the status of the pending transactions.
+// A transaction is resolved once its status is final (success, fail or invalid), and its block is final, as well.
+// The status of executed transactions is the one computed by proxy-go (which also takes into account their contract results and logs).
+func (tracker *submittedTransactionsTracker) pollOnce() {
+	pendingHashes := tracker.getPendingHashes()
+	if len(pendingHashes) == 0 {
+		return
+	}
+
+	nodeStatus, err := tracker.provider.GetNodeStatus()
+	if err != nil {
+		log.Warn("submittedTransactionsTracker.pollOnce(): cannot get node status", "err", err)
+		return
+	}
+
+	latestFinalNonce := nodeStatus.LatestBlock.Nonce
+
+	for _, hash := range pendingHashes {
+		tx, err := tracker.provider.GetTransactionByHash(hash)
+		if err != nil {
+			// Not (yet) known by the observer, or transient error.
+			log.Debug("submittedTransactionsTracker.pollOnce(): cannot get transaction", "hash", hash, "err", err)
+			continue
+		}
+
+		status := decideTrackedTransactionStatus(tx.Status)
+		if status == trackedTransactionStatusPending {
+			continue
+		}
+		if tx.BlockNonce > latestFinalNonce {
+			continue
+		}
+
+		// Invalid transactions are reported as failed by proxy-go, thus aren't double-checked.
+		if status != trackedTransactionStatusInvalid {
+			processedStatus, err := tracker.provider.GetProcessedTransactionStatus(hash)
+			if err != nil {
+				log.Debug("submittedTransactionsTracker.pollOnce(): cannot get processed status", "hash", hash, "err", err)
+				continue
+			}
+
+			status = decideTrackedTransactionStatus(processedStatus)
+			if status == trackedTransactionStatusPending {
+				continue
+			}
+		}
+
+		blockIdentifier := &types.BlockIdentifier{
+			Index: int64(tx.BlockNonce),
+			Hash:  tx.BlockHash,
+		}
+
+		resolved, ok := tracker.resolve(hash, status, blockIdentifier)
+		if !ok {
+			continue
+		}
+
+		log.Debug("submittedTransactionsTracker.pollOnce(): resolved", "hash", hash, "status", status, "block", tx.BlockNonce)
+
+		if len(tracker.webhookUrl) > 0 {
+			tracker.enqueueNotification(resolved)
+		}
+	}
+}
+
+func (tracker *submittedTransactionsTracker) resolve(hash string, status string, blockIdentifier *types.BlockIdentifier) (trackedTransaction, bool) {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	// The transaction might have been evicted in the meantime.
+	tracked, ok := tracker.byHash[hash]
+	if !ok {
+		return trackedTransaction{}, false
+	}
+
+	tracked.Status = status
+	tracked.BlockIdentifier = blockIdentifier
+	tracked.ResolvedAt = tracker.getTime().UnixMilli()
+	return *tracked, true
+}
+
+// enqueueNotification hands a resolved transaction to the notifications worker, without blocking. If the queue is full, the notification is dropped.
+func (tracker *submittedTransactionsTracker) enqueueNotification(tracked trackedTransaction) {
+	select {
+	case tracker.notifications <- tracked:
+	default:
+		log.Warn("submittedTransactionsTracker.enqueueNotification(): queue is full, notification dropped", "hash", tracked.Hash)
+	}
+}
+
+// sendNotifications pushes (one by one) the queued notifications to the webhook, until "Close" is called
+func (tracker *submittedTransactionsTracker) sendNotifications() {
+	for {
+		select {
+		case tracked := <-tracker.notifications:
+			tracker.notifyWebhook(tracked)
+		case <-tracker.stopChan:
+			return
+		}
+	}
+}
+
+// notifyWebhook pushes the final status of a transaction to the configured webhook.
+// Failures are only logged (the status endpoint remains the source of truth).
+func (tracker *submittedTransactionsTracker) notifyWebhook(tracked trackedTransaction) {
+	payload, err := json.Marshal(tracked)
+	if err != nil {
+		log.Warn("submittedTransactionsTracker.notifyWebhook(): cannot marshal", "hash", tracked.Hash, "err", err)
+		return
+	}
+
+	response, err := tracker.httpClient.Post(tracker.webhookUrl, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		log.Warn("submittedTransactionsTracker.notifyWebhook()", "hash", tracked.Hash, "err", err)
+		return
+	}
+
+	_ = response.Body.Close()
+
+	if response.StatusCode >= http.StatusBadRequest {
+		log.Warn("submittedTransactionsTracker.notifyWebhook()", "hash", tracked.Hash, "err", fmt.Sprintf("unexpected status code: %d", response.StatusCode))
+	}
+}
+
+func decideTrackedTransactionStatus(status transaction.TxStatus) string {
+	switch status {
+	case transaction.TxStatusSuccess:
+		return trackedTransactionStatusSuccess
+	case transaction.TxStatusFail:
+		return trackedTransactionStatusFail
+	case transaction.TxStatusInvalid:
+		return trackedTransactionStatusInvalid
+	default:
+		return trackedTransactionStatusPending
+	}
+}
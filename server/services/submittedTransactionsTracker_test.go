@@ -0,0 +1,254 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubmittedTransactionsTracker_TrackAndEvict(t *testing.T) {
+	t.Parallel()
+
+	tracker := newSubmittedTransactionsTracker(argsNewSubmittedTransactionsTracker{
+		provider:  testscommon.NewNetworkProviderMock(),
+		maxNumTxs: 2,
+	})
+
+	tracker.getTime = func() time.Time {
+		return time.UnixMilli(1000)
+	}
+
+	tracker.track("aaaa")
+	tracker.track("bbbb")
+	tracker.track("aaaa")
+
+	tracked, ok := tracker.get("aaaa")
+	require.True(t, ok)
+	require.Equal(t, trackedTransaction{Hash: "aaaa", Status: trackedTransactionStatusPending, SubmittedAt: 1000}, tracked)
+	require.Equal(t, []string{"aaaa", "bbbb"}, tracker.getPendingHashes())
+
+	// Oldest one is evicted.
+	tracker.track("cccc")
+	_, ok = tracker.get("aaaa")
+	require.False(t, ok)
+	require.Equal(t, []string{"bbbb", "cccc"}, tracker.getPendingHashes())
+}
+
+func TestSubmittedTransactionsTracker_PollOnce(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	networkProvider.MockNodeStatus.LatestBlock.Nonce = 100
+	networkProvider.MockTransactionsByHash["aaaa"] = &transaction.ApiTransactionResult{Status: transaction.TxStatusSuccess, BlockNonce: 99, BlockHash: "b99"}
+	networkProvider.MockTransactionsByHash["bbbb"] = &transaction.ApiTransactionResult{Status: transaction.TxStatusFail, BlockNonce: 100, BlockHash: "b100"}
+	networkProvider.MockTransactionsByHash["cccc"] = &transaction.ApiTransactionResult{Status: transaction.TxStatusInvalid, BlockNonce: 98, BlockHash: "b98"}
+	// Not final yet (status).
+	networkProvider.MockTransactionsByHash["dddd"] = &transaction.ApiTransactionResult{Status: transaction.TxStatusPending}
+	// Not final yet (block).
+	networkProvider.MockTransactionsByHash["eeee"] = &transaction.ApiTransactionResult{Status: transaction.TxStatusSuccess, BlockNonce: 101, BlockHash: "b101"}
+	// "ffff" is not known by the observer.
+	// Failed, as computed by proxy-go (e.g. because of a failed contract result).
+	networkProvider.MockTransactionsByHash["gggg"] = &transaction.ApiTransactionResult{Status: transaction.TxStatusSuccess, BlockNonce: 99, BlockHash: "b99"}
+	networkProvider.MockProcessedStatusesByHash["gggg"] = transaction.TxStatusFail
+	// Not final yet, as computed by proxy-go (e.g. contract results still pending).
+	networkProvider.MockTransactionsByHash["hhhh"] = &transaction.ApiTransactionResult{Status: transaction.TxStatusSuccess, BlockNonce: 99, BlockHash: "b99"}
+	networkProvider.MockProcessedStatusesByHash["hhhh"] = transaction.TxStatusPending
+
+	var mutex sync.Mutex
+	notifications := make([]trackedTransaction, 0)
+
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		notification := trackedTransaction{}
+		_ = json.NewDecoder(r.Body).Decode(&notification)
+
+		mutex.Lock()
+		notifications = append(notifications, notification)
+		mutex.Unlock()
+	}))
+	defer webhook.Close()
+
+	tracker := newSubmittedTransactionsTracker(argsNewSubmittedTransactionsTracker{
+		provider:   networkProvider,
+		maxNumTxs:  10,
+		webhookUrl: webhook.URL,
+	})
+
+	tracker.getTime = func() time.Time {
+		return time.UnixMilli(1000)
+	}
+
+	go tracker.sendNotifications()
+	defer func() {
+		_ = tracker.Close()
+	}()
+
+	getNumNotifications := func() int {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return len(notifications)
+	}
+
+	for _, hash := range []string{"aaaa", "bbbb", "cccc", "dddd", "eeee", "ffff", "gggg", "hhhh"} {
+		tracker.track(hash)
+	}
+
+	tracker.pollOnce()
+
+	tracked, _ := tracker.get("aaaa")
+	require.Equal(t, trackedTransaction{
+		Hash:            "aaaa",
+		Status:          trackedTransactionStatusSuccess,
+		BlockIdentifier: &types.BlockIdentifier{Index: 99, Hash: "b99"},
+		SubmittedAt:     1000,
+		ResolvedAt:      1000,
+	}, tracked)
+
+	tracked, _ = tracker.get("bbbb")
+	require.Equal(t, trackedTransactionStatusFail, tracked.Status)
+	require.Equal(t, &types.BlockIdentifier{Index: 100, Hash: "b100"}, tracked.BlockIdentifier)
+
+	tracked, _ = tracker.get("cccc")
+	require.Equal(t, trackedTransactionStatusInvalid, tracked.Status)
+
+	tracked, _ = tracker.get("gggg")
+	require.Equal(t, trackedTransactionStatusFail, tracked.Status)
+
+	require.Equal(t, []string{"dddd", "eeee", "ffff", "hhhh"}, tracker.getPendingHashes())
+
+	require.Eventually(t, func() bool {
+		return getNumNotifications() == 4
+	}, time.Second, 10*time.Millisecond)
+
+	mutex.Lock()
+	require.Equal(t, "aaaa", notifications[0].Hash)
+	require.Equal(t, trackedTransactionStatusSuccess, notifications[0].Status)
+	require.Equal(t, &types.BlockIdentifier{Index: 99, Hash: "b99"}, notifications[0].BlockIdentifier)
+	require.Equal(t, "bbbb", notifications[1].Hash)
+	require.Equal(t, "cccc", notifications[2].Hash)
+	require.Equal(t, "gggg", notifications[3].Hash)
+	require.Equal(t, trackedTransactionStatusFail, notifications[3].Status)
+	mutex.Unlock()
+
+	// Once the block becomes final, the transaction is resolved (and resolved transactions aren't polled again).
+	networkProvider.MockNodeStatus.LatestBlock.Nonce = 101
+	tracker.pollOnce()
+
+	tracked, _ = tracker.get("eeee")
+	require.Equal(t, trackedTransactionStatusSuccess, tracked.Status)
+	require.Equal(t, []string{"dddd", "ffff", "hhhh"}, tracker.getPendingHashes())
+
+	require.Eventually(t, func() bool {
+		return getNumNotifications() == 5
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestSubmittedTransactionsTracker_PollOnceIsNotDelayedBySlowWebhook(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	networkProvider.MockNodeStatus.LatestBlock.Nonce = 100
+	networkProvider.MockTransactionsByHash["aaaa"] = &transaction.ApiTransactionResult{Status: transaction.TxStatusSuccess, BlockNonce: 99, BlockHash: "b99"}
+	networkProvider.MockTransactionsByHash["bbbb"] = &transaction.ApiTransactionResult{Status: transaction.TxStatusSuccess, BlockNonce: 99, BlockHash: "b99"}
+
+	webhookUnblocked := make(chan struct{})
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-webhookUnblocked
+	}))
+	defer webhook.Close()
+	defer close(webhookUnblocked)
+
+	tracker := newSubmittedTransactionsTracker(argsNewSubmittedTransactionsTracker{
+		provider:   networkProvider,
+		webhookUrl: webhook.URL,
+	})
+
+	go tracker.sendNotifications()
+	defer func() {
+		_ = tracker.Close()
+	}()
+
+	tracker.track("aaaa")
+	tracker.track("bbbb")
+
+	pollDone := make(chan struct{})
+	go func() {
+		tracker.pollOnce()
+		close(pollDone)
+	}()
+
+	select {
+	case <-pollDone:
+	case <-time.After(webhookTimeout / 2):
+		require.Fail(t, "polling was delayed by the webhook")
+	}
+
+	require.Empty(t, tracker.getPendingHashes())
+}
+
+func TestSubmittedTransactionsTracker_StartAndClose(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	networkProvider.MockNodeStatus.LatestBlock.Nonce = 100
+	networkProvider.MockTransactionsByHash["aaaa"] = &transaction.ApiTransactionResult{Status: transaction.TxStatusSuccess, BlockNonce: 99, BlockHash: "b99"}
+
+	tracker := newSubmittedTransactionsTracker(argsNewSubmittedTransactionsTracker{
+		provider:     networkProvider,
+		pollInterval: 10 * time.Millisecond,
+	})
+
+	tracker.track("aaaa")
+	tracker.Start()
+	defer func() {
+		_ = tracker.Close()
+	}()
+
+	require.Eventually(t, func() bool {
+		tracked, _ := tracker.get("aaaa")
+		return tracked.Status == trackedTransactionStatusSuccess
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestConstructionService_TrackSubmittedTransactions(t *testing.T) {
+	t.Parallel()
+
+	signedTx := `{"nonce":42,"value":"1234","receiver":"erd1spyavw0956vq68xj8y4tenjpq2wd5a9p2c6j8gsz7ztyrnpxrruqzu66jx","sender":"erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th","gasPrice":1000000000,"gasLimit":50000,"signature":"aabb","chainID":"T","version":1}`
+
+	t.Run("when tracking is enabled", func(t *testing.T) {
+		t.Parallel()
+
+		networkProvider := testscommon.NewNetworkProviderMock()
+		networkProvider.MockNetworkConfig.ShouldTrackSubmittedTxs = true
+		networkProvider.MockComputedTransactionHash = "aaaa"
+		tracker := NewSubmittedTransactionsTracker(networkProvider)
+		service := createConstructionService(networkProvider)
+		service.submitter.UseSubmittedTransactionsTracker(tracker)
+
+		_, errTyped := service.ConstructionSubmit(context.Background(), &types.ConstructionSubmitRequest{SignedTransaction: signedTx})
+		require.Nil(t, errTyped)
+
+		tracked, ok := tracker.get("aaaa")
+		require.True(t, ok)
+		require.Equal(t, trackedTransactionStatusPending, tracked.Status)
+	})
+
+	t.Run("when tracking is disabled", func(t *testing.T) {
+		t.Parallel()
+
+		networkProvider := testscommon.NewNetworkProviderMock()
+		service := createConstructionService(networkProvider)
+		require.Nil(t, service.submitter.tracker)
+
+		_, errTyped := service.ConstructionSubmit(context.Background(), &types.ConstructionSubmitRequest{SignedTransaction: signedTx})
+		require.Nil(t, errTyped)
+	})
+}
@@ -0,0 +1,240 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+)
+
+// eventTopicsSchema describes the topics an event is expected to hold.
+type eventTopicsSchema struct {
+	// numTopics is the number of fixed topics (the minimum, if "allowsExtraTopics" is set or "numTopicsPerGroup" is not zero).
+	numTopics int
+	// allowsExtraTopics is set for events that gained additional (unused) topics across releases.
+	allowsExtraTopics bool
+	// numTopicsPerGroup is set for events holding a variable number of groups of topics (e.g. one group per transfer).
+	numTopicsPerGroup int
+}
+
+func (schema eventTopicsSchema) check(event *transaction.Events) error {
+	numTopics := len(event.Topics)
+	isValid := numTopics == schema.numTopics
+
+	if schema.numTopicsPerGroup > 0 {
+		isValid = numTopics >= schema.numTopics && (numTopics-schema.numTopics)%schema.numTopicsPerGroup == 0
+	} else if schema.allowsExtraTopics {
+		isValid = numTopics >= schema.numTopics
+	}
+
+	if !isValid {
+		return fmt.Errorf("%w: bad number of topics for %s event = %d", errCannotRecognizeEvent, event.Identifier, numTopics)
+	}
+
+	return nil
+}
+
+var (
+	topicsOfEventSCDeploy              = eventTopicsSchema{numTopics: 2, allowsExtraTopics: true}
+	topicsOfEventTransferValueOnly     = eventTopicsSchema{numTopics: 2}
+	topicsOfEventESDTTransfer          = eventTopicsSchema{numTopics: 4}
+	topicsOfEventMultiESDTNFTTransfer  = eventTopicsSchema{numTopics: 1, numTopicsPerGroup: 3}
+	topicsOfEventESDTLocalBurn         = eventTopicsSchema{numTopics: 3}
+	topicsOfEventESDTLocalMint         = eventTopicsSchema{numTopics: 3}
+	topicsOfEventESDTWipe              = eventTopicsSchema{numTopics: 4}
+	topicsOfEventESDTNFTCreate         = eventTopicsSchema{numTopics: 4}
+	topicsOfEventESDTNFTBurn           = eventTopicsSchema{numTopics: 3}
+	topicsOfEventESDTNFTAddQuantity    = eventTopicsSchema{numTopics: 3}
+	topicsOfEventClaimDeveloperRewards = eventTopicsSchema{numTopics: 2}
+)
+
+// transactionEventHandler produces the operations of the events with a given identifier
+// (optionally, only of the events emitted by a given contract).
+type transactionEventHandler struct {
+	identifier string
+	// contractAddress, if set, restricts the handler to the events emitted by this address.
+	// Such a handler takes precedence over a handler (for the same identifier) that isn't restricted.
+	contractAddress string
+	topics          eventTopicsSchema
+	// isActive, if set, tells whether the handler is active in a given epoch (e.g. the event was introduced by a protocol release).
+	isActive func(epoch uint32) bool
+	handle   func(tx *transaction.ApiTransactionResult, event *transaction.Events) ([]*types.Operation, error)
+}
+
+func (handler *transactionEventHandler) isActiveInEpoch(epoch uint32) bool {
+	return handler.isActive == nil || handler.isActive(epoch)
+}
+
+type transactionEventHandlerKey struct {
+	identifier      string
+	contractAddress string
+}
+
+// transactionEventHandlersRegistry dispatches each event of a transaction to (at most) one handler.
+// Unknown (not handled) or malformed events are skipped, logged and counted; in strict mode, if they are balance-affecting, they cause an error instead.
+type transactionEventHandlersRegistry struct {
+	handlers []*transactionEventHandler
+	byKey    map[transactionEventHandlerKey]*transactionEventHandler
+
+	isStrict               bool
+	balanceAffectingEvents map[string]struct{}
+	diagnostics            *transactionEventsDiagnostics
+}
+
+func newTransactionEventHandlersRegistry(isStrict bool) *transactionEventHandlersRegistry {
+	return &transactionEventHandlersRegistry{
+		handlers: make([]*transactionEventHandler, 0),
+		byKey:    make(map[transactionEventHandlerKey]*transactionEventHandler),
+
+		isStrict:               isStrict,
+		balanceAffectingEvents: make(map[string]struct{}),
+		diagnostics:            newTransactionEventsDiagnostics(),
+	}
+}
+
+func (registry *transactionEventHandlersRegistry) markAsBalanceAffecting(identifiers ...string) {
+	for _, identifier := range identifiers {
+		registry.balanceAffectingEvents[identifier] = struct{}{}
+	}
+}
+
+func (registry *transactionEventHandlersRegistry) isBalanceAffecting(identifier string) bool {
+	_, ok := registry.balanceAffectingEvents[identifier]
+	return ok
+}
+
+func (registry *transactionEventHandlersRegistry) register(handler *transactionEventHandler) error {
+	if handler.identifier == "" || handler.handle == nil {
+		return fmt.Errorf("%w: missing identifier or handling function", errBadEventHandler)
+	}
+
+	key := transactionEventHandlerKey{identifier: handler.identifier, contractAddress: handler.contractAddress}
+	_, exists := registry.byKey[key]
+	if exists {
+		return fmt.Errorf("%w: identifier = %s, contract = %s", errEventHandlerAlreadyRegistered, handler.identifier, handler.contractAddress)
+	}
+
+	registry.handlers = append(registry.handlers, handler)
+	registry.byKey[key] = handler
+	return nil
+}
+
+// findHandler looks for an active handler of the event: first, one restricted to the emitter of the event, then one that isn't restricted.
+// It also tells whether the event is known at all (i.e. there is a handler for it, even if not active in the given epoch).
+func (registry *transactionEventHandlersRegistry) findHandler(event *transaction.Events, epoch uint32) (*transactionEventHandler, bool) {
+	keys := []transactionEventHandlerKey{
+		{identifier: event.Identifier, contractAddress: event.Address},
+		{identifier: event.Identifier},
+	}
+
+	isKnown := false
+
+	for _, key := range keys {
+		handler, ok := registry.byKey[key]
+		if !ok {
+			continue
+		}
+
+		isKnown = true
+
+		if handler.isActiveInEpoch(epoch) {
+			return handler, true
+		}
+	}
+
+	return nil, isKnown
+}
+
+// produceOperations dispatches the events of the transaction to the registered handlers.
+// Operations are grouped by handler (in the order of registration), then by event (in the order of the logs).
+func (registry *transactionEventHandlersRegistry) produceOperations(tx *transaction.ApiTransactionResult) ([]*types.Operation, error) {
+	operations := make([]*types.Operation, 0)
+
+	if tx.Logs == nil || len(tx.Logs.Events) == 0 {
+		return operations, nil
+	}
+
+	eventsByHandler := make(map[*transactionEventHandler][]*transaction.Events)
+
+	for _, event := range tx.Logs.Events {
+		handler, isKnown := registry.findHandler(event, tx.Epoch)
+		if !isKnown {
+			err := registry.onUnknownEvent(tx, event)
+			if err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+		if handler == nil {
+			// Known event, but not handled in this epoch.
+			continue
+		}
+
+		eventsByHandler[handler] = append(eventsByHandler[handler], event)
+	}
+
+	for _, handler := range registry.handlers {
+		for _, event := range eventsByHandler[handler] {
+			handlerOperations, err := registry.handleEvent(handler, tx, event)
+			if err != nil {
+				return nil, registry.onMalformedEvent(tx, event, err)
+			}
+
+			operations = append(operations, handlerOperations...)
+		}
+	}
+
+	return operations, nil
+}
+
+func (registry *transactionEventHandlersRegistry) handleEvent(handler *transactionEventHandler, tx *transaction.ApiTransactionResult, event *transaction.Events) ([]*types.Operation, error) {
+	err := handler.topics.check(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.handle(tx, event)
+}
+
+func (registry *transactionEventHandlersRegistry) onUnknownEvent(tx *transaction.ApiTransactionResult, event *transaction.Events) error {
+	if isInformativeEvent(event.Identifier) {
+		return nil
+	}
+
+	isBalanceAffecting := registry.isBalanceAffecting(event.Identifier)
+	registry.diagnostics.recordUnknownEvent(tx, event, isBalanceAffecting)
+
+	if !isBalanceAffecting {
+		log.Trace("unknown event", "identifier", event.Identifier, "tx", tx.Hash, "block", tx.BlockNonce)
+		return nil
+	}
+
+	log.Warn("unknown balance-affecting event", "identifier", event.Identifier, "tx", tx.Hash, "block", tx.BlockNonce)
+
+	if registry.isStrict {
+		return fmt.Errorf("%w: unknown balance-affecting event %s, tx = %s", errCannotRecognizeEvent, event.Identifier, tx.Hash)
+	}
+
+	return nil
+}
+
+// onMalformedEvent records an event that has a handler, but couldn't be handled. Such events always fail the transformation (regardless of the strict mode),
+// since skipping them would lead to missing balance changes.
+func (registry *transactionEventHandlersRegistry) onMalformedEvent(tx *transaction.ApiTransactionResult, event *transaction.Events, err error) error {
+	isBalanceAffecting := registry.isBalanceAffecting(event.Identifier)
+	registry.diagnostics.recordMalformedEvent(tx, event, isBalanceAffecting, err)
+
+	log.Warn("malformed event", "identifier", event.Identifier, "tx", tx.Hash, "block", tx.BlockNonce, "err", err)
+	return err
+}
+
+// isInformativeEvent tells whether the event is known to never affect balances (thus, not worth counting when unknown)
+func isInformativeEvent(identifier string) bool {
+	switch identifier {
+	case transactionEventSignalError, transactionEventInternalVMErrors, transactionEventWriteLog, transactionEventCompletedTx:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,407 @@
+package services
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventTopicsSchema_Check(t *testing.T) {
+	t.Parallel()
+
+	newEvent := func(numTopics int) *transaction.Events {
+		return &transaction.Events{Identifier: "foo", Topics: make([][]byte, numTopics)}
+	}
+
+	exact := eventTopicsSchema{numTopics: 3}
+	require.Nil(t, exact.check(newEvent(3)))
+	require.ErrorIs(t, exact.check(newEvent(2)), errCannotRecognizeEvent)
+	require.ErrorContains(t, exact.check(newEvent(4)), "bad number of topics for foo event = 4")
+
+	withExtraTopics := eventTopicsSchema{numTopics: 2, allowsExtraTopics: true}
+	require.Nil(t, withExtraTopics.check(newEvent(2)))
+	require.Nil(t, withExtraTopics.check(newEvent(3)))
+	require.ErrorIs(t, withExtraTopics.check(newEvent(1)), errCannotRecognizeEvent)
+
+	withGroups := eventTopicsSchema{numTopics: 1, numTopicsPerGroup: 3}
+	require.Nil(t, withGroups.check(newEvent(1)))
+	require.Nil(t, withGroups.check(newEvent(4)))
+	require.Nil(t, withGroups.check(newEvent(7)))
+	require.ErrorIs(t, withGroups.check(newEvent(0)), errCannotRecognizeEvent)
+	require.ErrorIs(t, withGroups.check(newEvent(5)), errCannotRecognizeEvent)
+}
+
+func TestTransactionEventHandlersRegistry_Register(t *testing.T) {
+	t.Parallel()
+
+	handle := func(_ *transaction.ApiTransactionResult, _ *transaction.Events) ([]*types.Operation, error) {
+		return nil, nil
+	}
+
+	registry := newTransactionEventHandlersRegistry(false)
+
+	err := registry.register(&transactionEventHandler{identifier: "foo", handle: handle})
+	require.Nil(t, err)
+
+	err = registry.register(&transactionEventHandler{identifier: "foo", contractAddress: testscommon.TestContractFooShard0.Address, handle: handle})
+	require.Nil(t, err)
+
+	err = registry.register(&transactionEventHandler{identifier: "foo", handle: handle})
+	require.ErrorIs(t, err, errEventHandlerAlreadyRegistered)
+
+	err = registry.register(&transactionEventHandler{identifier: "foo", contractAddress: testscommon.TestContractFooShard0.Address, handle: handle})
+	require.ErrorIs(t, err, errEventHandlerAlreadyRegistered)
+
+	err = registry.register(&transactionEventHandler{identifier: "bar"})
+	require.ErrorIs(t, err, errBadEventHandler)
+
+	err = registry.register(&transactionEventHandler{handle: handle})
+	require.ErrorIs(t, err, errBadEventHandler)
+
+	require.Len(t, registry.handlers, 2)
+}
+
+func TestTransactionEventHandlersRegistry_ProduceOperations(t *testing.T) {
+	t.Parallel()
+
+	// Each handler emits an operation holding the identifier of the event and a label of the handler.
+	newHandler := func(identifier string, contractAddress string, label string) *transactionEventHandler {
+		return &transactionEventHandler{
+			identifier:      identifier,
+			contractAddress: contractAddress,
+			topics:          eventTopicsSchema{numTopics: 1},
+			handle: func(_ *transaction.ApiTransactionResult, event *transaction.Events) ([]*types.Operation, error) {
+				return []*types.Operation{{Type: label + ":" + string(event.Topics[0])}}, nil
+			},
+		}
+	}
+
+	getTypesOfOperations := func(operations []*types.Operation) []string {
+		result := make([]string, 0, len(operations))
+		for _, operation := range operations {
+			result = append(result, operation.Type)
+		}
+
+		return result
+	}
+
+	t.Run("operations are grouped by handler, in the order of registration", func(t *testing.T) {
+		t.Parallel()
+
+		registry := newTransactionEventHandlersRegistry(false)
+		_ = registry.register(newHandler("a", "", "A"))
+		_ = registry.register(newHandler("b", "", "B"))
+
+		operations, err := registry.produceOperations(&transaction.ApiTransactionResult{
+			Logs: &transaction.ApiLogs{
+				Events: []*transaction.Events{
+					{Identifier: "b", Topics: [][]byte{[]byte("1")}},
+					{Identifier: "unknown", Topics: [][]byte{[]byte("2")}},
+					{Identifier: "a", Topics: [][]byte{[]byte("3")}},
+					{Identifier: "b", Topics: [][]byte{[]byte("4")}},
+					{Identifier: "a", Topics: [][]byte{[]byte("5")}},
+				},
+			},
+		})
+
+		require.Nil(t, err)
+		require.Equal(t, []string{"A:3", "A:5", "B:1", "B:4"}, getTypesOfOperations(operations))
+	})
+
+	t.Run("without events", func(t *testing.T) {
+		t.Parallel()
+
+		registry := newTransactionEventHandlersRegistry(false)
+		_ = registry.register(newHandler("a", "", "A"))
+
+		operations, err := registry.produceOperations(&transaction.ApiTransactionResult{})
+		require.Nil(t, err)
+		require.Empty(t, operations)
+	})
+
+	t.Run("each event is dispatched once, with precedence for handlers restricted to a contract", func(t *testing.T) {
+		t.Parallel()
+
+		registry := newTransactionEventHandlersRegistry(false)
+		_ = registry.register(newHandler("a", "", "generic"))
+		_ = registry.register(newHandler("a", testscommon.TestContractFooShard0.Address, "foo"))
+
+		operations, err := registry.produceOperations(&transaction.ApiTransactionResult{
+			Logs: &transaction.ApiLogs{
+				Events: []*transaction.Events{
+					{Identifier: "a", Address: testscommon.TestContractFooShard0.Address, Topics: [][]byte{[]byte("1")}},
+					{Identifier: "a", Address: testscommon.TestContractBarShard0.Address, Topics: [][]byte{[]byte("2")}},
+				},
+			},
+		})
+
+		require.Nil(t, err)
+		require.Equal(t, []string{"generic:2", "foo:1"}, getTypesOfOperations(operations))
+	})
+
+	t.Run("inactive handlers are skipped", func(t *testing.T) {
+		t.Parallel()
+
+		registry := newTransactionEventHandlersRegistry(false)
+		_ = registry.register(newHandler("a", "", "generic"))
+
+		restricted := newHandler("a", testscommon.TestContractFooShard0.Address, "foo")
+		restricted.isActive = func(epoch uint32) bool { return epoch >= 42 }
+		_ = registry.register(restricted)
+
+		tx := &transaction.ApiTransactionResult{
+			Epoch: 41,
+			Logs: &transaction.ApiLogs{
+				Events: []*transaction.Events{
+					{Identifier: "a", Address: testscommon.TestContractFooShard0.Address, Topics: [][]byte{[]byte("1")}},
+				},
+			},
+		}
+
+		operations, err := registry.produceOperations(tx)
+		require.Nil(t, err)
+		require.Equal(t, []string{"generic:1"}, getTypesOfOperations(operations))
+
+		tx.Epoch = 42
+		operations, err = registry.produceOperations(tx)
+		require.Nil(t, err)
+		require.Equal(t, []string{"foo:1"}, getTypesOfOperations(operations))
+	})
+
+	t.Run("with malformed events (regardless of the strict mode)", func(t *testing.T) {
+		t.Parallel()
+
+		for _, isStrict := range []bool{false, true} {
+			registry := newTransactionEventHandlersRegistry(isStrict)
+			registry.markAsBalanceAffecting("a")
+			_ = registry.register(newHandler("a", "", "A"))
+			_ = registry.register(newHandler("b", "", "B"))
+
+			// Handled, though not (explicitly) balance-affecting: fails, as well.
+			operations, err := registry.produceOperations(&transaction.ApiTransactionResult{
+				Logs: &transaction.ApiLogs{
+					Events: []*transaction.Events{
+						{Identifier: "b", Topics: [][]byte{[]byte("1"), []byte("2")}},
+					},
+				},
+			})
+
+			require.ErrorIs(t, err, errCannotRecognizeEvent)
+			require.Nil(t, operations)
+
+			operations, err = registry.produceOperations(&transaction.ApiTransactionResult{
+				Hash:       "aaaa",
+				BlockNonce: 42,
+				Logs: &transaction.ApiLogs{
+					Events: []*transaction.Events{
+						{Identifier: "a", Topics: [][]byte{[]byte("3")}},
+						{Identifier: "a", Topics: [][]byte{[]byte("1"), []byte("2")}},
+					},
+				},
+			})
+
+			require.ErrorIs(t, err, errCannotRecognizeEvent)
+			require.Nil(t, operations)
+			require.Equal(t, unrecognizedEventStats{
+				Identifier:         "a",
+				IsBalanceAffecting: true,
+				NumMalformed:       1,
+				LastTxHash:         "aaaa",
+				LastBlockNonce:     42,
+				LastError:          "cannot recognize transaction event: bad number of topics for a event = 2",
+			}, registry.diagnostics.getStats()[0])
+		}
+	})
+
+	t.Run("with handler errors", func(t *testing.T) {
+		t.Parallel()
+
+		registry := newTransactionEventHandlersRegistry(false)
+		handler := newHandler("a", "", "A")
+		handler.handle = func(_ *transaction.ApiTransactionResult, _ *transaction.Events) ([]*types.Operation, error) {
+			return nil, errors.New("arbitrary error")
+		}
+		_ = registry.register(handler)
+
+		_, err := registry.produceOperations(&transaction.ApiTransactionResult{
+			Logs: &transaction.ApiLogs{
+				Events: []*transaction.Events{
+					{Identifier: "a", Topics: [][]byte{[]byte("1")}},
+				},
+			},
+		})
+
+		require.ErrorContains(t, err, "arbitrary error")
+		require.Equal(t, uint64(1), registry.diagnostics.getStats()[0].NumMalformed)
+	})
+
+	t.Run("with unknown events", func(t *testing.T) {
+		t.Parallel()
+
+		lenientRegistry := newTransactionEventHandlersRegistry(false)
+		lenientRegistry.markAsBalanceAffecting("x")
+		strictRegistry := newTransactionEventHandlersRegistry(true)
+		strictRegistry.markAsBalanceAffecting("x")
+
+		tx := &transaction.ApiTransactionResult{
+			Hash: "bbbb",
+			Logs: &transaction.ApiLogs{
+				Events: []*transaction.Events{
+					{Identifier: "writeLog"},
+					{Identifier: "completedTxEvent"},
+					{Identifier: "y"},
+					{Identifier: "y"},
+				},
+			},
+		}
+
+		_, err := lenientRegistry.produceOperations(tx)
+		require.Nil(t, err)
+		_, err = strictRegistry.produceOperations(tx)
+		require.Nil(t, err)
+
+		tx.Logs.Events = append(tx.Logs.Events, &transaction.Events{Identifier: "x"})
+
+		_, err = lenientRegistry.produceOperations(tx)
+		require.Nil(t, err)
+		_, err = strictRegistry.produceOperations(tx)
+		require.ErrorIs(t, err, errCannotRecognizeEvent)
+		require.ErrorContains(t, err, "unknown balance-affecting event x")
+
+		require.Equal(t, []unrecognizedEventStats{
+			{Identifier: "x", IsBalanceAffecting: true, NumUnknown: 1, LastTxHash: "bbbb"},
+			{Identifier: "y", NumUnknown: 4, LastTxHash: "bbbb"},
+		}, lenientRegistry.diagnostics.getStats())
+	})
+}
+
+func TestTransactionsTransformer_BuiltInEventHandlers(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	networkProvider.MockActivationEpochSirius = 42
+	networkProvider.MockNetworkConfig.ShouldUseStrictEventsMode = true
+	transformer := newTransactionsTransformer(networkProvider)
+
+	t.Run("transferValueOnly, before Sirius (not handled at all)", func(t *testing.T) {
+		t.Parallel()
+
+		operations, err := transformer.eventHandlers.produceOperations(&transaction.ApiTransactionResult{
+			Epoch: 41,
+			Logs: &transaction.ApiLogs{
+				Events: []*transaction.Events{
+					{
+						Identifier: "transferValueOnly",
+						Address:    "erd1qqqqqqqqqqqqqpgqmmud45gkr78scw8numnn290dsyzc7z6kq6uqw2jcza",
+						Topics: [][]byte{
+							testscommon.TestContractFooShard0.PubKey,
+							testscommon.TestContractBarShard0.PubKey,
+							big.NewInt(100).Bytes(),
+						},
+					},
+				},
+			},
+		})
+
+		require.Nil(t, err)
+		require.Empty(t, operations)
+	})
+
+	t.Run("transferValueOnly, after Sirius, with bad number of topics (strict mode)", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := transformer.eventHandlers.produceOperations(&transaction.ApiTransactionResult{
+			Epoch: 43,
+			Logs: &transaction.ApiLogs{
+				Events: []*transaction.Events{
+					{
+						Identifier: "transferValueOnly",
+						Address:    testscommon.TestContractFooShard0.Address,
+						Topics: [][]byte{
+							testscommon.TestContractFooShard0.PubKey,
+							testscommon.TestContractBarShard0.PubKey,
+							big.NewInt(100).Bytes(),
+						},
+					},
+				},
+			},
+		})
+
+		require.ErrorContains(t, err, "bad number of topics for transferValueOnly event = 3")
+	})
+}
+
+func TestTransactionsTransformer_RegisterEventHandler(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	networkProvider.MockCustomCurrencies = []resources.Currency{{Symbol: "WEGLD-abcdef"}}
+	extension := newNetworkProviderExtension(networkProvider)
+	transformer := newTransactionsTransformer(networkProvider)
+
+	wrappingContract := testscommon.TestContractFooShard0.Address
+
+	// A custom handler, for a (hypothetical) "wrapEgld" event of a wrapping contract (topics: caller, value).
+	err := transformer.registerEventHandler(&transactionEventHandler{
+		identifier:      "wrapEgld",
+		contractAddress: wrappingContract,
+		topics:          eventTopicsSchema{numTopics: 2},
+		handle: func(_ *transaction.ApiTransactionResult, event *transaction.Events) ([]*types.Operation, error) {
+			caller := networkProvider.ConvertPubKeyToAddress(event.Topics[0])
+			value := big.NewInt(0).SetBytes(event.Topics[1]).String()
+
+			return []*types.Operation{
+				{
+					Type:    opCustomTransfer,
+					Account: addressToAccountIdentifier(caller),
+					Amount:  extension.valueToCustomAmount(value, "WEGLD-abcdef"),
+				},
+			}, nil
+		},
+	})
+	require.Nil(t, err)
+
+	// Built-in handlers cannot be overridden for all emitters.
+	err = transformer.registerEventHandler(&transactionEventHandler{
+		identifier: transactionEventESDTLocalMint,
+		handle: func(_ *transaction.ApiTransactionResult, _ *transaction.Events) ([]*types.Operation, error) {
+			return nil, nil
+		},
+	})
+	require.ErrorIs(t, err, errEventHandlerAlreadyRegistered)
+
+	tx := &transaction.ApiTransactionResult{
+		Logs: &transaction.ApiLogs{
+			Events: []*transaction.Events{
+				{
+					Identifier: "wrapEgld",
+					Address:    wrappingContract,
+					Topics:     [][]byte{testscommon.TestPubKeyAlice, big.NewInt(1000).Bytes()},
+				},
+				{
+					// Same identifier, emitted by another contract: not handled.
+					Identifier: "wrapEgld",
+					Address:    testscommon.TestContractBarShard0.Address,
+					Topics:     [][]byte{testscommon.TestPubKeyAlice, big.NewInt(2000).Bytes()},
+				},
+			},
+		},
+	}
+
+	rosettaTx := &types.Transaction{Operations: []*types.Operation{}}
+	err = transformer.addOperationsGivenTransactionEvents(tx, rosettaTx, nil)
+	require.Nil(t, err)
+	require.Equal(t, []*types.Operation{
+		{
+			Type:    opCustomTransfer,
+			Account: addressToAccountIdentifier(testscommon.TestAddressAlice),
+			Amount:  extension.valueToCustomAmount("1000", "WEGLD-abcdef"),
+		},
+	}, rosettaTx.Operations)
+}
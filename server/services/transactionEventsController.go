@@ -1,7 +1,6 @@
 package services
 
 import (
-	"fmt"
 	"math/big"
 	"strings"
 
@@ -18,62 +17,23 @@ func newTransactionEventsController(provider NetworkProvider) *transactionEvents
 	}
 }
 
-func (controller *transactionEventsController) extractEventSCDeploy(tx *transaction.ApiTransactionResult) ([]*eventSCDeploy, error) {
-	rawEvents := controller.findManyEventsByIdentifier(tx, transactionEventSCDeploy)
-	typedEvents := make([]*eventSCDeploy, 0, len(rawEvents))
+// decodeEventSCDeploy decodes a "SCDeploy" event. Before Sirius, there are 2 topics: contract address, deployer address.
+// After Sirius, there are 3 topics: contract address, deployer address, codehash (not used).
+func (controller *transactionEventsController) decodeEventSCDeploy(event *transaction.Events) *eventSCDeploy {
+	// "event.Address" is same as "event.Topics[0]"" (the address of the deployed contract).
+	contractAddress := event.Address
+	deployerPubKey := event.Topics[1]
+	deployerAddress := controller.provider.ConvertPubKeyToAddress(deployerPubKey)
 
-	for _, event := range rawEvents {
-		numTopics := len(event.Topics)
-		if numTopics < numTopicsOfEventSCDeployBeforeSirius {
-			// Before Sirius, there are 2 topics: contract address, deployer address.
-			// After Sirius, there are 3 topics: contract address, deployer address, codehash (not used).
-			return nil, fmt.Errorf("%w: bad number of topics for SCdeploy event = %d", errCannotRecognizeEvent, numTopics)
-		}
-
-		// "event.Address" is same as "event.Topics[0]"" (the address of the deployed contract).
-		contractAddress := event.Address
-		deployerPubKey := event.Topics[1]
-		deployerAddress := controller.provider.ConvertPubKeyToAddress(deployerPubKey)
-
-		typedEvents = append(typedEvents, &eventSCDeploy{
-			contractAddress: contractAddress,
-			deployerAddress: deployerAddress,
-		})
+	return &eventSCDeploy{
+		contractAddress: contractAddress,
+		deployerAddress: deployerAddress,
 	}
-
-	return typedEvents, nil
-}
-
-func (controller *transactionEventsController) extractEventTransferValueOnly(tx *transaction.ApiTransactionResult) ([]*eventTransferValueOnly, error) {
-	if !controller.provider.IsReleaseSiriusActive(tx.Epoch) {
-		return make([]*eventTransferValueOnly, 0), nil
-	}
-
-	rawEvents := controller.findManyEventsByIdentifier(tx, transactionEventTransferValueOnly)
-	typedEvents := make([]*eventTransferValueOnly, 0)
-
-	for _, event := range rawEvents {
-		typedEvent, err := controller.decideEffectiveEventTransferValueOnlyAfterSirius(event)
-		if err != nil {
-			return nil, err
-		}
-
-		if typedEvent != nil {
-			typedEvents = append(typedEvents, typedEvent)
-		}
-	}
-
-	return typedEvents, nil
 }
 
+// decodeEventTransferValueOnly decodes a "transferValueOnly" event (after Sirius). It returns nil for ineffective events.
 // See: https://github.com/multiversx/mx-specs/blob/main/releases/protocol/release-specs-v1.6.0-Sirius.md#17-logs--events-changes-5490
-func (controller *transactionEventsController) decideEffectiveEventTransferValueOnlyAfterSirius(event *transaction.Events) (*eventTransferValueOnly, error) {
-	numTopics := len(event.Topics)
-
-	if numTopics != numTopicsOfEventTransferValueOnlyAfterSirius {
-		return nil, fmt.Errorf("%w: bad number of topics for 'transferValueOnly' = %d", errCannotRecognizeEvent, numTopics)
-	}
-
+func (controller *transactionEventsController) decodeEventTransferValueOnly(event *transaction.Events) (*eventTransferValueOnly, error) {
 	valueBytes := event.Topics[0]
 	receiverPubKey := event.Topics[1]
 
@@ -141,216 +101,81 @@ func (controller *transactionEventsController) hasSignalErrorOfSendingValueToNon
 	return false
 }
 
-func (controller *transactionEventsController) extractEventsESDTOrESDTNFTTransfers(tx *transaction.ApiTransactionResult) ([]*eventESDT, error) {
-	rawEventsESDTTransfer := controller.findManyEventsByIdentifier(tx, transactionEventESDTTransfer)
-	rawEventsESDTNFTTransfer := controller.findManyEventsByIdentifier(tx, transactionEventESDTNFTTransfer)
-	rawEventsMultiESDTNFTTransfer := controller.findManyEventsByIdentifier(tx, transactionEventMultiESDTNFTTransfer)
-
-	typedEvents := make([]*eventESDT, 0)
-
-	// First, handle single transfers
-	for _, event := range append(rawEventsESDTTransfer, rawEventsESDTNFTTransfer...) {
-		numTopics := len(event.Topics)
-		if numTopics != numTopicsOfEventESDTTransfer {
-			return nil, fmt.Errorf("%w: bad number of topics for (ESDT|ESDTNFT)Transfer event = %d", errCannotRecognizeEvent, numTopics)
-		}
-
-		typedEvent, err := newEventESDTFromBasicTopics(event.Topics)
-		if err != nil {
-			return nil, err
-		}
-
-		receiverPubkey := event.Topics[3]
-		typedEvent.receiverAddress = controller.provider.ConvertPubKeyToAddress(receiverPubkey)
-		typedEvent.senderAddress = event.Address
-		typedEvents = append(typedEvents, typedEvent)
-	}
-
-	// Then, handle multi transfers
-	for _, event := range rawEventsMultiESDTNFTTransfer {
-		numTopics := len(event.Topics)
-		numTopicsExceptLast := numTopics - 1
-		numTopicsPerTransfer := numTopicsPerTransferOfEventMultiESDTNFTTransfer
-
-		if numTopicsExceptLast%numTopicsPerTransfer != 0 {
-			return nil, fmt.Errorf("%w: bad number of topics for MultiESDTNFTTransfer event = %d", errCannotRecognizeEvent, numTopics)
-		}
-
-		numTransfers := numTopicsExceptLast / numTopicsPerTransfer
-		receiverPubkey := event.Topics[numTopics-1]
-		receiver := controller.provider.ConvertPubKeyToAddress(receiverPubkey)
-
-		for i := 0; i < numTransfers; i++ {
-			typedEvent, err := newEventESDTFromBasicTopics(event.Topics[i*numTopicsPerTransfer+0 : i*numTopicsPerTransfer+3])
-			if err != nil {
-				return nil, err
-			}
-
-			typedEvent.receiverAddress = receiver
-			typedEvent.senderAddress = event.Address
-			typedEvents = append(typedEvents, typedEvent)
-		}
-	}
-
-	return typedEvents, nil
-}
-
-func (controller *transactionEventsController) extractEventsESDTLocalBurn(tx *transaction.ApiTransactionResult) ([]*eventESDT, error) {
-	rawEvents := controller.findManyEventsByIdentifier(tx, transactionEventESDTLocalBurn)
-	typedEvents := make([]*eventESDT, 0, len(rawEvents))
-
-	for _, event := range rawEvents {
-		numTopics := len(event.Topics)
-		if numTopics != numTopicsOfEventESDTLocalBurn {
-			return nil, fmt.Errorf("%w: bad number of topics for ESDTLocalBurn event = %d", errCannotRecognizeEvent, numTopics)
-		}
-
-		typedEvent, err := newEventESDTFromBasicTopics(event.Topics)
-		if err != nil {
-			return nil, err
-		}
-
-		typedEvent.otherAddress = event.Address
-		typedEvents = append(typedEvents, typedEvent)
-	}
-
-	return typedEvents, nil
-}
-
-func (controller *transactionEventsController) extractEventsESDTLocalMint(tx *transaction.ApiTransactionResult) ([]*eventESDT, error) {
-	rawEvents := controller.findManyEventsByIdentifier(tx, transactionEventESDTLocalMint)
-	typedEvents := make([]*eventESDT, 0, len(rawEvents))
-
-	for _, event := range rawEvents {
-		numTopics := len(event.Topics)
-		if numTopics != numTopicsOfEventESDTLocalMint {
-			return nil, fmt.Errorf("%w: bad number of topics for ESDTLocalMint event = %d", errCannotRecognizeEvent, numTopics)
-		}
-
-		typedEvent, err := newEventESDTFromBasicTopics(event.Topics)
-		if err != nil {
-			return nil, err
-		}
-
-		typedEvent.otherAddress = event.Address
-		typedEvents = append(typedEvents, typedEvent)
-	}
-
-	return typedEvents, nil
-}
-
-func (controller *transactionEventsController) extractEventsESDTWipe(tx *transaction.ApiTransactionResult) ([]*eventESDT, error) {
-	rawEvents := controller.findManyEventsByIdentifier(tx, transactionEventESDTWipe)
-	typedEvents := make([]*eventESDT, 0, len(rawEvents))
-
-	for _, event := range rawEvents {
-		numTopics := len(event.Topics)
-		if numTopics != numTopicsOfEventESDTWipe {
-			return nil, fmt.Errorf("%w: bad number of topics for ESDTWipe event = %d", errCannotRecognizeEvent, numTopics)
-		}
-
-		typedEvent, err := newEventESDTFromBasicTopics(event.Topics)
-		if err != nil {
-			return nil, err
-		}
-
-		accountPubkey := event.Topics[3]
-		typedEvent.otherAddress = controller.provider.ConvertPubKeyToAddress(accountPubkey)
-		typedEvents = append(typedEvents, typedEvent)
+// decodeEventESDTOrESDTNFTTransfer decodes an "ESDTTransfer" or an "ESDTNFTTransfer" event.
+// Topics: identifier, nonce, value, receiver.
+func (controller *transactionEventsController) decodeEventESDTOrESDTNFTTransfer(event *transaction.Events) (*eventESDT, error) {
+	typedEvent, err := newEventESDTFromBasicTopics(event.Topics)
+	if err != nil {
+		return nil, err
 	}
 
-	return typedEvents, nil
+	receiverPubkey := event.Topics[3]
+	typedEvent.receiverAddress = controller.provider.ConvertPubKeyToAddress(receiverPubkey)
+	typedEvent.senderAddress = event.Address
+	return typedEvent, nil
 }
 
-func (controller *transactionEventsController) extractEventsESDTNFTCreate(tx *transaction.ApiTransactionResult) ([]*eventESDT, error) {
-	rawEvents := controller.findManyEventsByIdentifier(tx, transactionEventESDTNFTCreate)
-	typedEvents := make([]*eventESDT, 0, len(rawEvents))
+// decodeEventMultiESDTNFTTransfer decodes a "MultiESDTNFTTransfer" event into one typed event per transfer.
+// Topics: (identifier, nonce, value) for each transfer, then the receiver.
+func (controller *transactionEventsController) decodeEventMultiESDTNFTTransfer(event *transaction.Events) ([]*eventESDT, error) {
+	numTopics := len(event.Topics)
+	numTopicsPerTransfer := topicsOfEventMultiESDTNFTTransfer.numTopicsPerGroup
+	numTransfers := (numTopics - 1) / numTopicsPerTransfer
+	receiverPubkey := event.Topics[numTopics-1]
+	receiver := controller.provider.ConvertPubKeyToAddress(receiverPubkey)
 
-	for _, event := range rawEvents {
-		numTopics := len(event.Topics)
-		if numTopics != numTopicsOfEventESDTNFTCreate {
-			return nil, fmt.Errorf("%w: bad number of topics for %s event = %d", errCannotRecognizeEvent, transactionEventESDTNFTCreate, numTopics)
-		}
+	typedEvents := make([]*eventESDT, 0, numTransfers)
 
-		typedEvent, err := newEventESDTFromBasicTopics(event.Topics)
+	for i := 0; i < numTransfers; i++ {
+		typedEvent, err := newEventESDTFromBasicTopics(event.Topics[i*numTopicsPerTransfer+0 : i*numTopicsPerTransfer+3])
 		if err != nil {
 			return nil, err
 		}
 
-		typedEvent.otherAddress = event.Address
+		typedEvent.receiverAddress = receiver
+		typedEvent.senderAddress = event.Address
 		typedEvents = append(typedEvents, typedEvent)
 	}
 
 	return typedEvents, nil
 }
 
-func (controller *transactionEventsController) extractEventsESDTNFTBurn(tx *transaction.ApiTransactionResult) ([]*eventESDT, error) {
-	rawEvents := controller.findManyEventsByIdentifier(tx, transactionEventESDTNFTBurn)
-	typedEvents := make([]*eventESDT, 0, len(rawEvents))
-
-	for _, event := range rawEvents {
-		numTopics := len(event.Topics)
-		if numTopics != numTopicsOfEventESDTNFTBurn {
-			return nil, fmt.Errorf("%w: bad number of topics for %s event = %d", errCannotRecognizeEvent, transactionEventESDTNFTBurn, numTopics)
-		}
-
-		typedEvent, err := newEventESDTFromBasicTopics(event.Topics)
-		if err != nil {
-			return nil, err
-		}
-
-		typedEvent.otherAddress = event.Address
-		typedEvents = append(typedEvents, typedEvent)
+// decodeEventESDTOfEmitter decodes events such as "ESDTLocalBurn", "ESDTLocalMint", "ESDTNFTCreate", "ESDTNFTBurn" or "ESDTNFTAddQuantity",
+// whose balance change affects the emitter of the event. Topics: identifier, nonce, value (and, possibly, others, not used).
+func (controller *transactionEventsController) decodeEventESDTOfEmitter(event *transaction.Events) (*eventESDT, error) {
+	typedEvent, err := newEventESDTFromBasicTopics(event.Topics)
+	if err != nil {
+		return nil, err
 	}
 
-	return typedEvents, nil
+	typedEvent.otherAddress = event.Address
+	return typedEvent, nil
 }
 
-func (controller *transactionEventsController) extractEventsESDTNFTAddQuantity(tx *transaction.ApiTransactionResult) ([]*eventESDT, error) {
-	rawEvents := controller.findManyEventsByIdentifier(tx, transactionEventESDTNFTAddQuantity)
-	typedEvents := make([]*eventESDT, 0, len(rawEvents))
-
-	for _, event := range rawEvents {
-		numTopics := len(event.Topics)
-		if numTopics != numTopicsOfEventESDTNFTAddQuantity {
-			return nil, fmt.Errorf("%w: bad number of topics for %s event = %d", errCannotRecognizeEvent, transactionEventESDTNFTAddQuantity, numTopics)
-		}
-
-		typedEvent, err := newEventESDTFromBasicTopics(event.Topics)
-		if err != nil {
-			return nil, err
-		}
-
-		typedEvent.otherAddress = event.Address
-		typedEvents = append(typedEvents, typedEvent)
+// decodeEventESDTWipe decodes an "ESDTWipe" event. Topics: identifier, nonce, value, the account being wiped.
+func (controller *transactionEventsController) decodeEventESDTWipe(event *transaction.Events) (*eventESDT, error) {
+	typedEvent, err := newEventESDTFromBasicTopics(event.Topics)
+	if err != nil {
+		return nil, err
 	}
 
-	return typedEvents, nil
+	accountPubkey := event.Topics[3]
+	typedEvent.otherAddress = controller.provider.ConvertPubKeyToAddress(accountPubkey)
+	return typedEvent, nil
 }
 
-func (controller *transactionEventsController) extractEventsClaimDeveloperRewards(tx *transaction.ApiTransactionResult) ([]*eventClaimDeveloperRewards, error) {
-	rawEvents := controller.findManyEventsByIdentifier(tx, transactionEventClaimDeveloperRewards)
-	typedEvents := make([]*eventClaimDeveloperRewards, 0, len(rawEvents))
-
-	for _, event := range rawEvents {
-		numTopics := len(event.Topics)
-		if numTopics != numTopicsOfEventClaimDeveloperRewards {
-			return nil, fmt.Errorf("%w: bad number of topics for %s event = %d", errCannotRecognizeEvent, transactionEventClaimDeveloperRewards, numTopics)
-		}
-
-		valueBytes := event.Topics[0]
-		receiverPubkey := event.Topics[1]
+// decodeEventClaimDeveloperRewards decodes a "ClaimDeveloperRewards" event. Topics: value, receiver.
+func (controller *transactionEventsController) decodeEventClaimDeveloperRewards(event *transaction.Events) *eventClaimDeveloperRewards {
+	valueBytes := event.Topics[0]
+	receiverPubkey := event.Topics[1]
 
-		value := big.NewInt(0).SetBytes(valueBytes)
-		receiver := controller.provider.ConvertPubKeyToAddress(receiverPubkey)
+	value := big.NewInt(0).SetBytes(valueBytes)
+	receiver := controller.provider.ConvertPubKeyToAddress(receiverPubkey)
 
-		typedEvents = append(typedEvents, &eventClaimDeveloperRewards{
-			value:           value.String(),
-			receiverAddress: receiver,
-		})
+	return &eventClaimDeveloperRewards{
+		value:           value.String(),
+		receiverAddress: receiver,
 	}
-
-	return typedEvents, nil
 }
 
 func (controller *transactionEventsController) findManyEventsByIdentifier(tx *transaction.ApiTransactionResult, identifier string) []*transaction.Events {
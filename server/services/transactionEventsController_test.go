@@ -172,12 +172,10 @@ func TestEventHasTopic(t *testing.T) {
 	require.False(t, eventHasTopic(&event, "bar"))
 }
 
-func TestTransactionEventsController_ExtractEvents(t *testing.T) {
+func TestTransactionEventsController_DecodeEvents(t *testing.T) {
 	networkProvider := testscommon.NewNetworkProviderMock()
 	controller := newTransactionEventsController(networkProvider)
 
-	networkProvider.MockActivationEpochSirius = 42
-
 	t.Run("SCDeploy", func(t *testing.T) {
 		topic0, _ := hex.DecodeString("00000000000000000500def8dad1161f8f0c38f3e6e73515ed81058f0b5606b8")
 		topic1, _ := hex.DecodeString("5cf4abc83e50c5309d807fc3f676988759a1e301001bc9a0265804f42af806b8")
@@ -199,38 +197,9 @@ func TestTransactionEventsController_ExtractEvents(t *testing.T) {
 			},
 		}
 
-		events, err := controller.extractEventSCDeploy(tx)
-		require.NoError(t, err)
-		require.Len(t, events, 1)
-		require.Equal(t, "erd1qqqqqqqqqqqqqpgqmmud45gkr78scw8numnn290dsyzc7z6kq6uqw2jcza", events[0].contractAddress)
-		require.Equal(t, "erd1tn62hjp72rznp8vq0lplva5csav6rccpqqdungpxtqz0g2hcq6uq9k4cc6", events[0].deployerAddress)
-	})
-
-	t.Run("transferValueOnly, before Sirius (not handled at all)", func(t *testing.T) {
-		topic0 := testscommon.TestContractFooShard0.PubKey
-		topic1 := testscommon.TestContractBarShard0.PubKey
-		topic2 := big.NewInt(100).Bytes()
-
-		tx := &transaction.ApiTransactionResult{
-			Epoch: 41,
-			Logs: &transaction.ApiLogs{
-				Events: []*transaction.Events{
-					{
-						Identifier: "transferValueOnly",
-						Address:    "erd1qqqqqqqqqqqqqpgqmmud45gkr78scw8numnn290dsyzc7z6kq6uqw2jcza",
-						Topics: [][]byte{
-							topic0,
-							topic1,
-							topic2,
-						},
-					},
-				},
-			},
-		}
-
-		events, err := controller.extractEventTransferValueOnly(tx)
-		require.NoError(t, err)
-		require.Len(t, events, 0)
+		event := controller.decodeEventSCDeploy(tx.Logs.Events[0])
+		require.Equal(t, "erd1qqqqqqqqqqqqqpgqmmud45gkr78scw8numnn290dsyzc7z6kq6uqw2jcza", event.contractAddress)
+		require.Equal(t, "erd1tn62hjp72rznp8vq0lplva5csav6rccpqqdungpxtqz0g2hcq6uq9k4cc6", event.deployerAddress)
 	})
 
 	t.Run("transferValueOnly, after Sirius, effective (intra-shard ExecuteOnDestContext)", func(t *testing.T) {
@@ -254,12 +223,12 @@ func TestTransactionEventsController_ExtractEvents(t *testing.T) {
 			},
 		}
 
-		events, err := controller.extractEventTransferValueOnly(tx)
+		event, err := controller.decodeEventTransferValueOnly(tx.Logs.Events[0])
 		require.NoError(t, err)
-		require.Len(t, events, 1)
-		require.Equal(t, testscommon.TestContractFooShard0.Address, events[0].sender)
-		require.Equal(t, testscommon.TestContractBarShard0.Address, events[0].receiver)
-		require.Equal(t, "100", events[0].value)
+		require.NotNil(t, event)
+		require.Equal(t, testscommon.TestContractFooShard0.Address, event.sender)
+		require.Equal(t, testscommon.TestContractBarShard0.Address, event.receiver)
+		require.Equal(t, "100", event.value)
 	})
 
 	t.Run("transferValueOnly, after Sirius, ineffective (cross-shard AsyncCall)", func(t *testing.T) {
@@ -283,9 +252,9 @@ func TestTransactionEventsController_ExtractEvents(t *testing.T) {
 			},
 		}
 
-		events, err := controller.extractEventTransferValueOnly(tx)
+		event, err := controller.decodeEventTransferValueOnly(tx.Logs.Events[0])
 		require.NoError(t, err)
-		require.Len(t, events, 0)
+		require.Nil(t, event)
 	})
 
 	t.Run("transferValueOnly, after Sirius, ineffective, intra-shard BackTransfer", func(t *testing.T) {
@@ -309,9 +278,9 @@ func TestTransactionEventsController_ExtractEvents(t *testing.T) {
 			},
 		}
 
-		events, err := controller.extractEventTransferValueOnly(tx)
+		event, err := controller.decodeEventTransferValueOnly(tx.Logs.Events[0])
 		require.NoError(t, err)
-		require.Len(t, events, 0)
+		require.Nil(t, event)
 	})
 
 	t.Run("ESDTNFTCreate", func(t *testing.T) {
@@ -332,13 +301,12 @@ func TestTransactionEventsController_ExtractEvents(t *testing.T) {
 			},
 		}
 
-		events, err := controller.extractEventsESDTNFTCreate(tx)
+		event, err := controller.decodeEventESDTOfEmitter(tx.Logs.Events[0])
 		require.NoError(t, err)
-		require.Len(t, events, 1)
-		require.Equal(t, "EXAMPLE-abcdef", events[0].identifier)
-		require.Equal(t, testscommon.TestAddressAlice, events[0].otherAddress)
-		require.Equal(t, []byte{0x2a}, events[0].nonceAsBytes)
-		require.Equal(t, "1", events[0].value)
+		require.Equal(t, "EXAMPLE-abcdef", event.identifier)
+		require.Equal(t, testscommon.TestAddressAlice, event.otherAddress)
+		require.Equal(t, []byte{0x2a}, event.nonceAsBytes)
+		require.Equal(t, "1", event.value)
 	})
 
 	t.Run("ESDTNFTBurn", func(t *testing.T) {
@@ -358,13 +326,12 @@ func TestTransactionEventsController_ExtractEvents(t *testing.T) {
 			},
 		}
 
-		events, err := controller.extractEventsESDTNFTBurn(tx)
+		event, err := controller.decodeEventESDTOfEmitter(tx.Logs.Events[0])
 		require.NoError(t, err)
-		require.Len(t, events, 1)
-		require.Equal(t, "EXAMPLE-abcdef", events[0].identifier)
-		require.Equal(t, testscommon.TestAddressAlice, events[0].otherAddress)
-		require.Equal(t, []byte{0x2a}, events[0].nonceAsBytes)
-		require.Equal(t, "1", events[0].value)
+		require.Equal(t, "EXAMPLE-abcdef", event.identifier)
+		require.Equal(t, testscommon.TestAddressAlice, event.otherAddress)
+		require.Equal(t, []byte{0x2a}, event.nonceAsBytes)
+		require.Equal(t, "1", event.value)
 	})
 
 	t.Run("ESDTNFTAddQuantity", func(t *testing.T) {
@@ -384,13 +351,12 @@ func TestTransactionEventsController_ExtractEvents(t *testing.T) {
 			},
 		}
 
-		events, err := controller.extractEventsESDTNFTAddQuantity(tx)
+		event, err := controller.decodeEventESDTOfEmitter(tx.Logs.Events[0])
 		require.NoError(t, err)
-		require.Len(t, events, 1)
-		require.Equal(t, "EXAMPLE-aabbcc", events[0].identifier)
-		require.Equal(t, testscommon.TestAddressAlice, events[0].otherAddress)
-		require.Equal(t, []byte{0x2a}, events[0].nonceAsBytes)
-		require.Equal(t, "100", events[0].value)
+		require.Equal(t, "EXAMPLE-aabbcc", event.identifier)
+		require.Equal(t, testscommon.TestAddressAlice, event.otherAddress)
+		require.Equal(t, []byte{0x2a}, event.nonceAsBytes)
+		require.Equal(t, "100", event.value)
 	})
 
 	t.Run("ClaimDeveloperRewards", func(t *testing.T) {
@@ -411,10 +377,8 @@ func TestTransactionEventsController_ExtractEvents(t *testing.T) {
 			},
 		}
 
-		events, err := controller.extractEventsClaimDeveloperRewards(tx)
-		require.NoError(t, err)
-		require.Len(t, events, 1)
-		require.Equal(t, "100", events[0].value)
-		require.Equal(t, "erd1tn62hjp72rznp8vq0lplva5csav6rccpqqdungpxtqz0g2hcq6uq9k4cc6", events[0].receiverAddress)
+		event := controller.decodeEventClaimDeveloperRewards(tx.Logs.Events[0])
+		require.Equal(t, "100", event.value)
+		require.Equal(t, "erd1tn62hjp72rznp8vq0lplva5csav6rccpqqdungpxtqz0g2hcq6uq9k4cc6", event.receiverAddress)
 	})
 }
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+)
+
+// Event identifiers are arbitrary (e.g. emitted by contracts), thus the number of tracked identifiers is bounded.
+const maxNumUnrecognizedEventIdentifiers = 1000
+
+// unrecognizedEventStats holds the counters of the unknown (not handled) or malformed events having a given identifier
+type unrecognizedEventStats struct {
+	Identifier         string `json:"identifier"`
+	IsBalanceAffecting bool   `json:"is_balance_affecting"`
+	NumUnknown         uint64 `json:"num_unknown"`
+	NumMalformed       uint64 `json:"num_malformed"`
+	LastTxHash         string `json:"last_tx_hash"`
+	LastBlockNonce     uint64 `json:"last_block_nonce"`
+	LastError          string `json:"last_error,omitempty"`
+}
+
+// transactionEventsDiagnostics counts (per identifier) the events that couldn't be turned into operations.
+type transactionEventsDiagnostics struct {
+	mutex             sync.RWMutex
+	statsByIdentifier map[string]*unrecognizedEventStats
+}
+
+func newTransactionEventsDiagnostics() *transactionEventsDiagnostics {
+	return &transactionEventsDiagnostics{
+		statsByIdentifier: make(map[string]*unrecognizedEventStats),
+	}
+}
+
+func (diagnostics *transactionEventsDiagnostics) recordUnknownEvent(tx *transaction.ApiTransactionResult, event *transaction.Events, isBalanceAffecting bool) {
+	diagnostics.mutex.Lock()
+	defer diagnostics.mutex.Unlock()
+
+	stats, ok := diagnostics.getOrCreateStats(tx, event, isBalanceAffecting)
+	if ok {
+		stats.NumUnknown++
+	}
+}
+
+func (diagnostics *transactionEventsDiagnostics) recordMalformedEvent(tx *transaction.ApiTransactionResult, event *transaction.Events, isBalanceAffecting bool, err error) {
+	diagnostics.mutex.Lock()
+	defer diagnostics.mutex.Unlock()
+
+	stats, ok := diagnostics.getOrCreateStats(tx, event, isBalanceAffecting)
+	if ok {
+		stats.NumMalformed++
+		stats.LastError = err.Error()
+	}
+}
+
+// getOrCreateStats must be called under the mutex. It returns false if the identifier isn't tracked (and cannot be, since the bound has been reached).
+func (diagnostics *transactionEventsDiagnostics) getOrCreateStats(tx *transaction.ApiTransactionResult, event *transaction.Events, isBalanceAffecting bool) (*unrecognizedEventStats, bool) {
+	stats, ok := diagnostics.statsByIdentifier[event.Identifier]
+	if !ok {
+		if len(diagnostics.statsByIdentifier) >= maxNumUnrecognizedEventIdentifiers {
+			return nil, false
+		}
+
+		stats = &unrecognizedEventStats{Identifier: event.Identifier}
+		diagnostics.statsByIdentifier[event.Identifier] = stats
+	}
+
+	stats.IsBalanceAffecting = isBalanceAffecting
+	stats.LastTxHash = tx.Hash
+	stats.LastBlockNonce = tx.BlockNonce
+	return stats, true
+}
+
+// getStats returns a copy of the counters, sorted by identifier
+func (diagnostics *transactionEventsDiagnostics) getStats() []unrecognizedEventStats {
+	diagnostics.mutex.RLock()
+	defer diagnostics.mutex.RUnlock()
+
+	result := make([]unrecognizedEventStats, 0, len(diagnostics.statsByIdentifier))
+	for _, stats := range diagnostics.statsByIdentifier {
+		result = append(result, *stats)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Identifier < result[j].Identifier
+	})
+
+	return result
+}
@@ -0,0 +1,26 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionEventsDiagnostics_IsBounded(t *testing.T) {
+	t.Parallel()
+
+	diagnostics := newTransactionEventsDiagnostics()
+	tx := &transaction.ApiTransactionResult{Hash: "aaaa"}
+
+	for i := 0; i < maxNumUnrecognizedEventIdentifiers+10; i++ {
+		diagnostics.recordUnknownEvent(tx, &transaction.Events{Identifier: fmt.Sprintf("event%d", i)}, false)
+	}
+
+	require.Len(t, diagnostics.getStats(), maxNumUnrecognizedEventIdentifiers)
+
+	// Identifiers already tracked are still counted.
+	diagnostics.recordUnknownEvent(tx, &transaction.Events{Identifier: "event0"}, false)
+	require.Equal(t, uint64(2), diagnostics.getStats()[0].NumUnknown)
+}
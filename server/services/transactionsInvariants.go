@@ -0,0 +1,305 @@
+package services
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-core-go/data/api"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+)
+
+// Invariants checked (if enabled) on the outcome of the transformation
+const (
+	invariantTransfersNetToZero        = "transfersNetToZero"
+	invariantFeeWithinGasLimit         = "feeWithinGasLimit"
+	invariantSignsOfAmounts            = "signsOfAmounts"
+	invariantUniqueTransactionsInBlock = "uniqueTransactionsInBlock"
+)
+
+// Events that change the supply of a token: their operations (custom transfers) have a single leg.
+var eventsChangingTokenSupply = map[string]struct{}{
+	transactionEventESDTLocalBurn:      {},
+	transactionEventESDTLocalMint:      {},
+	transactionEventESDTWipe:           {},
+	transactionEventESDTNFTCreate:      {},
+	transactionEventESDTNFTBurn:        {},
+	transactionEventESDTNFTAddQuantity: {},
+}
+
+// Operations (native transfers) that always come in pairs (sender, receiver)
+var operationTypesOfTransferLegs = map[string]struct{}{
+	opTransfer: {},
+	opScResult: {},
+}
+
+var operationTypesWithNonPositiveAmounts = map[string]struct{}{
+	opFee:            {},
+	opFeeOfInvalidTx: {},
+}
+
+var operationTypesWithNonNegativeAmounts = map[string]struct{}{
+	opReward:                     {},
+	opFeeRefund:                  {},
+	opFeeRefundAsScResult:        {},
+	opDeveloperRewards:           {},
+	opDeveloperRewardsAsScResult: {},
+}
+
+// transactionInvariant is checked on each Rosetta transaction, before filtering its operations (by address, by amount).
+// The original transaction is nil for Rosetta transactions created from receipts.
+type transactionInvariant struct {
+	name  string
+	check func(tx *transaction.ApiTransactionResult, rosettaTx *types.Transaction) error
+}
+
+// blockInvariant is checked on the (final) Rosetta transactions of a block.
+type blockInvariant struct {
+	name  string
+	check func(block *api.Block, rosettaTxs []*types.Transaction) error
+}
+
+// invariantsChecker checks the enabled invariants. Violations are logged and counted - or, in strict mode, returned as errors.
+type invariantsChecker struct {
+	isStrict        bool
+	txInvariants    []*transactionInvariant
+	blockInvariants []*blockInvariant
+	diagnostics     *invariantsDiagnostics
+}
+
+var txInvariantsByName = map[string]*transactionInvariant{
+	invariantTransfersNetToZero: {name: invariantTransfersNetToZero, check: checkTransfersNetToZero},
+	invariantFeeWithinGasLimit:  {name: invariantFeeWithinGasLimit, check: checkFeeWithinGasLimit},
+	invariantSignsOfAmounts:     {name: invariantSignsOfAmounts, check: checkSignsOfAmounts},
+}
+
+var blockInvariantsByName = map[string]*blockInvariant{
+	invariantUniqueTransactionsInBlock: {name: invariantUniqueTransactionsInBlock, check: checkUniqueTransactionsInBlock},
+}
+
+// validateInvariants checks that the invariants to be enabled are known (e.g. not misspelled in the configuration)
+func validateInvariants(names []string) error {
+	for _, name := range names {
+		_, isTxInvariant := txInvariantsByName[name]
+		_, isBlockInvariant := blockInvariantsByName[name]
+		if !isTxInvariant && !isBlockInvariant {
+			return fmt.Errorf("%w: %s", errUnknownInvariant, name)
+		}
+	}
+
+	return nil
+}
+
+func newInvariantsChecker(enabledInvariants []string, isStrict bool) *invariantsChecker {
+	checker := &invariantsChecker{
+		isStrict:        isStrict,
+		txInvariants:    make([]*transactionInvariant, 0),
+		blockInvariants: make([]*blockInvariant, 0),
+		diagnostics:     newInvariantsDiagnostics(),
+	}
+
+	for _, name := range enabledInvariants {
+		if invariant, ok := txInvariantsByName[name]; ok {
+			checker.txInvariants = append(checker.txInvariants, invariant)
+			continue
+		}
+
+		if invariant, ok := blockInvariantsByName[name]; ok {
+			checker.blockInvariants = append(checker.blockInvariants, invariant)
+			continue
+		}
+
+		log.Warn("newInvariantsChecker(): unknown invariant, ignored", "name", name)
+	}
+
+	return checker
+}
+
+func (checker *invariantsChecker) getEnabledInvariants() []string {
+	names := make([]string, 0, len(checker.txInvariants)+len(checker.blockInvariants))
+
+	for _, invariant := range checker.txInvariants {
+		names = append(names, invariant.name)
+	}
+	for _, invariant := range checker.blockInvariants {
+		names = append(names, invariant.name)
+	}
+
+	return names
+}
+
+func (checker *invariantsChecker) checkTransaction(blockNonce uint64, tx *transaction.ApiTransactionResult, rosettaTx *types.Transaction) error {
+	for _, invariant := range checker.txInvariants {
+		err := invariant.check(tx, rosettaTx)
+		if err != nil {
+			return checker.onViolation(invariant.name, blockNonce, rosettaTx.TransactionIdentifier.Hash, err)
+		}
+	}
+
+	return nil
+}
+
+func (checker *invariantsChecker) checkBlock(block *api.Block, rosettaTxs []*types.Transaction) error {
+	for _, invariant := range checker.blockInvariants {
+		err := invariant.check(block, rosettaTxs)
+		if err != nil {
+			return checker.onViolation(invariant.name, block.Nonce, "", err)
+		}
+	}
+
+	return nil
+}
+
+func (checker *invariantsChecker) onViolation(name string, blockNonce uint64, txHash string, err error) error {
+	checker.diagnostics.recordViolation(name, blockNonce, txHash, err)
+
+	if checker.isStrict {
+		return fmt.Errorf("%w: %s, block = %d, tx = %s: %v", errInvariantViolated, name, blockNonce, txHash, err)
+	}
+
+	log.Warn("invariant violated", "invariant", name, "block", blockNonce, "tx", txHash, "err", err)
+	return nil
+}
+
+// checkTransfersNetToZero checks that the legs of transfers net to zero, for each currency.
+// Custom currencies are not checked if the transaction holds events that change the supply of tokens (e.g. burn, mint).
+func checkTransfersNetToZero(tx *transaction.ApiTransactionResult, rosettaTx *types.Transaction) error {
+	shouldCheckCustomCurrencies := tx != nil && !holdsEventsChangingTokenSupply(tx)
+	sums := make(map[string]*big.Int)
+
+	for _, operation := range rosettaTx.Operations {
+		_, isTransferLeg := operationTypesOfTransferLegs[operation.Type]
+		isCustomTransferLeg := shouldCheckCustomCurrencies && operation.Type == opCustomTransfer
+		if !isTransferLeg && !isCustomTransferLeg {
+			continue
+		}
+
+		value, err := parseAmountOfOperation(operation)
+		if err != nil {
+			return err
+		}
+
+		symbol := operation.Amount.Currency.Symbol
+		if _, ok := sums[symbol]; !ok {
+			sums[symbol] = big.NewInt(0)
+		}
+
+		sums[symbol].Add(sums[symbol], value)
+	}
+
+	for symbol, sum := range sums {
+		if sum.Sign() != 0 {
+			return fmt.Errorf("transfers of %s do not net to zero: %s", symbol, sum.String())
+		}
+	}
+
+	return nil
+}
+
+// checkFeeWithinGasLimit checks that the fee (of a normal or invalid transaction) does not exceed gasLimit * gasPrice.
+func checkFeeWithinGasLimit(tx *transaction.ApiTransactionResult, rosettaTx *types.Transaction) error {
+	if tx == nil {
+		return nil
+	}
+
+	isNormal := tx.Type == string(transaction.TxTypeNormal)
+	isInvalid := tx.Type == string(transaction.TxTypeInvalid)
+	if !isNormal && !isInvalid {
+		return nil
+	}
+
+	// Gas limit and gas price are missing if the transaction isn't fully provided (nothing to check against).
+	if tx.GasLimit == 0 || tx.GasPrice == 0 {
+		return nil
+	}
+
+	fee := big.NewInt(0)
+
+	for _, operation := range rosettaTx.Operations {
+		if operation.Type != opFee && operation.Type != opFeeOfInvalidTx {
+			continue
+		}
+
+		value, err := parseAmountOfOperation(operation)
+		if err != nil {
+			return err
+		}
+
+		fee.Sub(fee, value)
+	}
+
+	maxFee := big.NewInt(0).Mul(big.NewInt(0).SetUint64(tx.GasLimit), big.NewInt(0).SetUint64(tx.GasPrice))
+	if fee.Cmp(maxFee) > 0 {
+		return fmt.Errorf("fee %s exceeds gasLimit * gasPrice = %s", fee.String(), maxFee.String())
+	}
+
+	return nil
+}
+
+// checkSignsOfAmounts checks that fees are debited, while rewards and refunds are credited.
+func checkSignsOfAmounts(_ *transaction.ApiTransactionResult, rosettaTx *types.Transaction) error {
+	for _, operation := range rosettaTx.Operations {
+		_, mustBeNonPositive := operationTypesWithNonPositiveAmounts[operation.Type]
+		_, mustBeNonNegative := operationTypesWithNonNegativeAmounts[operation.Type]
+		if !mustBeNonPositive && !mustBeNonNegative {
+			continue
+		}
+
+		value, err := parseAmountOfOperation(operation)
+		if err != nil {
+			return err
+		}
+
+		if mustBeNonPositive && value.Sign() > 0 {
+			return fmt.Errorf("operation %s has a positive amount: %s", operation.Type, value.String())
+		}
+		if mustBeNonNegative && value.Sign() < 0 {
+			return fmt.Errorf("operation %s has a negative amount: %s", operation.Type, value.String())
+		}
+	}
+
+	return nil
+}
+
+// checkUniqueTransactionsInBlock checks that a transaction is not returned twice in a block.
+func checkUniqueTransactionsInBlock(_ *api.Block, rosettaTxs []*types.Transaction) error {
+	seen := make(map[string]struct{}, len(rosettaTxs))
+
+	for _, rosettaTx := range rosettaTxs {
+		hash := rosettaTx.TransactionIdentifier.Hash
+		if _, ok := seen[hash]; ok {
+			return fmt.Errorf("transaction %s appears more than once", hash)
+		}
+
+		seen[hash] = struct{}{}
+	}
+
+	return nil
+}
+
+func holdsEventsChangingTokenSupply(tx *transaction.ApiTransactionResult) bool {
+	if tx.Logs == nil {
+		return false
+	}
+
+	for _, event := range tx.Logs.Events {
+		if _, ok := eventsChangingTokenSupply[event.Identifier]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseAmountOfOperation(operation *types.Operation) (*big.Int, error) {
+	if operation.Amount == nil || operation.Amount.Currency == nil {
+		return nil, fmt.Errorf("operation %s has no amount", operation.Type)
+	}
+
+	value, ok := big.NewInt(0).SetString(operation.Amount.Value, 10)
+	if !ok {
+		return nil, fmt.Errorf("operation %s has a bad amount: %s", operation.Type, operation.Amount.Value)
+	}
+
+	return value, nil
+}
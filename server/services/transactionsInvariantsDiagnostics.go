@@ -0,0 +1,60 @@
+package services
+
+import (
+	"sort"
+	"sync"
+)
+
+// invariantViolationStats holds the counters of the violations of a given invariant
+type invariantViolationStats struct {
+	Invariant      string `json:"invariant"`
+	NumViolations  uint64 `json:"num_violations"`
+	LastTxHash     string `json:"last_tx_hash,omitempty"`
+	LastBlockNonce uint64 `json:"last_block_nonce"`
+	LastError      string `json:"last_error"`
+}
+
+// invariantsDiagnostics counts (per invariant) the violations found while transforming blocks.
+type invariantsDiagnostics struct {
+	mutex            sync.RWMutex
+	statsByInvariant map[string]*invariantViolationStats
+}
+
+func newInvariantsDiagnostics() *invariantsDiagnostics {
+	return &invariantsDiagnostics{
+		statsByInvariant: make(map[string]*invariantViolationStats),
+	}
+}
+
+func (diagnostics *invariantsDiagnostics) recordViolation(invariant string, blockNonce uint64, txHash string, err error) {
+	diagnostics.mutex.Lock()
+	defer diagnostics.mutex.Unlock()
+
+	stats, ok := diagnostics.statsByInvariant[invariant]
+	if !ok {
+		stats = &invariantViolationStats{Invariant: invariant}
+		diagnostics.statsByInvariant[invariant] = stats
+	}
+
+	stats.NumViolations++
+	stats.LastTxHash = txHash
+	stats.LastBlockNonce = blockNonce
+	stats.LastError = err.Error()
+}
+
+// getStats returns a copy of the counters, sorted by invariant
+func (diagnostics *invariantsDiagnostics) getStats() []invariantViolationStats {
+	diagnostics.mutex.RLock()
+	defer diagnostics.mutex.RUnlock()
+
+	result := make([]invariantViolationStats, 0, len(diagnostics.statsByInvariant))
+	for _, stats := range diagnostics.statsByInvariant {
+		result = append(result, *stats)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Invariant < result[j].Invariant
+	})
+
+	return result
+}
@@ -0,0 +1,202 @@
+package services
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-core-go/data/api"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+var allInvariants = []string{
+	invariantTransfersNetToZero,
+	invariantFeeWithinGasLimit,
+	invariantSignsOfAmounts,
+	invariantUniqueTransactionsInBlock,
+}
+
+func TestValidateInvariants(t *testing.T) {
+	t.Parallel()
+
+	require.Nil(t, validateInvariants(allInvariants))
+	require.Nil(t, validateInvariants(nil))
+
+	err := validateInvariants([]string{invariantSignsOfAmounts, "signOfAmounts"})
+	require.ErrorIs(t, err, errUnknownInvariant)
+	require.ErrorContains(t, err, "signOfAmounts")
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	networkProvider.MockNetworkConfig.CheckedInvariants = []string{"unknown"}
+	_, err = NewTransactionsTransformer(networkProvider)
+	require.ErrorIs(t, err, errUnknownInvariant)
+
+	networkProvider.MockNetworkConfig.CheckedInvariants = allInvariants
+	transformer, err := NewTransactionsTransformer(networkProvider)
+	require.Nil(t, err)
+	require.Equal(t, allInvariants, transformer.invariants.getEnabledInvariants())
+}
+
+func TestInvariantsChecker_HoldOnTestBlocks(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	networkProvider.MockNetworkConfig.CheckedInvariants = allInvariants
+	networkProvider.MockNetworkConfig.ShouldUseStrictInvariantsMode = true
+	transformer := newTransactionsTransformer(networkProvider)
+	require.Equal(t, allInvariants, transformer.invariants.getEnabledInvariants())
+
+	files, err := filepath.Glob("testdata/blocks_with_*.json")
+	require.Nil(t, err)
+	require.NotEmpty(t, files)
+
+	for _, file := range files {
+		blocks, err := readTestBlocks(file)
+		require.Nil(t, err)
+
+		for _, block := range blocks {
+			_, err := transformer.transformBlockTxs(block)
+			require.Nil(t, err, file)
+		}
+	}
+
+	require.Empty(t, transformer.invariants.diagnostics.getStats())
+}
+
+func TestInvariantsChecker_CheckTransaction(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	extension := newNetworkProviderExtension(networkProvider)
+
+	newRosettaTx := func(operations ...*types.Operation) *types.Transaction {
+		return &types.Transaction{
+			TransactionIdentifier: hashToTransactionIdentifier("aaaa"),
+			Operations:            operations,
+		}
+	}
+
+	nativeOperation := func(operationType string, value string) *types.Operation {
+		return &types.Operation{
+			Type:    operationType,
+			Account: addressToAccountIdentifier(testscommon.TestAddressAlice),
+			Amount:  extension.valueToNativeAmount(value),
+		}
+	}
+
+	customOperation := func(value string) *types.Operation {
+		return &types.Operation{
+			Type:    opCustomTransfer,
+			Account: addressToAccountIdentifier(testscommon.TestAddressAlice),
+			Amount:  extension.valueToCustomAmount(value, "FOO-abcdef"),
+		}
+	}
+
+	tx := &transaction.ApiTransactionResult{
+		Type:     string(transaction.TxTypeNormal),
+		GasLimit: 50000,
+		GasPrice: 1000000000,
+	}
+
+	t.Run("transfers net to zero", func(t *testing.T) {
+		require.Nil(t, checkTransfersNetToZero(tx, newRosettaTx(
+			nativeOperation(opTransfer, "-100"),
+			nativeOperation(opTransfer, "100"),
+			nativeOperation(opScResult, "-5"),
+			nativeOperation(opScResult, "5"),
+			nativeOperation(opFee, "-50000000000000"),
+			customOperation("-7"),
+			customOperation("7"),
+		)))
+
+		err := checkTransfersNetToZero(tx, newRosettaTx(nativeOperation(opTransfer, "-100")))
+		require.ErrorContains(t, err, "transfers of XeGLD do not net to zero: -100")
+
+		err = checkTransfersNetToZero(tx, newRosettaTx(customOperation("-7")))
+		require.ErrorContains(t, err, "transfers of FOO-abcdef do not net to zero: -7")
+	})
+
+	t.Run("transfers net to zero, with events changing the supply of tokens", func(t *testing.T) {
+		txWithBurn := &transaction.ApiTransactionResult{
+			Logs: &transaction.ApiLogs{
+				Events: []*transaction.Events{{Identifier: transactionEventESDTLocalBurn}},
+			},
+		}
+
+		require.Nil(t, checkTransfersNetToZero(txWithBurn, newRosettaTx(customOperation("-7"))))
+
+		err := checkTransfersNetToZero(txWithBurn, newRosettaTx(nativeOperation(opTransfer, "-100")))
+		require.ErrorContains(t, err, "do not net to zero")
+	})
+
+	t.Run("fee within gas limit", func(t *testing.T) {
+		require.Nil(t, checkFeeWithinGasLimit(tx, newRosettaTx(nativeOperation(opFee, "-50000000000000"))))
+		require.Nil(t, checkFeeWithinGasLimit(nil, newRosettaTx(nativeOperation(opFee, "-50000000000001"))))
+
+		err := checkFeeWithinGasLimit(tx, newRosettaTx(nativeOperation(opFee, "-50000000000001")))
+		require.ErrorContains(t, err, "fee 50000000000001 exceeds gasLimit * gasPrice = 50000000000000")
+	})
+
+	t.Run("signs of amounts", func(t *testing.T) {
+		require.Nil(t, checkSignsOfAmounts(tx, newRosettaTx(
+			nativeOperation(opFee, "-1"),
+			nativeOperation(opFeeRefund, "1"),
+			nativeOperation(opReward, "1"),
+			nativeOperation(opTransfer, "-1"),
+		)))
+
+		err := checkSignsOfAmounts(tx, newRosettaTx(nativeOperation(opFee, "1")))
+		require.ErrorContains(t, err, "operation Fee has a positive amount: 1")
+
+		err = checkSignsOfAmounts(tx, newRosettaTx(nativeOperation(opFeeRefundAsScResult, "-1")))
+		require.ErrorContains(t, err, "operation FeeRefundAsSmartContractResult has a negative amount: -1")
+	})
+
+	t.Run("lenient mode", func(t *testing.T) {
+		checker := newInvariantsChecker([]string{invariantSignsOfAmounts, "unknown"}, false)
+		require.Equal(t, []string{invariantSignsOfAmounts}, checker.getEnabledInvariants())
+
+		err := checker.checkTransaction(42, tx, newRosettaTx(nativeOperation(opFee, "1")))
+		require.Nil(t, err)
+
+		require.Equal(t, []invariantViolationStats{
+			{
+				Invariant:      invariantSignsOfAmounts,
+				NumViolations:  1,
+				LastTxHash:     "aaaa",
+				LastBlockNonce: 42,
+				LastError:      "operation Fee has a positive amount: 1",
+			},
+		}, checker.diagnostics.getStats())
+	})
+
+	t.Run("strict mode", func(t *testing.T) {
+		checker := newInvariantsChecker([]string{invariantSignsOfAmounts}, true)
+
+		err := checker.checkTransaction(42, tx, newRosettaTx(nativeOperation(opFee, "1")))
+		require.ErrorIs(t, err, errInvariantViolated)
+		require.Len(t, checker.diagnostics.getStats(), 1)
+	})
+}
+
+func TestInvariantsChecker_CheckBlock(t *testing.T) {
+	t.Parallel()
+
+	checker := newInvariantsChecker([]string{invariantUniqueTransactionsInBlock}, true)
+	block := &api.Block{Nonce: 42}
+
+	err := checker.checkBlock(block, []*types.Transaction{
+		{TransactionIdentifier: hashToTransactionIdentifier("aaaa")},
+		{TransactionIdentifier: hashToTransactionIdentifier("bbbb")},
+	})
+	require.Nil(t, err)
+
+	err = checker.checkBlock(block, []*types.Transaction{
+		{TransactionIdentifier: hashToTransactionIdentifier("aaaa")},
+		{TransactionIdentifier: hashToTransactionIdentifier("aaaa")},
+	})
+	require.ErrorIs(t, err, errInvariantViolated)
+	require.ErrorContains(t, err, "transaction aaaa appears more than once")
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"time"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-proxy-go/data"
+)
+
+// transactionsSubmitter sends signed transactions to the observer, on behalf of the construction service (one by one) and of the batch submission controller.
+// As configured, it also deduplicates re-submissions, simulates the transactions before sending them, records them in the audit log and tracks them.
+type transactionsSubmitter struct {
+	provider   NetworkProvider
+	errFactory *errFactory
+	submitted  *recentSubmissions
+	auditLog   *submissionsAuditLog
+	tracker    *submittedTransactionsTracker
+}
+
+// NewTransactionsSubmitter creates a new instance of a transactionsSubmitter
+func NewTransactionsSubmitter(networkProvider NetworkProvider) *transactionsSubmitter {
+	submitter := &transactionsSubmitter{
+		provider:   networkProvider,
+		errFactory: newErrFactory(),
+	}
+
+	dedupWindowSeconds := networkProvider.GetNetworkConfig().SubmitDedupWindowSeconds
+	if dedupWindowSeconds > 0 {
+		submitter.submitted = newRecentSubmissions(time.Duration(dedupWindowSeconds) * time.Second)
+	}
+
+	return submitter
+}
+
+// UseSubmissionsAuditLog sets the audit log of submitted transactions
+func (submitter *transactionsSubmitter) UseSubmissionsAuditLog(auditLog *submissionsAuditLog) {
+	submitter.auditLog = auditLog
+}
+
+// UseSubmittedTransactionsTracker sets the tracker of submitted transactions
+func (submitter *transactionsSubmitter) UseSubmittedTransactionsTracker(tracker *submittedTransactionsTracker) {
+	submitter.tracker = tracker
+}
+
+// submit sends a signed transaction, and returns its hash
+func (submitter *transactionsSubmitter) submit(signedTransaction string) (*types.TransactionIdentifierResponse, *types.Error) {
+	if submitter.provider.IsOffline() {
+		return nil, submitter.errFactory.newErr(ErrOfflineMode)
+	}
+
+	tx, err := getTxFromRequest(signedTransaction)
+	if err != nil {
+		submitter.auditSubmission(signedTransaction, nil, "", submissionOutcomeRejected, err.Error())
+		return nil, submitter.errFactory.newErrWithOriginal(ErrMalformedValue, err)
+	}
+
+	computedHash, previousHash, isDuplicate := submitter.reserveSubmission(tx)
+	if isDuplicate {
+		log.Info("transactionsSubmitter.submit(): transaction already submitted, not broadcasting again", "hash", previousHash)
+		submitter.auditSubmission(signedTransaction, tx, previousHash, submissionOutcomeDeduplicated, "")
+		return newTransactionIdentifierResponse(previousHash), nil
+	}
+
+	if submitter.provider.GetNetworkConfig().ShouldSimulateBeforeSubmit {
+		errTyped := submitter.simulateBeforeSubmit(tx)
+		if errTyped != nil {
+			submitter.onSubmissionFailed(computedHash)
+			submitter.auditSubmission(signedTransaction, tx, computedHash, submissionOutcomeRejected, describeErrorForAudit(errTyped))
+			return nil, errTyped
+		}
+	}
+
+	txHash, err := submitter.provider.SendTransaction(tx)
+	if err != nil {
+		submitter.onSubmissionFailed(computedHash)
+		submitter.auditSubmission(signedTransaction, tx, computedHash, submissionOutcomeFailed, err.Error())
+		return nil, submitter.errFactory.newErrWithOriginal(ErrUnableToSubmitTransaction, err)
+	}
+
+	submitter.auditSubmission(signedTransaction, tx, txHash, submissionOutcomeSubmitted, txHash)
+	submitter.onSubmitted(computedHash, txHash)
+
+	return newTransactionIdentifierResponse(txHash), nil
+}
+
+// reserveSubmission computes the hash of the transaction (used for deduplication and auditing), and looks for a recent (or pending) submission of the same transaction.
+// If there's none, the transaction is reserved: the caller must call either onSubmitted() or onSubmissionFailed() afterwards.
+// If the hash cannot be computed, deduplication is skipped (the observer decides upon the transaction).
+func (submitter *transactionsSubmitter) reserveSubmission(tx *data.Transaction) (string, string, bool) {
+	computedHash, err := submitter.provider.ComputeTransactionHash(tx)
+	if err != nil {
+		log.Debug("transactionsSubmitter.reserveSubmission(): cannot compute hash", "err", err)
+		return "", "", false
+	}
+
+	if submitter.submitted == nil {
+		return computedHash, "", false
+	}
+
+	previousHash, isDuplicate := submitter.submitted.reserve(computedHash)
+	return computedHash, previousHash, isDuplicate
+}
+
+// onSubmitted is called for each transaction accepted by the observer
+func (submitter *transactionsSubmitter) onSubmitted(computedHash string, hash string) {
+	if submitter.submitted != nil && len(computedHash) > 0 {
+		submitter.submitted.confirm(computedHash, hash)
+	}
+
+	if submitter.tracker != nil {
+		submitter.tracker.track(hash)
+	}
+}
+
+// onSubmissionFailed is called for each (reserved) transaction that isn't accepted by the observer, or isn't sent at all (e.g. rejected by simulation)
+func (submitter *transactionsSubmitter) onSubmissionFailed(computedHash string) {
+	if submitter.submitted != nil && len(computedHash) > 0 {
+		submitter.submitted.release(computedHash)
+	}
+}
+
+// auditSubmission appends a record to the audit log (if configured). A failure to do so is logged, but does not affect the submission.
+func (submitter *transactionsSubmitter) auditSubmission(signedTransaction string, tx *data.Transaction, hash string, outcome string, observerResponse string) {
+	if submitter.auditLog == nil {
+		return
+	}
+
+	record := &submissionAuditRecord{
+		Hash:              hash,
+		SignedTransaction: signedTransaction,
+		Outcome:           outcome,
+		ObserverResponse:  observerResponse,
+	}
+
+	if tx != nil {
+		record.Sender = tx.Sender
+		record.Nonce = tx.Nonce
+	}
+
+	err := submitter.auditLog.append(record)
+	if err != nil {
+		log.Error("transactionsSubmitter.auditSubmission(): cannot append to audit log", "hash", hash, "outcome", outcome, "err", err)
+	}
+}
@@ -18,18 +18,54 @@ type transactionsTransformer struct {
 	extension        *networkProviderExtension
 	featuresDetector *transactionsFeaturesDetector
 	eventsController *transactionEventsController
+	eventHandlers    *transactionEventHandlersRegistry
+	invariants       *invariantsChecker
+}
+
+// NewTransactionsTransformer creates the transformer of transactions (shared by the block service and the diagnostics controllers).
+// It fails if the configuration refers to unknown invariants.
+func NewTransactionsTransformer(provider NetworkProvider) (*transactionsTransformer, error) {
+	err := validateInvariants(provider.GetNetworkConfig().CheckedInvariants)
+	if err != nil {
+		return nil, err
+	}
+
+	return newTransactionsTransformer(provider), nil
 }
 
 func newTransactionsTransformer(provider NetworkProvider) *transactionsTransformer {
-	return &transactionsTransformer{
+	transformer := &transactionsTransformer{
 		provider:         provider,
 		extension:        newNetworkProviderExtension(provider),
 		featuresDetector: newTransactionsFeaturesDetector(provider),
 		eventsController: newTransactionEventsController(provider),
+		eventHandlers:    newTransactionEventHandlersRegistry(provider.GetNetworkConfig().ShouldUseStrictEventsMode),
+		invariants:       newInvariantsChecker(provider.GetNetworkConfig().CheckedInvariants, provider.GetNetworkConfig().ShouldUseStrictInvariantsMode),
 	}
+
+	for _, handler := range transformer.createBuiltInEventHandlers() {
+		// Built-in handlers have distinct identifiers, thus registration cannot fail.
+		_ = transformer.eventHandlers.register(handler)
+		transformer.eventHandlers.markAsBalanceAffecting(handler.identifier)
+	}
+
+	transformer.eventHandlers.markAsBalanceAffecting(provider.GetNetworkConfig().BalanceAffectingEvents...)
+
+	return transformer
 }
 
+// registerEventHandler registers a custom handler of transaction events (e.g. for the events of a specific contract).
+func (transformer *transactionsTransformer) registerEventHandler(handler *transactionEventHandler) error {
+	return transformer.eventHandlers.register(handler)
+}
+
+// transformBlockTxs transforms the transactions of a block. The order is deterministic: transactions (in miniblock order, then in their order within the miniblock), then gas refunds (receipts).
 func (transformer *transactionsTransformer) transformBlockTxs(block *api.Block) ([]*types.Transaction, error) {
+	return transformer.transformBlockTxsWithTrace(block, nil)
+}
+
+// transformBlockTxsWithTrace is like transformBlockTxs, but also records (in the trace, if not nil) the rules and filters that were applied.
+func (transformer *transactionsTransformer) transformBlockTxsWithTrace(block *api.Block, trace *transformationTrace) ([]*types.Transaction, error) {
 	txs := make([]*transaction.ApiTransactionResult, 0)
 	receipts := make([]*transaction.ApiReceipt, 0)
 
@@ -44,12 +80,31 @@ func (transformer *transactionsTransformer) transformBlockTxs(block *api.Block)
 		}
 	}
 
-	txs = filterOutIntrashardContractResultsWhoseOriginalTransactionIsInInvalidMiniblock(txs)
-	txs = filterOutIntrashardRelayedTransactionAlreadyHeldInInvalidMiniblock(txs)
+	filteredTxs := filterOutIntrashardContractResultsWhoseOriginalTransactionIsInInvalidMiniblock(txs)
+	trace.recordRemovedTransactions(
+		ruleFilterOutIntrashardContractResultsWhoseOriginalTransactionIsInInvalidMiniblock,
+		"intra-shard contract results of transactions held in an invalid miniblock",
+		txs, filteredTxs,
+	)
+	txs = filteredTxs
+
+	filteredTxs = filterOutIntrashardRelayedTransactionAlreadyHeldInInvalidMiniblock(txs)
+	trace.recordRemovedTransactions(
+		ruleFilterOutIntrashardRelayedTransactionAlreadyHeldInInvalidMiniblock,
+		"intra-shard relayed transactions also held in an invalid miniblock",
+		txs, filteredTxs,
+	)
+	txs = filteredTxs
 
 	rosettaTxs := make([]*types.Transaction, 0)
 	for _, tx := range txs {
-		rosettaTx, err := transformer.txToRosettaTx(tx, txs)
+		rosettaTx, err := transformer.txToRosettaTx(tx, txs, trace)
+		if err != nil {
+			return nil, err
+		}
+
+		// Invariants are checked before filtering the operations (e.g. by address), while all the legs of the transfers are still present.
+		err = transformer.invariants.checkTransaction(block.Nonce, tx, rosettaTx)
 		if err != nil {
 			return nil, err
 		}
@@ -64,48 +119,75 @@ func (transformer *transactionsTransformer) transformBlockTxs(block *api.Block)
 				return nil, err
 			}
 
+			err = transformer.invariants.checkTransaction(block.Nonce, nil, rosettaTx)
+			if err != nil {
+				return nil, err
+			}
+
 			rosettaTxs = append(rosettaTxs, rosettaTx)
 		}
 	}
 
 	for _, rosettaTx := range rosettaTxs {
-		filteredOperations, err := filterOperationsByAddress(rosettaTx.Operations, transformer.provider.IsAddressObserved)
+		txHash := rosettaTx.TransactionIdentifier.Hash
+
+		operationsOfObservedAccounts, err := filterOperationsByAddress(rosettaTx.Operations, transformer.provider.IsAddressObserved)
 		if err != nil {
 			return nil, err
 		}
 
-		filteredOperations = filterOutOperationsWithZeroAmount(filteredOperations)
+		trace.recordRemovedOperations(ruleFilterOperationsByAddress, "operations of accounts that aren't observed", txHash, rosettaTx.Operations, operationsOfObservedAccounts)
+
+		filteredOperations := filterOutOperationsWithZeroAmount(operationsOfObservedAccounts)
+		trace.recordRemovedOperations(ruleFilterOutOperationsWithZeroAmount, "operations with zero amount", txHash, operationsOfObservedAccounts, filteredOperations)
 
 		applyDefaultStatusOnOperations(filteredOperations)
 		rosettaTx.Operations = filteredOperations
 	}
 
-	rosettaTxs = filterOutRosettaTransactionsWithNoOperations(rosettaTxs)
+	filteredRosettaTxs := filterOutRosettaTransactionsWithNoOperations(rosettaTxs)
+
+	if trace.isEnabled() {
+		for _, rosettaTx := range rosettaTxs {
+			if len(rosettaTx.Operations) == 0 {
+				trace.record(&transformationStep{
+					Rule:                ruleFilterOutRosettaTransactionsWithNoOperations,
+					Reason:              "transaction without (remaining) operations",
+					RemovedTransactions: []string{rosettaTx.TransactionIdentifier.Hash},
+				})
+			}
+		}
+	}
+
+	err := transformer.invariants.checkBlock(block, filteredRosettaTxs)
+	if err != nil {
+		return nil, err
+	}
 
-	return rosettaTxs, nil
+	return filteredRosettaTxs, nil
 }
 
-func (transformer *transactionsTransformer) txToRosettaTx(tx *transaction.ApiTransactionResult, txsInBlock []*transaction.ApiTransactionResult) (*types.Transaction, error) {
+func (transformer *transactionsTransformer) txToRosettaTx(tx *transaction.ApiTransactionResult, txsInBlock []*transaction.ApiTransactionResult, trace *transformationTrace) (*types.Transaction, error) {
 	var rosettaTx *types.Transaction
 	var err error
 
 	switch tx.Type {
 	case string(transaction.TxTypeNormal):
-		rosettaTx, err = transformer.normalTxToRosetta(tx)
+		rosettaTx, err = transformer.normalTxToRosetta(tx, trace)
 		if err != nil {
 			return nil, err
 		}
 	case string(transaction.TxTypeReward):
 		rosettaTx = transformer.rewardTxToRosettaTx(tx)
 	case string(transaction.TxTypeUnsigned):
-		rosettaTx = transformer.unsignedTxToRosettaTx(tx, txsInBlock)
+		rosettaTx = transformer.unsignedTxToRosettaTx(tx, txsInBlock, trace)
 	case string(transaction.TxTypeInvalid):
 		rosettaTx = transformer.invalidTxToRosettaTx(tx)
 	default:
 		return nil, fmt.Errorf("unknown transaction type: %s", tx.Type)
 	}
 
-	err = transformer.addOperationsGivenTransactionEvents(tx, rosettaTx)
+	err = transformer.addOperationsGivenTransactionEvents(tx, rosettaTx, trace)
 	if err != nil {
 		return nil, err
 	}
@@ -116,10 +198,17 @@ func (transformer *transactionsTransformer) txToRosettaTx(tx *transaction.ApiTra
 func (transformer *transactionsTransformer) unsignedTxToRosettaTx(
 	scr *transaction.ApiTransactionResult,
 	txsInBlock []*transaction.ApiTransactionResult,
+	trace *transformationTrace,
 ) *types.Transaction {
 	if transformer.featuresDetector.isSmartContractResultIneffectiveRefund(scr) {
 		log.Debug("unsignedTxToRosettaTx: ineffective refund", "hash", scr.Hash, "block", scr.BlockNonce)
 
+		trace.record(&transformationStep{
+			Rule:            ruleIneffectiveRefund,
+			Reason:          "contract result is an ineffective refund (its value is already accounted for), thus no operations are emitted",
+			TransactionHash: scr.Hash,
+		})
+
 		return &types.Transaction{
 			TransactionIdentifier: hashToTransactionIdentifier(scr.Hash),
 			Operations:            []*types.Operation{},
@@ -145,6 +234,12 @@ func (transformer *transactionsTransformer) unsignedTxToRosettaTx(
 	if !transformer.areClaimDeveloperRewardsEventsEnabled(scr.Epoch) {
 		// Handle developer rewards in a legacy manner (without looking at events / logs)
 		if transformer.featuresDetector.doesContractResultHoldRewardsOfClaimDeveloperRewards(scr, txsInBlock) {
+			trace.record(&transformationStep{
+				Rule:            ruleLegacyDeveloperRewards,
+				Reason:          "contract result holds developer rewards (before Spica, without looking at events)",
+				TransactionHash: scr.Hash,
+			})
+
 			return &types.Transaction{
 				TransactionIdentifier: hashToTransactionIdentifier(scr.Hash),
 				Operations: []*types.Operation{
@@ -189,18 +284,26 @@ func (transformer *transactionsTransformer) rewardTxToRosettaTx(tx *transaction.
 	}
 }
 
-func (transformer *transactionsTransformer) normalTxToRosetta(tx *transaction.ApiTransactionResult) (*types.Transaction, error) {
+func (transformer *transactionsTransformer) normalTxToRosetta(tx *transaction.ApiTransactionResult, trace *transformationTrace) (*types.Transaction, error) {
 	operations := make([]*types.Operation, 0)
 
 	transfersValue := isNonZeroAmount(tx.Value)
 
-	if transformer.provider.IsReleaseSiriusActive(tx.Epoch) {
+	if transfersValue && transformer.provider.IsReleaseSiriusActive(tx.Epoch) {
 		// Special handling of:
 		// - intra-shard contract calls, bearing value, which fail with signal error
 		// - direct contract deployments, bearing value, which fail with signal error
 		// For these, the protocol does not generate an explicit SCR with the value refund (before Sirius, in some cases, it did).
 		// However, since the value remains at the sender, we don't emit any operations in these circumstances.
-		transfersValue = transfersValue && !transformer.featuresDetector.isContractDeploymentWithSignalErrorOrIntrashardContractCallWithSignalError(tx)
+		if transformer.featuresDetector.isContractDeploymentWithSignalErrorOrIntrashardContractCallWithSignalError(tx) {
+			transfersValue = false
+
+			trace.record(&transformationStep{
+				Rule:            ruleValueNotTransferredDueToSignalError,
+				Reason:          "contract deployment or intra-shard contract call failed with signal error, thus the value remains at the sender",
+				TransactionHash: tx.Hash,
+			})
+		}
 	}
 
 	if transfersValue {
@@ -231,6 +334,15 @@ func (transformer *transactionsTransformer) normalTxToRosetta(tx *transaction.Ap
 
 	operations = append(operations, innerTxOperationsIfRelayedCompletelyIntrashardWithSignalError...)
 
+	if len(innerTxOperationsIfRelayedCompletelyIntrashardWithSignalError) > 0 {
+		trace.record(&transformationStep{
+			Rule:            ruleInnerTxOperationsOfRelayedBeforeSirius,
+			Reason:          "relayed transaction (before Sirius), completely intra-shard, with signal error: the inner transaction operations are added, to offset the value refund",
+			TransactionHash: tx.Hash,
+			AddedOperations: innerTxOperationsIfRelayedCompletelyIntrashardWithSignalError,
+		})
+	}
+
 	return &types.Transaction{
 		TransactionIdentifier: hashToTransactionIdentifier(tx.Hash),
 		Operations:            operations,
@@ -381,225 +493,227 @@ func (transformer *transactionsTransformer) mempoolMoveBalanceTxToRosettaTx(tx *
 	}
 }
 
-func (transformer *transactionsTransformer) addOperationsGivenTransactionEvents(tx *transaction.ApiTransactionResult, rosettaTx *types.Transaction) error {
+func (transformer *transactionsTransformer) addOperationsGivenTransactionEvents(tx *transaction.ApiTransactionResult, rosettaTx *types.Transaction, trace *transformationTrace) error {
 	hasSignalError := transformer.featuresDetector.eventsController.hasAnySignalError(tx)
 	if hasSignalError {
+		trace.record(&transformationStep{
+			Rule:            ruleEventsIgnoredDueToSignalError,
+			Reason:          "transaction has a signal error, thus its events are ignored",
+			TransactionHash: tx.Hash,
+		})
+
 		return nil
 	}
 
-	eventsSCDeploy, err := transformer.eventsController.extractEventSCDeploy(tx)
+	operations, err := transformer.eventHandlers.produceOperations(tx)
 	if err != nil {
 		return err
 	}
 
-	eventsTransferValueOnly, err := transformer.eventsController.extractEventTransferValueOnly(tx)
-	if err != nil {
-		return err
+	if len(operations) > 0 {
+		trace.record(&transformationStep{
+			Rule:            ruleOperationsFromEvents,
+			Reason:          "operations recovered from the events of the transaction",
+			TransactionHash: tx.Hash,
+			AddedOperations: operations,
+		})
 	}
 
-	eventsESDTTransfer, err := transformer.eventsController.extractEventsESDTOrESDTNFTTransfers(tx)
-	if err != nil {
-		return err
-	}
+	rosettaTx.Operations = append(rosettaTx.Operations, operations...)
+	return nil
+}
 
-	eventsESDTLocalBurn, err := transformer.eventsController.extractEventsESDTLocalBurn(tx)
-	if err != nil {
-		return err
+// createBuiltInEventHandlers creates the handlers of the events emitted by the protocol. The order of the handlers dictates the order of the operations.
+func (transformer *transactionsTransformer) createBuiltInEventHandlers() []*transactionEventHandler {
+	return []*transactionEventHandler{
+		{
+			identifier: transactionEventSCDeploy,
+			topics:     topicsOfEventSCDeploy,
+			handle:     transformer.handleEventSCDeploy,
+		},
+		{
+			identifier: transactionEventTransferValueOnly,
+			topics:     topicsOfEventTransferValueOnly,
+			isActive:   transformer.provider.IsReleaseSiriusActive,
+			handle:     transformer.handleEventTransferValueOnly,
+		},
+		{
+			identifier: transactionEventESDTTransfer,
+			topics:     topicsOfEventESDTTransfer,
+			handle:     transformer.handleEventESDTOrESDTNFTTransfer,
+		},
+		{
+			identifier: transactionEventESDTNFTTransfer,
+			topics:     topicsOfEventESDTTransfer,
+			handle:     transformer.handleEventESDTOrESDTNFTTransfer,
+		},
+		{
+			identifier: transactionEventMultiESDTNFTTransfer,
+			topics:     topicsOfEventMultiESDTNFTTransfer,
+			handle:     transformer.handleEventMultiESDTNFTTransfer,
+		},
+		{
+			identifier: transactionEventESDTLocalBurn,
+			topics:     topicsOfEventESDTLocalBurn,
+			handle:     transformer.newHandlerOfEventESDTOfEmitter(true),
+		},
+		{
+			identifier: transactionEventESDTLocalMint,
+			topics:     topicsOfEventESDTLocalMint,
+			handle:     transformer.newHandlerOfEventESDTOfEmitter(false),
+		},
+		{
+			identifier: transactionEventESDTWipe,
+			topics:     topicsOfEventESDTWipe,
+			handle:     transformer.handleEventESDTWipe,
+		},
+		{
+			identifier: transactionEventESDTNFTCreate,
+			topics:     topicsOfEventESDTNFTCreate,
+			handle:     transformer.newHandlerOfEventESDTOfEmitter(false),
+		},
+		{
+			identifier: transactionEventESDTNFTBurn,
+			topics:     topicsOfEventESDTNFTBurn,
+			handle:     transformer.newHandlerOfEventESDTOfEmitter(true),
+		},
+		{
+			identifier: transactionEventESDTNFTAddQuantity,
+			topics:     topicsOfEventESDTNFTAddQuantity,
+			handle:     transformer.newHandlerOfEventESDTOfEmitter(false),
+		},
+		{
+			identifier: transactionEventClaimDeveloperRewards,
+			topics:     topicsOfEventClaimDeveloperRewards,
+			isActive:   transformer.areClaimDeveloperRewardsEventsEnabled,
+			handle:     transformer.handleEventClaimDeveloperRewards,
+		},
 	}
+}
 
-	eventsESDTLocalMint, err := transformer.eventsController.extractEventsESDTLocalMint(tx)
-	if err != nil {
-		return err
+func (transformer *transactionsTransformer) handleEventSCDeploy(tx *transaction.ApiTransactionResult, event *transaction.Events) ([]*types.Operation, error) {
+	// Handle direct deployments with transfer of value (indirect deployments are currently excluded to prevent any potential misinterpretations).
+	if tx.Receiver != systemContractDeployAddress {
+		return nil, nil
 	}
 
-	eventsESDTWipe, err := transformer.eventsController.extractEventsESDTWipe(tx)
-	if err != nil {
-		return err
-	}
+	typedEvent := transformer.eventsController.decodeEventSCDeploy(event)
 
-	eventsESDTNFTCreate, err := transformer.eventsController.extractEventsESDTNFTCreate(tx)
-	if err != nil {
-		return err
-	}
+	return []*types.Operation{
+		// Deployer's balance change is already captured in operations recovered not from logs / events, but from the transaction itself.
+		// It remains to "simulate" the transfer from the system deployment address to the contract address.
+		{
+			Type:    opTransfer,
+			Account: addressToAccountIdentifier(tx.Receiver),
+			Amount:  transformer.extension.valueToNativeAmount("-" + tx.Value),
+		},
+		{
+			Type:    opTransfer,
+			Account: addressToAccountIdentifier(typedEvent.contractAddress),
+			Amount:  transformer.extension.valueToNativeAmount(tx.Value),
+		},
+	}, nil
+}
 
-	eventsESDTNFTBurn, err := transformer.eventsController.extractEventsESDTNFTBurn(tx)
+func (transformer *transactionsTransformer) handleEventTransferValueOnly(tx *transaction.ApiTransactionResult, event *transaction.Events) ([]*types.Operation, error) {
+	typedEvent, err := transformer.eventsController.decodeEventTransferValueOnly(event)
 	if err != nil {
-		return err
+		return nil, err
 	}
-
-	eventsESDTNFTAddQuantity, err := transformer.eventsController.extractEventsESDTNFTAddQuantity(tx)
-	if err != nil {
-		return err
+	if typedEvent == nil {
+		return nil, nil
 	}
 
-	eventsClaimDeveloperRewards, err := transformer.eventsController.extractEventsClaimDeveloperRewards(tx)
-	if err != nil {
-		return err
-	}
+	log.Debug("eventTransferValueOnly (effective)", "tx", tx.Hash, "block", tx.BlockNonce)
 
-	for _, event := range eventsSCDeploy {
-		// Handle direct deployments with transfer of value (indirect deployments are currently excluded to prevent any potential misinterpretations).
-		if tx.Receiver == systemContractDeployAddress {
-			operations := []*types.Operation{
-				// Deployer's balance change is already captured in operations recovered not from logs / events, but from the transaction itself.
-				// It remains to "simulate" the transfer from the system deployment address to the contract address.
-				{
-					Type:    opTransfer,
-					Account: addressToAccountIdentifier(tx.Receiver),
-					Amount:  transformer.extension.valueToNativeAmount("-" + tx.Value),
-				},
-				{
-					Type:    opTransfer,
-					Account: addressToAccountIdentifier(event.contractAddress),
-					Amount:  transformer.extension.valueToNativeAmount(tx.Value),
-				},
-			}
+	return []*types.Operation{
+		{
+			Type:    opTransfer,
+			Account: addressToAccountIdentifier(typedEvent.sender),
+			Amount:  transformer.extension.valueToNativeAmount("-" + typedEvent.value),
+		},
+		{
+			Type:    opTransfer,
+			Account: addressToAccountIdentifier(typedEvent.receiver),
+			Amount:  transformer.extension.valueToNativeAmount(typedEvent.value),
+		},
+	}, nil
+}
 
-			rosettaTx.Operations = append(rosettaTx.Operations, operations...)
-		}
+func (transformer *transactionsTransformer) handleEventESDTOrESDTNFTTransfer(_ *transaction.ApiTransactionResult, event *transaction.Events) ([]*types.Operation, error) {
+	typedEvent, err := transformer.eventsController.decodeEventESDTOrESDTNFTTransfer(event)
+	if err != nil {
+		return nil, err
 	}
 
-	for _, event := range eventsTransferValueOnly {
-		log.Debug("eventTransferValueOnly (effective)", "tx", tx.Hash, "block", tx.BlockNonce)
-
-		operations := []*types.Operation{
-			{
-				Type:    opTransfer,
-				Account: addressToAccountIdentifier(event.sender),
-				Amount:  transformer.extension.valueToNativeAmount("-" + event.value),
-			},
-			{
-				Type:    opTransfer,
-				Account: addressToAccountIdentifier(event.receiver),
-				Amount:  transformer.extension.valueToNativeAmount(event.value),
-			},
-		}
-
-		rosettaTx.Operations = append(rosettaTx.Operations, operations...)
-	}
+	return transformer.extractOperationsFromEventESDT(typedEvent), nil
+}
 
-	for _, event := range eventsESDTTransfer {
-		operations := transformer.extractOperationsFromEventESDT(event)
-		rosettaTx.Operations = append(rosettaTx.Operations, operations...)
+func (transformer *transactionsTransformer) handleEventMultiESDTNFTTransfer(_ *transaction.ApiTransactionResult, event *transaction.Events) ([]*types.Operation, error) {
+	typedEvents, err := transformer.eventsController.decodeEventMultiESDTNFTTransfer(event)
+	if err != nil {
+		return nil, err
 	}
 
-	for _, event := range eventsESDTLocalBurn {
-		if !transformer.provider.HasCustomCurrency(event.identifier) {
-			// We are only emitting balance-changing operations for supported currencies.
-			continue
-		}
-
-		operations := []*types.Operation{
-			{
-				Type:    opCustomTransfer,
-				Account: addressToAccountIdentifier(event.otherAddress),
-				Amount:  transformer.extension.valueToCustomAmount("-"+event.value, event.getExtendedIdentifier()),
-			},
-		}
-
-		rosettaTx.Operations = append(rosettaTx.Operations, operations...)
+	operations := make([]*types.Operation, 0)
+	for _, typedEvent := range typedEvents {
+		operations = append(operations, transformer.extractOperationsFromEventESDT(typedEvent)...)
 	}
 
-	for _, event := range eventsESDTLocalMint {
-		if !transformer.provider.HasCustomCurrency(event.identifier) {
-			// We are only emitting balance-changing operations for supported currencies.
-			continue
-		}
+	return operations, nil
+}
 
-		operations := []*types.Operation{
-			{
-				Type:    opCustomTransfer,
-				Account: addressToAccountIdentifier(event.otherAddress),
-				Amount:  transformer.extension.valueToCustomAmount(event.value, event.getExtendedIdentifier()),
-			},
+// newHandlerOfEventESDTOfEmitter creates a handler for events that mint (or burn, if "isDecrease" is set) tokens of the emitter.
+func (transformer *transactionsTransformer) newHandlerOfEventESDTOfEmitter(isDecrease bool) func(*transaction.ApiTransactionResult, *transaction.Events) ([]*types.Operation, error) {
+	return func(_ *transaction.ApiTransactionResult, event *transaction.Events) ([]*types.Operation, error) {
+		typedEvent, err := transformer.eventsController.decodeEventESDTOfEmitter(event)
+		if err != nil {
+			return nil, err
 		}
 
-		rosettaTx.Operations = append(rosettaTx.Operations, operations...)
+		return transformer.extractCustomTransferOperationFromEventESDT(typedEvent, isDecrease), nil
 	}
+}
 
-	for _, event := range eventsESDTWipe {
-		if !transformer.provider.HasCustomCurrency(event.identifier) {
-			// We are only emitting balance-changing operations for supported currencies.
-			continue
-		}
-
-		operations := []*types.Operation{
-			{
-				Type:    opCustomTransfer,
-				Account: addressToAccountIdentifier(event.otherAddress),
-				Amount:  transformer.extension.valueToCustomAmount("-"+event.value, event.getExtendedIdentifier()),
-			},
-		}
-
-		rosettaTx.Operations = append(rosettaTx.Operations, operations...)
+func (transformer *transactionsTransformer) handleEventESDTWipe(_ *transaction.ApiTransactionResult, event *transaction.Events) ([]*types.Operation, error) {
+	typedEvent, err := transformer.eventsController.decodeEventESDTWipe(event)
+	if err != nil {
+		return nil, err
 	}
 
-	for _, event := range eventsESDTNFTCreate {
-		if !transformer.provider.HasCustomCurrency(event.identifier) {
-			// We are only emitting balance-changing operations for supported currencies.
-			continue
-		}
-
-		operations := []*types.Operation{
-			{
-				Type:    opCustomTransfer,
-				Account: addressToAccountIdentifier(event.otherAddress),
-				Amount:  transformer.extension.valueToCustomAmount(event.value, event.getExtendedIdentifier()),
-			},
-		}
+	return transformer.extractCustomTransferOperationFromEventESDT(typedEvent, true), nil
+}
 
-		rosettaTx.Operations = append(rosettaTx.Operations, operations...)
+func (transformer *transactionsTransformer) extractCustomTransferOperationFromEventESDT(event *eventESDT, isDecrease bool) []*types.Operation {
+	if !transformer.provider.HasCustomCurrency(event.identifier) {
+		// We are only emitting balance-changing operations for supported currencies.
+		return nil
 	}
 
-	for _, event := range eventsESDTNFTBurn {
-		if !transformer.provider.HasCustomCurrency(event.identifier) {
-			// We are only emitting balance-changing operations for supported currencies.
-			continue
-		}
-
-		operations := []*types.Operation{
-			{
-				Type:    opCustomTransfer,
-				Account: addressToAccountIdentifier(event.otherAddress),
-				Amount:  transformer.extension.valueToCustomAmount("-"+event.value, event.getExtendedIdentifier()),
-			},
-		}
-
-		rosettaTx.Operations = append(rosettaTx.Operations, operations...)
+	value := event.value
+	if isDecrease {
+		value = "-" + value
 	}
 
-	for _, event := range eventsESDTNFTAddQuantity {
-		if !transformer.provider.HasCustomCurrency(event.identifier) {
-			// We are only emitting balance-changing operations for supported currencies.
-			continue
-		}
-
-		operations := []*types.Operation{
-			{
-				Type:    opCustomTransfer,
-				Account: addressToAccountIdentifier(event.otherAddress),
-				Amount:  transformer.extension.valueToCustomAmount(event.value, event.getExtendedIdentifier()),
-			},
-		}
-
-		rosettaTx.Operations = append(rosettaTx.Operations, operations...)
+	return []*types.Operation{
+		{
+			Type:    opCustomTransfer,
+			Account: addressToAccountIdentifier(event.otherAddress),
+			Amount:  transformer.extension.valueToCustomAmount(value, event.getExtendedIdentifier()),
+		},
 	}
+}
 
-	if transformer.areClaimDeveloperRewardsEventsEnabled(tx.Epoch) {
-		for _, event := range eventsClaimDeveloperRewards {
-			operations := []*types.Operation{
-				{
-					Type:    opDeveloperRewards,
-					Account: addressToAccountIdentifier(event.receiverAddress),
-					Amount:  transformer.extension.valueToNativeAmount(event.value),
-				},
-			}
-
-			rosettaTx.Operations = append(rosettaTx.Operations, operations...)
-		}
-	}
+func (transformer *transactionsTransformer) handleEventClaimDeveloperRewards(_ *transaction.ApiTransactionResult, event *transaction.Events) ([]*types.Operation, error) {
+	typedEvent := transformer.eventsController.decodeEventClaimDeveloperRewards(event)
 
-	return nil
+	return []*types.Operation{
+		{
+			Type:    opDeveloperRewards,
+			Account: addressToAccountIdentifier(typedEvent.receiverAddress),
+			Amount:  transformer.extension.valueToNativeAmount(typedEvent.value),
+		},
+	}, nil
 }
 
 func (transformer *transactionsTransformer) extractOperationsFromEventESDT(event *eventESDT) []*types.Operation {
@@ -52,7 +52,7 @@ func TestTransactionsTransformer_NormalTxToRosettaTx(t *testing.T) {
 			Metadata: extractTransactionMetadata(tx),
 		}
 
-		rosettaTx, err := transformer.normalTxToRosetta(tx)
+		rosettaTx, err := transformer.normalTxToRosetta(tx, nil)
 		require.NoError(t, err)
 		require.Equal(t, expectedRosettaTx, rosettaTx)
 	})
@@ -88,7 +88,7 @@ func TestTransactionsTransformer_NormalTxToRosettaTx(t *testing.T) {
 			Metadata: extractTransactionMetadata(tx),
 		}
 
-		rosettaTx, err := transformer.normalTxToRosetta(tx)
+		rosettaTx, err := transformer.normalTxToRosetta(tx, nil)
 		require.NoError(t, err)
 		require.Equal(t, expectedRosettaTx, rosettaTx)
 	})
@@ -126,7 +126,7 @@ func TestTransactionsTransformer_NormalTxToRosettaTx(t *testing.T) {
 			Metadata: extractTransactionMetadata(tx),
 		}
 
-		rosettaTx, err := transformer.normalTxToRosetta(tx)
+		rosettaTx, err := transformer.normalTxToRosetta(tx, nil)
 		require.NoError(t, err)
 		require.Equal(t, expectedRosettaTx, rosettaTx)
 	})
@@ -176,7 +176,7 @@ func TestTransactionsTransformer_NormalTxToRosettaTx(t *testing.T) {
 			Metadata: extractTransactionMetadata(tx),
 		}
 
-		rosettaTx, err := transformer.normalTxToRosetta(tx)
+		rosettaTx, err := transformer.normalTxToRosetta(tx, nil)
 		require.NoError(t, err)
 		require.Equal(t, expectedRosettaTx, rosettaTx)
 	})
@@ -216,7 +216,7 @@ func TestTransactionsTransformer_NormalTxToRosettaTx(t *testing.T) {
 			Metadata: extractTransactionMetadata(tx),
 		}
 
-		rosettaTx, err := transformer.normalTxToRosetta(tx)
+		rosettaTx, err := transformer.normalTxToRosetta(tx, nil)
 		require.NoError(t, err)
 		require.Equal(t, expectedRosettaTx, rosettaTx)
 	})
@@ -255,7 +255,7 @@ func TestTransactionsTransformer_NormalTxToRosettaTx(t *testing.T) {
 			Metadata: extractTransactionMetadata(tx),
 		}
 
-		rosettaTx, err := transformer.normalTxToRosetta(tx)
+		rosettaTx, err := transformer.normalTxToRosetta(tx, nil)
 		require.NoError(t, err)
 		require.Equal(t, expectedRosettaTx, rosettaTx)
 	})
@@ -400,7 +400,7 @@ func TestTransactionsTransformer_UnsignedTxToRosettaTx(t *testing.T) {
 			},
 		}
 
-		rosettaTx := transformer.unsignedTxToRosettaTx(tx, nil)
+		rosettaTx := transformer.unsignedTxToRosettaTx(tx, nil, nil)
 		require.Equal(t, expectedTx, rosettaTx)
 	})
 
@@ -429,7 +429,7 @@ func TestTransactionsTransformer_UnsignedTxToRosettaTx(t *testing.T) {
 			Metadata: extractTransactionMetadata(tx),
 		}
 
-		rosettaTx := transformer.unsignedTxToRosettaTx(tx, []*transaction.ApiTransactionResult{tx})
+		rosettaTx := transformer.unsignedTxToRosettaTx(tx, []*transaction.ApiTransactionResult{tx}, nil)
 		require.Equal(t, expectedTx, rosettaTx)
 	})
 
@@ -448,7 +448,7 @@ func TestTransactionsTransformer_UnsignedTxToRosettaTx(t *testing.T) {
 			Metadata:              nil,
 		}
 
-		rosettaTx := transformer.unsignedTxToRosettaTx(tx, []*transaction.ApiTransactionResult{tx})
+		rosettaTx := transformer.unsignedTxToRosettaTx(tx, []*transaction.ApiTransactionResult{tx}, nil)
 		require.Equal(t, expectedTx, rosettaTx)
 	})
 }
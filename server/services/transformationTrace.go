@@ -0,0 +1,171 @@
+package services
+
+import (
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-core-go/data/api"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+)
+
+// Rules (and filters) applied while transforming the transactions of a block, as recorded in a transformation trace.
+const (
+	ruleSimplifyBlockWithScheduledTransactions                                         = "simplifyBlockWithScheduledTransactions"
+	ruleFilterOutIntrashardContractResultsWhoseOriginalTransactionIsInInvalidMiniblock = "filterOutIntrashardContractResultsWhoseOriginalTransactionIsInInvalidMiniblock"
+	ruleFilterOutIntrashardRelayedTransactionAlreadyHeldInInvalidMiniblock             = "filterOutIntrashardRelayedTransactionAlreadyHeldInInvalidMiniblock"
+	ruleIneffectiveRefund                                                              = "ineffectiveRefund"
+	ruleLegacyDeveloperRewards                                                         = "legacyDeveloperRewards"
+	ruleValueNotTransferredDueToSignalError                                            = "valueNotTransferredDueToSignalError"
+	ruleInnerTxOperationsOfRelayedBeforeSirius                                         = "innerTxOperationsOfRelayedBeforeSirius"
+	ruleEventsIgnoredDueToSignalError                                                  = "eventsIgnoredDueToSignalError"
+	ruleOperationsFromEvents                                                           = "operationsFromEvents"
+	ruleFilterOperationsByAddress                                                      = "filterOperationsByAddress"
+	ruleFilterOutOperationsWithZeroAmount                                              = "filterOutOperationsWithZeroAmount"
+	ruleFilterOutRosettaTransactionsWithNoOperations                                   = "filterOutRosettaTransactionsWithNoOperations"
+)
+
+// transformationStep describes a rule (or a filter) that changed the outcome of the transformation
+type transformationStep struct {
+	Rule                string             `json:"rule"`
+	Reason              string             `json:"reason"`
+	TransactionHash     string             `json:"transaction_hash,omitempty"`
+	RemovedTransactions []string           `json:"removed_transactions,omitempty"`
+	AddedTransactions   []string           `json:"added_transactions,omitempty"`
+	RemovedOperations   []*types.Operation `json:"removed_operations,omitempty"`
+	AddedOperations     []*types.Operation `json:"added_operations,omitempty"`
+}
+
+// transformationTrace records the steps of the transformation of a block. A nil trace records nothing.
+type transformationTrace struct {
+	steps []*transformationStep
+}
+
+func newTransformationTrace() *transformationTrace {
+	return &transformationTrace{
+		steps: make([]*transformationStep, 0),
+	}
+}
+
+func (trace *transformationTrace) isEnabled() bool {
+	return trace != nil
+}
+
+func (trace *transformationTrace) record(step *transformationStep) {
+	if trace == nil {
+		return
+	}
+
+	// Operations are copied, since they might be mutated (e.g. re-indexed) by subsequent steps.
+	step.RemovedOperations = copyOperations(step.RemovedOperations)
+	step.AddedOperations = copyOperations(step.AddedOperations)
+	trace.steps = append(trace.steps, step)
+}
+
+// recordRemovedTransactions records a step if the filter removed any transactions
+func (trace *transformationTrace) recordRemovedTransactions(rule string, reason string, before []*transaction.ApiTransactionResult, after []*transaction.ApiTransactionResult) {
+	if trace == nil || len(before) == len(after) {
+		return
+	}
+
+	kept := make(map[*transaction.ApiTransactionResult]struct{}, len(after))
+	for _, tx := range after {
+		kept[tx] = struct{}{}
+	}
+
+	removed := make([]string, 0, len(before)-len(after))
+	for _, tx := range before {
+		if _, ok := kept[tx]; !ok {
+			removed = append(removed, tx.Hash)
+		}
+	}
+
+	trace.record(&transformationStep{
+		Rule:                rule,
+		Reason:              reason,
+		RemovedTransactions: removed,
+	})
+}
+
+// recordRemovedOperations records a step if the filter removed any operations (of a given transaction)
+func (trace *transformationTrace) recordRemovedOperations(rule string, reason string, txHash string, before []*types.Operation, after []*types.Operation) {
+	if trace == nil || len(before) == len(after) {
+		return
+	}
+
+	kept := make(map[*types.Operation]struct{}, len(after))
+	for _, operation := range after {
+		kept[operation] = struct{}{}
+	}
+
+	removed := make([]*types.Operation, 0, len(before)-len(after))
+	for _, operation := range before {
+		if _, ok := kept[operation]; !ok {
+			removed = append(removed, operation)
+		}
+	}
+
+	trace.record(&transformationStep{
+		Rule:              rule,
+		Reason:            reason,
+		TransactionHash:   txHash,
+		RemovedOperations: removed,
+	})
+}
+
+// recordSimplificationOfBlock records the transactions removed (e.g. scheduled, thus moved to the next block) or added (e.g. scheduled, taken from the previous block) by the simplification of the block
+func (trace *transformationTrace) recordSimplificationOfBlock(rawBlock *api.Block, effectiveTxs []*transaction.ApiTransactionResult) {
+	if trace == nil {
+		return
+	}
+
+	rawHashes := make(map[string]struct{})
+	effectiveHashes := make(map[string]struct{})
+	removed := make([]string, 0)
+	added := make([]string, 0)
+
+	for _, tx := range effectiveTxs {
+		effectiveHashes[tx.Hash] = struct{}{}
+	}
+
+	for _, tx := range gatherTransactionsOfBlock(rawBlock) {
+		rawHashes[tx.Hash] = struct{}{}
+
+		if _, ok := effectiveHashes[tx.Hash]; !ok {
+			removed = append(removed, tx.Hash)
+		}
+	}
+
+	for _, tx := range effectiveTxs {
+		if _, ok := rawHashes[tx.Hash]; !ok {
+			added = append(added, tx.Hash)
+		}
+	}
+
+	if len(removed) == 0 && len(added) == 0 {
+		return
+	}
+
+	trace.record(&transformationStep{
+		Rule:                ruleSimplifyBlockWithScheduledTransactions,
+		Reason:              "scheduled miniblocks and their results are accounted in the block where they are executed",
+		RemovedTransactions: removed,
+		AddedTransactions:   added,
+	})
+}
+
+func copyOperations(operations []*types.Operation) []*types.Operation {
+	if len(operations) == 0 {
+		return nil
+	}
+
+	result := make([]*types.Operation, 0, len(operations))
+	for _, operation := range operations {
+		operationCopy := *operation
+		if operation.OperationIdentifier != nil {
+			identifierCopy := *operation.OperationIdentifier
+			operationCopy.OperationIdentifier = &identifierCopy
+		}
+
+		result = append(result, &operationCopy)
+	}
+
+	return result
+}
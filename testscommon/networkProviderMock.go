@@ -38,16 +38,29 @@ type networkProviderMock struct {
 	MockNodeStatus                  *resources.AggregatedNodeStatus
 	MockBlocksByNonce               map[uint64]*api.Block
 	MockBlocksByHash                map[string]*api.Block
+	MockRawBlocksByNonce            map[uint64]*api.Block
 	MockNextAccountBlockCoordinates *resources.BlockCoordinates
 	MockAccountsByAddress           map[string]*resources.Account
 	MockAccountsNativeBalances      map[string]*resources.AccountBalanceOnBlock
 	MockAccountsCustomBalances      map[string]*resources.AccountBalanceOnBlock
+	MockAccountsGuardianData        map[string]*api.GuardianData
 	MockMempoolTransactionsByHash   map[string]*transaction.ApiTransactionResult
+	MockTransactionsByHash          map[string]*transaction.ApiTransactionResult
+	MockProcessedStatusesByHash     map[string]transaction.TxStatus
+	MockMempoolNoncesBySender       map[string]uint64
+	MockAddressesByUsername         map[string]string
 	MockComputedTransactionHash     string
 	MockComputedReceiptHash         string
+	MockTransactionCostGasUnits     uint64
+	MockSimulationResults           *transaction.SimulationResults
 	MockNextError                   error
 
-	SendTransactionCalled func(tx *data.Transaction) (string, error)
+	SendTransactionCalled        func(tx *data.Transaction) (string, error)
+	SendTransactionsCalled       func(txs []*data.Transaction) (map[int]string, error)
+	ComputeTransactionCostCalled func(tx *data.Transaction) (*resources.TransactionCost, error)
+	SimulateTransactionCalled    func(tx *data.Transaction) (*transaction.SimulationResults, error)
+	GetAccountBalanceCalled      func(address string, tokenIdentifier string, options resources.AccountQueryOptions) (*resources.AccountBalanceOnBlock, error)
+	GetAccountGuardianDataCalled func(address string) (*resources.AccountGuardianDataOnBlock, error)
 }
 
 // NewNetworkProviderMock -
@@ -74,8 +87,17 @@ func NewNetworkProviderMock() *networkProviderMock {
 			GasPerDataByte:           1500,
 			GasPriceModifier:         0.01,
 			GasLimitCustomTransfer:   200000,
+			GasLimitNFTTransfer:      1000000,
 			ExtraGasLimitGuardedTx:   50000,
 			ExtraGasLimitRelayedTxV3: 50000,
+			DelegationGasLimits: resources.DelegationGasLimits{
+				Delegate:                 12000000,
+				Undelegate:               12000000,
+				ClaimRewards:             6000000,
+				Withdraw:                 12000000,
+				ReDelegateRewards:        12000000,
+				CreateDelegationContract: 60000000,
+			},
 		},
 		MockGenesisBalances: make([]*resources.GenesisBalance, 0),
 		MockNodeStatus: &resources.AggregatedNodeStatus{
@@ -93,8 +115,9 @@ func NewNetworkProviderMock() *networkProviderMock {
 				Timestamp:         genesisTimestamp,
 			},
 		},
-		MockBlocksByNonce: make(map[uint64]*api.Block),
-		MockBlocksByHash:  make(map[string]*api.Block),
+		MockBlocksByNonce:    make(map[uint64]*api.Block),
+		MockBlocksByHash:     make(map[string]*api.Block),
+		MockRawBlocksByNonce: make(map[uint64]*api.Block),
 		MockNextAccountBlockCoordinates: &resources.BlockCoordinates{
 			Nonce: 0,
 			Hash:  emptyHash,
@@ -102,7 +125,12 @@ func NewNetworkProviderMock() *networkProviderMock {
 		MockAccountsByAddress:         make(map[string]*resources.Account),
 		MockAccountsNativeBalances:    make(map[string]*resources.AccountBalanceOnBlock),
 		MockAccountsCustomBalances:    make(map[string]*resources.AccountBalanceOnBlock),
+		MockAccountsGuardianData:      make(map[string]*api.GuardianData),
 		MockMempoolTransactionsByHash: make(map[string]*transaction.ApiTransactionResult),
+		MockTransactionsByHash:        make(map[string]*transaction.ApiTransactionResult),
+		MockProcessedStatusesByHash:   make(map[string]transaction.TxStatus),
+		MockMempoolNoncesBySender:     make(map[string]uint64),
+		MockAddressesByUsername:       make(map[string]string),
 		MockComputedTransactionHash:   emptyHash,
 		MockNextError:                 nil,
 	}
@@ -199,6 +227,20 @@ func (mock *networkProviderMock) GetBlockByNonce(nonce uint64) (*api.Block, erro
 	return nil, fmt.Errorf("block %d not found", nonce)
 }
 
+// GetRawBlockByNonce -
+func (mock *networkProviderMock) GetRawBlockByNonce(nonce uint64) (*api.Block, error) {
+	if mock.MockNextError != nil {
+		return nil, mock.MockNextError
+	}
+
+	block, ok := mock.MockRawBlocksByNonce[nonce]
+	if ok {
+		return block, nil
+	}
+
+	return nil, fmt.Errorf("block %d not found", nonce)
+}
+
 // GetBlockByHash -
 func (mock *networkProviderMock) GetBlockByHash(hash string) (*api.Block, error) {
 	if mock.MockNextError != nil {
@@ -230,11 +272,61 @@ func (mock *networkProviderMock) GetAccount(address string) (*resources.AccountO
 	return nil, fmt.Errorf("account %s not found", address)
 }
 
-func (mock *networkProviderMock) GetAccountBalance(address string, tokenIdentifier string, _ resources.AccountQueryOptions) (*resources.AccountBalanceOnBlock, error) {
+// GetHighestMempoolNonceOfSender -
+func (mock *networkProviderMock) GetHighestMempoolNonceOfSender(address string) (uint64, bool, error) {
+	if mock.MockNextError != nil {
+		return 0, false, mock.MockNextError
+	}
+
+	nonce, ok := mock.MockMempoolNoncesBySender[address]
+	return nonce, ok, nil
+}
+
+// ResolveUsername -
+func (mock *networkProviderMock) ResolveUsername(username string) (string, error) {
+	if mock.MockNextError != nil {
+		return "", mock.MockNextError
+	}
+
+	address, ok := mock.MockAddressesByUsername[username]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", resources.ErrUsernameNotFound, username)
+	}
+
+	return address, nil
+}
+
+// GetAccountGuardianData -
+func (mock *networkProviderMock) GetAccountGuardianData(address string) (*resources.AccountGuardianDataOnBlock, error) {
+	if mock.MockNextError != nil {
+		return nil, mock.MockNextError
+	}
+
+	if mock.GetAccountGuardianDataCalled != nil {
+		return mock.GetAccountGuardianDataCalled(address)
+	}
+
+	guardianData, ok := mock.MockAccountsGuardianData[address]
+	if !ok {
+		// Same as the observer, for accounts that aren't guarded.
+		guardianData = &api.GuardianData{}
+	}
+
+	return &resources.AccountGuardianDataOnBlock{
+		GuardianData:     *guardianData,
+		BlockCoordinates: *mock.MockNextAccountBlockCoordinates,
+	}, nil
+}
+
+func (mock *networkProviderMock) GetAccountBalance(address string, tokenIdentifier string, options resources.AccountQueryOptions) (*resources.AccountBalanceOnBlock, error) {
 	if mock.MockNextError != nil {
 		return nil, mock.MockNextError
 	}
 
+	if mock.GetAccountBalanceCalled != nil {
+		return mock.GetAccountBalanceCalled(address, tokenIdentifier, options)
+	}
+
 	isNativeBalance := tokenIdentifier == mock.MockNativeCurrencySymbol
 	if isNativeBalance {
 		accountBalance, ok := mock.MockAccountsNativeBalances[address]
@@ -358,6 +450,58 @@ func (mock *networkProviderMock) SendTransaction(tx *data.Transaction) (string,
 	return mock.MockComputedTransactionHash, nil
 }
 
+// SendTransactions -
+func (mock *networkProviderMock) SendTransactions(txs []*data.Transaction) (map[int]string, error) {
+	if mock.MockNextError != nil {
+		return nil, mock.MockNextError
+	}
+
+	if mock.SendTransactionsCalled != nil {
+		return mock.SendTransactionsCalled(txs)
+	}
+
+	hashes := make(map[int]string, len(txs))
+	for i := range txs {
+		hashes[i] = mock.MockComputedTransactionHash
+	}
+
+	return hashes, nil
+}
+
+// ComputeTransactionCost -
+func (mock *networkProviderMock) ComputeTransactionCost(tx *data.Transaction) (*resources.TransactionCost, error) {
+	if mock.MockNextError != nil {
+		return nil, mock.MockNextError
+	}
+
+	if mock.ComputeTransactionCostCalled != nil {
+		return mock.ComputeTransactionCostCalled(tx)
+	}
+
+	return &resources.TransactionCost{
+		GasUnits: mock.MockTransactionCostGasUnits,
+	}, nil
+}
+
+// SimulateTransaction -
+func (mock *networkProviderMock) SimulateTransaction(tx *data.Transaction) (*transaction.SimulationResults, error) {
+	if mock.MockNextError != nil {
+		return nil, mock.MockNextError
+	}
+
+	if mock.SimulateTransactionCalled != nil {
+		return mock.SimulateTransactionCalled(tx)
+	}
+
+	if mock.MockSimulationResults != nil {
+		return mock.MockSimulationResults, nil
+	}
+
+	return &transaction.SimulationResults{
+		Status: transaction.TxStatusSuccess,
+	}, nil
+}
+
 // GetMempoolTransactionByHash -
 func (mock *networkProviderMock) GetMempoolTransactionByHash(hash string) (*transaction.ApiTransactionResult, error) {
 	if mock.MockNextError != nil {
@@ -372,6 +516,43 @@ func (mock *networkProviderMock) GetMempoolTransactionByHash(hash string) (*tran
 	return nil, nil
 }
 
+// GetTransactionByHash -
+func (mock *networkProviderMock) GetTransactionByHash(hash string) (*transaction.ApiTransactionResult, error) {
+	if mock.MockNextError != nil {
+		return nil, mock.MockNextError
+	}
+
+	transactionObj, ok := mock.MockTransactionsByHash[hash]
+	if ok {
+		return transactionObj, nil
+	}
+
+	return nil, fmt.Errorf("transaction %s not found", hash)
+}
+
+// GetProcessedTransactionStatus -
+func (mock *networkProviderMock) GetProcessedTransactionStatus(hash string) (transaction.TxStatus, error) {
+	if mock.MockNextError != nil {
+		return "", mock.MockNextError
+	}
+
+	status, ok := mock.MockProcessedStatusesByHash[hash]
+	if ok {
+		return status, nil
+	}
+
+	transactionObj, ok := mock.MockTransactionsByHash[hash]
+	if !ok {
+		return "", fmt.Errorf("transaction %s not found", hash)
+	}
+
+	if transactionObj.Status == transaction.TxStatusInvalid {
+		return transaction.TxStatusFail, nil
+	}
+
+	return transactionObj.Status, nil
+}
+
 // IsReleaseSiriusActive -
 func (mock *networkProviderMock) IsReleaseSiriusActive(epoch uint32) bool {
 	return epoch >= mock.MockActivationEpochSirius
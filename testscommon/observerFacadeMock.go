@@ -15,6 +15,7 @@ type observerFacadeMock struct {
 	MockNumShards               uint32
 	MockSelfShard               uint32
 	MockGetResponse             interface{}
+	MockPostResponse            interface{}
 	MockAccount                 *data.AccountModel
 	MockComputedTransactionHash string
 	MockNextError               error
@@ -24,13 +25,15 @@ type observerFacadeMock struct {
 	MockTransactionsByHash map[string]*transaction.ApiTransactionResult
 	MockBlocks             []*api.Block
 
-	GetBlockByNonceCalled     func(shardID uint32, nonce uint64, options common.BlockQueryOptions) (*data.BlockApiResponse, error)
-	GetBlockByHashCalled      func(shardID uint32, hash string, options common.BlockQueryOptions) (*data.BlockApiResponse, error)
-	CallGetRestEndPointCalled func(baseUrl string, path string, value interface{}) (int, error)
-	SendTransactionCalled     func(tx *data.Transaction) (int, string, error)
+	GetBlockByNonceCalled      func(shardID uint32, nonce uint64, options common.BlockQueryOptions) (*data.BlockApiResponse, error)
+	GetBlockByHashCalled       func(shardID uint32, hash string, options common.BlockQueryOptions) (*data.BlockApiResponse, error)
+	CallGetRestEndPointCalled  func(baseUrl string, path string, value interface{}) (int, error)
+	CallPostRestEndPointCalled func(baseUrl string, path string, data interface{}, response interface{}) (int, error)
+	SendTransactionCalled      func(tx *data.Transaction) (int, string, error)
 
-	RecordedBaseUrl string
-	RecordedPath    string
+	RecordedBaseUrl     string
+	RecordedPath        string
+	RecordedPostPayload interface{}
 }
 
 // NewObserverFacadeMock -
@@ -77,6 +80,33 @@ func (mock *observerFacadeMock) CallGetRestEndPoint(baseUrl string, path string,
 	return 200, nil
 }
 
+// CallPostRestEndPoint -
+func (mock *observerFacadeMock) CallPostRestEndPoint(baseUrl string, path string, data interface{}, response interface{}) (int, error) {
+	mock.RecordedBaseUrl = baseUrl
+	mock.RecordedPath = path
+	mock.RecordedPostPayload = data
+
+	if mock.CallPostRestEndPointCalled != nil {
+		return mock.CallPostRestEndPointCalled(baseUrl, path, data, response)
+	}
+
+	if mock.MockNextError != nil {
+		return 0, mock.MockNextError
+	}
+
+	marshalledData, err := json.Marshal(mock.MockPostResponse)
+	if err != nil {
+		return 500, err
+	}
+
+	err = json.Unmarshal(marshalledData, response)
+	if err != nil {
+		return 500, err
+	}
+
+	return 200, nil
+}
+
 // ComputeShardId -
 func (mock *observerFacadeMock) ComputeShardId(pubKey []byte) uint32 {
 	shardCoordinator, err := sharding.NewMultiShardCoordinator(mock.MockNumShards, mock.MockSelfShard)
@@ -101,15 +131,6 @@ func (mock *observerFacadeMock) SendTransaction(tx *data.Transaction) (int, stri
 	return 200, mock.MockComputedTransactionHash, nil
 }
 
-// ComputeTransactionHash -
-func (mock *observerFacadeMock) ComputeTransactionHash(_ *data.Transaction) (string, error) {
-	if mock.MockNextError != nil {
-		return "", mock.MockNextError
-	}
-
-	return mock.MockComputedTransactionHash, nil
-}
-
 // GetTransactionByHashAndSenderAddress -
 func (mock *observerFacadeMock) GetTransactionByHashAndSenderAddress(hash string, _ string, _ bool) (*transaction.ApiTransactionResult, int, error) {
 	if mock.MockNextError != nil {
@@ -124,6 +145,25 @@ func (mock *observerFacadeMock) GetTransactionByHashAndSenderAddress(hash string
 	return nil, 0, fmt.Errorf("transaction %s not found", hash)
 }
 
+// GetProcessedTransactionStatus -
+func (mock *observerFacadeMock) GetProcessedTransactionStatus(txHash string) (*data.ProcessStatusResponse, error) {
+	if mock.MockNextError != nil {
+		return nil, mock.MockNextError
+	}
+
+	transactionObj, ok := mock.MockTransactionsByHash[txHash]
+	if !ok {
+		return nil, fmt.Errorf("transaction %s not found", txHash)
+	}
+
+	status := transactionObj.Status
+	if status == transaction.TxStatusInvalid {
+		status = transaction.TxStatusFail
+	}
+
+	return &data.ProcessStatusResponse{Status: string(status)}, nil
+}
+
 // GetBlockByHash -
 func (mock *observerFacadeMock) GetBlockByHash(shardID uint32, hash string, options common.BlockQueryOptions) (*data.BlockApiResponse, error) {
 	if mock.GetBlockByHashCalled != nil {